@@ -0,0 +1,215 @@
+// cmd/dedupe/main.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/jira"
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+	"github.com/Devon-ODell/PSDIv0.2/internal/paycor"
+	"github.com/joho/godotenv"
+)
+
+// Cluster outcomes in the dedupe report.
+const (
+	outcomePlanned   = "planned" // Dry run; nothing was changed
+	outcomeMerged    = "merged"
+	outcomeRepointed = "repointed" // A Paycor object key field now holds the survivor's key
+	outcomeFailed    = "failed"
+)
+
+// dedupeReport lists every duplicate cluster and what was, or would be, done about it.
+type dedupeReport struct {
+	GeneratedAt time.Time       `json:"generatedAt"`
+	ObjectType  string          `json:"objectType"`
+	Applied     bool            `json:"applied"`
+	Clusters    []clusterResult `json:"clusters"`
+
+	// WriteBacks lists the Paycor employees whose object key field (PAYCOR_OBJECT_KEY_FIELD)
+	// held the key of a merged-away asset. Only filled when WRITE_BACK_OBJECT_KEY is set.
+	WriteBacks []writeBackRepair `json:"writeBacks,omitempty"`
+}
+
+type clusterResult struct {
+	*jira.MergePlan
+	Key     string `json:"key"`
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+}
+
+// writeBackRepair is a Paycor employee whose object key field is, or would be, pointed from a
+// merged-away asset at the cluster's survivor.
+type writeBackRepair struct {
+	EmployeeID string `json:"employeeId"`
+	Email      string `json:"email,omitempty"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Outcome    string `json:"outcome"`
+	Error      string `json:"error,omitempty"`
+}
+
+// dedupe finds Employee assets that share an email or employee number, reports each cluster
+// with the attributes its assets disagree on and the survivor that would be kept, and with
+// -apply merges them: attributes the survivor lacks are copied onto it, references to the other
+// assets are repointed at it and the other assets are deleted. With WRITE_BACK_OBJECT_KEY set,
+// Paycor employees whose object key field held a deleted asset's key are pointed at the survivor.
+// Without -apply it only reports.
+func main() {
+	objectType := flag.String("object-type", "Employee", "Object type to deduplicate; only the Employee object type (JIRA_EMPLOYEE_OBJECT_TYPE_NAME) is supported")
+	apply := flag.Bool("apply", false, "Merge the clusters; without it the planned merges are only reported")
+	outPath := flag.String("out", "dedupe_report.json", "Where to write the JSON report; empty disables it")
+	target := flag.String("target", "", "Named Jira target, defined by JIRA_TARGET_<NAME>_* variables")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("INFO: No .env file found, relying on OS environment variables.")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("FATAL: Failed to load configuration: %v", err)
+	}
+	if *target != "" {
+		if err := cfg.ApplyJiraTarget(*target); err != nil {
+			log.Fatalf("FATAL: %v", err)
+		}
+	}
+	// "Employee" names the employee object type whatever it is called in the schema.
+	if !strings.EqualFold(*objectType, "Employee") && !strings.EqualFold(*objectType, cfg.Jira.JiraEmployeeObjectTypeName) {
+		log.Fatalf("FATAL: Object type '%s' is not supported; only the Employee object type (%s) can be deduplicated.", *objectType, cfg.Jira.JiraEmployeeObjectTypeName)
+	}
+	if cfg.Jira.JiraAttributeMapPath != "" {
+		loaded, err := models.LoadAttributeMap(cfg.Jira.JiraAttributeMapPath)
+		if err != nil {
+			log.Fatalf("FATAL: Failed to load attribute map: %v", err)
+		}
+		log.Printf("INFO: Loaded %d attribute IDs from %s.", loaded, cfg.Jira.JiraAttributeMapPath)
+	}
+
+	jiraClient, err := jira.NewClient(cfg.Jira)
+	if err != nil {
+		log.Fatalf("FATAL: Failed to initialize Jira client: %v", err)
+	}
+	ctx := context.Background()
+	clusters, err := jiraClient.FindDuplicateAssets(ctx, cfg.Features.StripEmailPlusTags)
+	if err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	if !*apply {
+		log.Println("INFO: DRY RUN: No assets will be changed. Re-run with -apply to merge.")
+	}
+
+	report := &dedupeReport{GeneratedAt: time.Now(), ObjectType: cfg.Jira.JiraEmployeeObjectTypeName, Applied: *apply}
+	failed := 0
+	repoint := make(map[string]string) // Merged-away object key -> survivor's object key
+	for _, cluster := range clusters {
+		result := clusterResult{Key: cluster.Key}
+		result.MergePlan, err = jiraClient.MergeAssets(ctx, cluster, *apply)
+		switch {
+		case err != nil:
+			log.Printf("ERROR: %v", err)
+			result.Outcome, result.Error = outcomeFailed, err.Error()
+			failed++
+		case *apply:
+			result.Outcome = outcomeMerged
+		default:
+			result.Outcome = outcomePlanned
+		}
+		if result.MergePlan != nil {
+			logPlan(result.MergePlan)
+			// A merge that failed part way may already have relinked or deleted duplicates, so
+			// Paycor follows the survivor in that case too.
+			for _, duplicate := range result.MergePlan.Duplicates {
+				repoint[duplicate.ObjectKey] = result.MergePlan.Survivor.ObjectKey
+			}
+		}
+		report.Clusters = append(report.Clusters, result)
+	}
+	log.Printf("INFO: [Dedupe] %d duplicate clusters (applied=%t, failed=%d).", len(clusters), *apply, failed)
+
+	if cfg.Paycor.PaycorWriteBackObjectKey && len(repoint) > 0 {
+		paycorClient, err := paycor.NewClient(ctx, cfg.Paycor)
+		if err != nil {
+			log.Fatalf("FATAL: Failed to initialize Paycor client: %v", err)
+		}
+		report.WriteBacks, err = repairWriteBacks(ctx, paycorClient, cfg.Paycor.PaycorObjectKeyField, repoint, *apply)
+		if err != nil {
+			log.Printf("ERROR: %v", err)
+			failed++
+		}
+		for _, repair := range report.WriteBacks {
+			if repair.Outcome == outcomeFailed {
+				failed++
+			}
+		}
+	}
+
+	if *outPath != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatalf("FATAL: Failed to marshal dedupe report: %v", err)
+		}
+		if err := os.WriteFile(*outPath, data, 0644); err != nil {
+			log.Fatalf("FATAL: Failed to write dedupe report to %s: %v", *outPath, err)
+		}
+		log.Printf("INFO: Dedupe report written to %s.", *outPath)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// logPlan writes a cluster's survivor, duplicates and differing attributes to the log.
+func logPlan(plan *jira.MergePlan) {
+	duplicates := make([]string, 0, len(plan.Duplicates))
+	for _, asset := range plan.Duplicates {
+		duplicates = append(duplicates, asset.ObjectKey)
+	}
+	log.Printf("INFO: [Dedupe] %s: keep %s, remove %s, copy %d attributes, relink %d objects and issues", plan.Key, plan.Survivor.ObjectKey, strings.Join(duplicates, ", "), len(plan.Copied), len(plan.Relinked))
+	for _, diff := range plan.Diffs {
+		values := make([]string, 0, len(diff.Values))
+		for _, asset := range append([]models.EmployeeAssets{plan.Survivor}, plan.Duplicates...) {
+			values = append(values, asset.ObjectKey+"='"+diff.Values[asset.ObjectKey]+"'")
+		}
+		log.Printf("INFO: [Dedupe]   %s differs: %s", diff.Attribute, strings.Join(values, " "))
+	}
+}
+
+// repairWriteBacks finds the Paycor employees whose object key field holds a key in repoint and,
+// with apply, sets the field to the survivor's key. Without apply they are only listed. The
+// error is for the Paycor fetch; a failed update is recorded on its repair.
+func repairWriteBacks(ctx context.Context, client *paycor.Client, field string, repoint map[string]string, apply bool) ([]writeBackRepair, error) {
+	employees, err := client.FetchAllEmployees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Paycor employees to repair object key write-backs: %w", err)
+	}
+	var repairs []writeBackRepair
+	for _, emp := range employees {
+		current, _ := emp.CustomFieldValue(field)
+		survivor, ok := repoint[strings.TrimSpace(current)]
+		if !ok {
+			continue
+		}
+		repair := writeBackRepair{EmployeeID: emp.ID, Email: emp.Email.EmailAddress, From: current, To: survivor, Outcome: outcomePlanned}
+		if !apply {
+			log.Printf("INFO: DRY RUN: [Dedupe] Would point Paycor field '%s' on employee %s from %s to %s.", field, emp.ID, current, survivor)
+		} else if err := client.UpdateEmployeeCustomField(ctx, emp.ID, field, survivor); err != nil {
+			log.Printf("ERROR: [Dedupe] Failed to point Paycor field '%s' on employee %s from %s to %s: %v", field, emp.ID, current, survivor, err)
+			repair.Outcome, repair.Error = outcomeFailed, err.Error()
+		} else {
+			log.Printf("INFO: [Dedupe] Pointed Paycor field '%s' on employee %s from %s to %s.", field, emp.ID, current, survivor)
+			repair.Outcome = outcomeRepointed
+		}
+		repairs = append(repairs, repair)
+	}
+	log.Printf("INFO: [Dedupe] %d Paycor employees held a merged-away object key.", len(repairs))
+	return repairs, nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/paycor"
+)
+
+func TestMain(m *testing.M) {
+	// The Paycor client logs every request; keep test output readable.
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestRepairWriteBacks(t *testing.T) {
+	const field = "Jira Asset Key"
+	// EMP-2 and EMP-3 were merged into EMP-1.
+	repoint := map[string]string{"EMP-2": "EMP-1", "EMP-3": "EMP-1"}
+	tests := []struct {
+		name       string
+		apply      bool
+		failUpdate bool
+		want       []writeBackRepair
+		wantPuts   map[string]string // Employee ID to the key written
+	}{
+		{
+			name: "dry run",
+			want: []writeBackRepair{
+				{EmployeeID: "2", Email: "jane.doe@example.com", From: "EMP-2", To: "EMP-1", Outcome: outcomePlanned},
+				{EmployeeID: "4", From: " EMP-3 ", To: "EMP-1", Outcome: outcomePlanned},
+			},
+			wantPuts: map[string]string{},
+		},
+		{
+			name:  "apply",
+			apply: true,
+			want: []writeBackRepair{
+				{EmployeeID: "2", Email: "jane.doe@example.com", From: "EMP-2", To: "EMP-1", Outcome: outcomeRepointed},
+				{EmployeeID: "4", From: " EMP-3 ", To: "EMP-1", Outcome: outcomeRepointed},
+			},
+			wantPuts: map[string]string{"2": "EMP-1", "4": "EMP-1"},
+		},
+		{
+			name:       "update fails",
+			apply:      true,
+			failUpdate: true,
+			want: []writeBackRepair{
+				{EmployeeID: "2", Email: "jane.doe@example.com", From: "EMP-2", To: "EMP-1", Outcome: outcomeFailed},
+				{EmployeeID: "4", From: " EMP-3 ", To: "EMP-1", Outcome: outcomeFailed},
+			},
+			wantPuts: map[string]string{"2": "EMP-1", "4": "EMP-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			puts := make(map[string]string)
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"access_token":"access","token_type":"Bearer","refresh_token":"refresh","expires_in":3600}`))
+			})
+			mux.HandleFunc("GET /legalentities/123456/employees", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"records":[
+					{"id":"1","customFields":[{"name":"Jira Asset Key","value":"EMP-1"}]},
+					{"id":"2","email":{"emailAddress":"jane.doe@example.com"},"customFields":[{"name":"jira asset key","value":"EMP-2"}]},
+					{"id":"3"},
+					{"id":"4","customFields":[{"name":"Jira Asset Key","value":" EMP-3 "}]}
+				]}`))
+			})
+			mux.HandleFunc("PUT /employees/{id}/customfields", func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					CustomFields []struct{ Name, Value string } `json:"customFields"`
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				if len(body.CustomFields) == 1 && body.CustomFields[0].Name == field {
+					puts[r.PathValue("id")] = body.CustomFields[0].Value
+				}
+				if tt.failUpdate {
+					http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				}
+			})
+			server := httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+			client, err := paycor.NewClient(context.Background(), config.PaycorConfig{
+				PaycorTokenURLBase:           server.URL + "/token",
+				PaycorAPIBaseURL:             server.URL,
+				PaycorClientID:               "client",
+				PaycorClientSecret:           "secret",
+				PaycorOcpApimSubscriptionKey: "subscription",
+				PaycorRefreshToken:           "refresh",
+				PaycorLegalEntityID:          "123456",
+				PaycorTokenAuthStyle:         config.PaycorTokenAuthQuery,
+				HTTP:                         config.HTTPClientConfig{Timeout: 10 * time.Second},
+			})
+			if err != nil {
+				t.Fatalf("paycor.NewClient: %v", err)
+			}
+
+			repairs, err := repairWriteBacks(context.Background(), client, field, repoint, tt.apply)
+			if err != nil {
+				t.Fatalf("repairWriteBacks: %v", err)
+			}
+			for i := range repairs {
+				if (repairs[i].Error != "") != (repairs[i].Outcome == outcomeFailed) {
+					t.Errorf("repair %d has outcome %s and error %q", i, repairs[i].Outcome, repairs[i].Error)
+				}
+				repairs[i].Error = ""
+			}
+			if !reflect.DeepEqual(repairs, tt.want) {
+				t.Errorf("repairs = %+v, want %+v", repairs, tt.want)
+			}
+			if !reflect.DeepEqual(puts, tt.wantPuts) {
+				t.Errorf("Paycor updates = %v, want %v", puts, tt.wantPuts)
+			}
+		})
+	}
+}
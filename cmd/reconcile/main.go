@@ -0,0 +1,72 @@
+// cmd/reconcile/main.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/jira"
+	"github.com/Devon-ODell/PSDIv0.2/internal/paycor"
+	"github.com/Devon-ODell/PSDIv0.2/internal/syncer"
+	"github.com/joho/godotenv"
+)
+
+// reconcile compares Paycor employees with Jira Employee assets and reports who is missing
+// from either side. It is read-only.
+func main() {
+	outPath := flag.String("out", "reconcile_report.json", "Where to write the JSON report; empty disables it")
+	target := flag.String("target", "", "Named Jira target, defined by JIRA_TARGET_<NAME>_* variables")
+	scope := flag.String("scope", "", "Only report employees matching this filter, as for the sync's -scope; combined with SYNC_FILTER")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("INFO: No .env file found, relying on OS environment variables.")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("FATAL: Failed to load configuration: %v", err)
+	}
+
+	if *target != "" {
+		if err := cfg.ApplyJiraTarget(*target); err != nil {
+			log.Fatalf("FATAL: %v", err)
+		}
+	}
+	cfg.AddSyncScope(*scope)
+
+	ctx := context.Background()
+	paycorClient, err := paycor.NewClient(ctx, cfg.Paycor)
+	if err != nil {
+		log.Fatalf("FATAL: Failed to initialize Paycor client: %v", err)
+	}
+	jiraClient, err := jira.NewClient(cfg.Jira)
+	if err != nil {
+		log.Fatalf("FATAL: Failed to initialize Jira client: %v", err)
+	}
+	engine, err := syncer.New(cfg, paycorClient, jiraClient, syncer.Options{DryRun: true})
+	if err != nil {
+		log.Fatalf("FATAL: Failed to initialize syncer: %v", err)
+	}
+
+	report, err := engine.Reconcile(ctx)
+	if err != nil {
+		log.Fatalf("FATAL: Reconcile failed: %v", err)
+	}
+	report.LogSummary()
+
+	if *outPath != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatalf("FATAL: Failed to marshal reconcile report: %v", err)
+		}
+		if err := os.WriteFile(*outPath, data, 0644); err != nil {
+			log.Fatalf("FATAL: Failed to write reconcile report to %s: %v", *outPath, err)
+		}
+		log.Printf("INFO: Reconcile report written to %s.", *outPath)
+	}
+}
@@ -0,0 +1,77 @@
+// cmd/runs/main.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/syncer"
+	"github.com/joho/godotenv"
+)
+
+// runs lists and inspects the sync runs kept in SYNC_HISTORY_DIR.
+//
+//	runs list [-limit 20]   one line per run, newest first
+//	runs show <run ID>      the run's full JSON report
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("INFO: No .env file found, relying on OS environment variables.")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("FATAL: Failed to load configuration: %v", err)
+	}
+	if cfg.SyncHistoryDir == "" {
+		log.Fatal("FATAL: SYNC_HISTORY_DIR is not set, so no run history is kept.")
+	}
+
+	if len(os.Args) < 2 {
+		log.Fatal("FATAL: Usage: runs list [-limit N] | runs show <run ID>")
+	}
+	switch os.Args[1] {
+	case "list":
+		listFlags := flag.NewFlagSet("list", flag.ExitOnError)
+		limit := listFlags.Int("limit", 20, "Number of runs to list; 0 lists all")
+		listFlags.Parse(os.Args[2:])
+		listRuns(cfg.SyncHistoryDir, *limit, cfg.DisplayTimezone)
+	case "show":
+		if len(os.Args) != 3 {
+			log.Fatal("FATAL: Usage: runs show <run ID>")
+		}
+		report, err := syncer.LoadRun(cfg.SyncHistoryDir, os.Args[2])
+		if err != nil {
+			log.Fatalf("FATAL: %v", err)
+		}
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatalf("FATAL: Failed to marshal run report: %v", err)
+		}
+		fmt.Println(string(data))
+	default:
+		log.Fatalf("FATAL: Unknown command '%s'. Usage: runs list [-limit N] | runs show <run ID>", os.Args[1])
+	}
+}
+
+func listRuns(dir string, limit int, displayTimezone *time.Location) {
+	runs, err := syncer.ListRuns(dir, limit)
+	if err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RUN ID\tSTATUS\tTARGET\tDRY RUN\tSTARTED\tDURATION\tCREATED\tUPDATED\tFAILED\tINVALID")
+	for _, run := range runs {
+		duration := "-"
+		if !run.FinishedAt.IsZero() {
+			duration = run.FinishedAt.Sub(run.StartedAt).Round(time.Second).String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%s\t%s\t%d\t%d\t%d\t%d\n",
+			run.RunID, run.Status, run.Target, run.DryRun, run.StartedAt.In(displayTimezone).Format(time.RFC3339), duration, run.Created, run.Updated, run.Failed, run.Invalid)
+	}
+	tw.Flush()
+}
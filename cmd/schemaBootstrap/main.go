@@ -0,0 +1,119 @@
+// cmd/schemaBootstrap/main.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/jira"
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+	"github.com/joho/godotenv"
+)
+
+// schemaBootstrap reads the Employee and Role object types' attributes from Jira, and the
+// Location object type's when JIRA_LOCATION_OBJECT_TYPE_ID is set, and prints
+// a ready-to-paste replacement for models.AttributeID. With -out it also writes a JSON file
+// that the sync loads at runtime via JIRA_ATTRIBUTE_MAP_PATH.
+func main() {
+	outPath := flag.String("out", "", "Also write the attribute map as JSON to this path")
+	target := flag.String("target", "", "Named Jira target, defined by JIRA_TARGET_<NAME>_* variables")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("INFO: No .env file found, relying on OS environment variables.")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("FATAL: Failed to load configuration: %v", err)
+	}
+
+	if *target != "" {
+		if err := cfg.ApplyJiraTarget(*target); err != nil {
+			log.Fatalf("FATAL: %v", err)
+		}
+	}
+	if cfg.Jira.JiraEmployeeObjectTypeID == "" || cfg.Jira.JiraRoleObjectTypeID == "" {
+		log.Fatal("FATAL: JIRA_EMPLOYEE_OBJECT_TYPE_ID and JIRA_ROLE_OBJECT_TYPE_ID must both be set.")
+	}
+
+	jiraClient, err := jira.NewClient(cfg.Jira)
+	if err != nil {
+		log.Fatalf("FATAL: Failed to initialize Jira client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	employeeAttributes, err := jiraClient.GetObjectTypeAttributes(ctx, cfg.Jira.JiraEmployeeObjectTypeID)
+	if err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	roleAttributes, err := jiraClient.GetObjectTypeAttributes(ctx, cfg.Jira.JiraRoleObjectTypeID)
+	if err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+
+	file := models.AttributeMapFile{
+		Employee: models.NewObjectTypeAttributeMap(cfg.Jira.JiraEmployeeObjectTypeID, employeeAttributes),
+		Role:     models.NewObjectTypeAttributeMap(cfg.Jira.JiraRoleObjectTypeID, roleAttributes),
+	}
+	if cfg.Jira.JiraLocationObjectTypeID != "" {
+		locationAttributes, err := jiraClient.GetObjectTypeAttributes(ctx, cfg.Jira.JiraLocationObjectTypeID)
+		if err != nil {
+			log.Fatalf("FATAL: %v", err)
+		}
+		location := models.NewObjectTypeAttributeMap(cfg.Jira.JiraLocationObjectTypeID, locationAttributes)
+		file.Location = &location
+	}
+
+	fmt.Print(goAttributeMap(file))
+
+	if *outPath != "" {
+		data, err := json.MarshalIndent(file, "", "  ")
+		if err != nil {
+			log.Fatalf("FATAL: Failed to marshal attribute map: %v", err)
+		}
+		if err := os.WriteFile(*outPath, data, 0644); err != nil {
+			log.Fatalf("FATAL: Failed to write attribute map to %s: %v", *outPath, err)
+		}
+		log.Printf("SUCCESS: Wrote attribute map to %s. Set JIRA_ATTRIBUTE_MAP_PATH to load it at runtime.", *outPath)
+	}
+}
+
+// goAttributeMap renders the attribute map as Go source in the shape of models.AttributeID,
+// with each attribute's type as a trailing comment.
+func goAttributeMap(file models.AttributeMapFile) string {
+	width := len(fmt.Sprintf("%q:", models.LocationNameAttribute))
+	for _, attr := range file.Employee.Attributes {
+		if w := len(fmt.Sprintf("%q:", attr.Name)); w > width {
+			width = w
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Employee object type %s, Role object type %s.\n", file.Employee.ObjectTypeID, file.Role.ObjectTypeID)
+	b.WriteString("var AttributeID = map[string]int{\n")
+	for _, attr := range file.Employee.Attributes {
+		fmt.Fprintf(&b, "\t%-*s %d, // %s\n", width, fmt.Sprintf("%q:", attr.Name), attr.ID, attr.Type)
+	}
+	for _, attr := range file.Role.Attributes {
+		if attr.Name == "Name" {
+			fmt.Fprintf(&b, "\t%-*s %d, // %s (Role object type)\n", width, fmt.Sprintf("%q:", models.RoleNameAttribute), attr.ID, attr.Type)
+		}
+	}
+	if file.Location != nil {
+		for _, attr := range file.Location.Attributes {
+			if attr.Name == "Name" {
+				fmt.Fprintf(&b, "\t%-*s %d, // %s (Location object type)\n", width, fmt.Sprintf("%q:", models.LocationNameAttribute), attr.ID, attr.Type)
+			}
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
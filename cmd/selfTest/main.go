@@ -0,0 +1,51 @@
+// cmd/selfTest/main.go
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+	"github.com/Devon-ODell/PSDIv0.2/internal/preflight"
+	"github.com/joho/godotenv"
+)
+
+// selfTest creates a throwaway Employee asset, finds it, updates it, verifies the update and
+// deletes it, printing PASS or FAIL for each step and exiting non-zero if any fails. Use it to
+// check a new Jira schema configuration before the first sync.
+func main() {
+	target := flag.String("target", "", "Named Jira target, defined by JIRA_TARGET_<NAME>_* variables")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("INFO: No .env file found, relying on OS environment variables.")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("FATAL: Failed to load configuration: %v", err)
+	}
+
+	if *target != "" {
+		if err := cfg.ApplyJiraTarget(*target); err != nil {
+			log.Fatalf("FATAL: %v", err)
+		}
+	}
+	if cfg.Jira.JiraAttributeMapPath != "" {
+		loaded, err := models.LoadAttributeMap(cfg.Jira.JiraAttributeMapPath)
+		if err != nil {
+			log.Fatalf("FATAL: Failed to load attribute map: %v", err)
+		}
+		log.Printf("INFO: Loaded %d attribute IDs from %s.", loaded, cfg.Jira.JiraAttributeMapPath)
+	}
+
+	results := preflight.SelfTest(context.Background(), cfg)
+	preflight.PrintTable(os.Stdout, results)
+
+	if !preflight.Passed(results) {
+		os.Exit(1)
+	}
+}
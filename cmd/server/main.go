@@ -2,24 +2,35 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"flag"
 	"log"
 	"os"
-	"strconv"
-	"time"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	// Import the new godotenv package
 	"github.com/joho/godotenv"
 
-	// Use your project's actual module path for internal packages
-	"github.com/Devon-ODell/PSDIv0.2/internal/config"
-	"github.com/Devon-ODell/PSDIv0.2/internal/jira"   // <-- IMPORT for Jira client
-	"github.com/Devon-ODell/PSDIv0.2/internal/models" // <-- IMPORT for shared data models
-	"github.com/Devon-ODell/PSDIv0.2/internal/paycor"
+	// The sync itself lives in pkg/psdi; this binary wires flags and signals to it.
+	"github.com/Devon-ODell/PSDIv0.2/internal/preflight"
+	"github.com/Devon-ODell/PSDIv0.2/pkg/psdi"
 )
 
 func main() {
+	dryRun := flag.Bool("dry-run", false, "Log the changes the sync would make without writing to Jira")
+	fields := flag.String("fields", "", "Comma-separated attribute names to restrict updates to (creates still send every attribute)")
+	resume := flag.Bool("resume", false, "Skip employees the interrupted previous run already completed (needs SYNC_CHECKPOINT_PATH)")
+	backfillPaycorIDs := flag.Bool("backfill-paycor-ids", false, "Instead of syncing, write the Paycor ID onto Jira assets that lack it, matched by email (honours -dry-run)")
+	limit := flag.Int("limit", 0, "Sync only the first N employees, for smoke-testing a mapping change; 0 syncs everyone")
+	target := flag.String("target", "", "Named Jira target to sync to, defined by JIRA_TARGET_<NAME>_* variables (e.g. staging, prod)")
+	scope := flag.String("scope", "", "Only sync employees matching this filter, e.g. \"department=Engineering\" or \"location=Austin; legalentity=123\"; combined with SYNC_FILTER")
+	validateOnly := flag.Bool("validate-only", false, "Instead of syncing, validate every Paycor employee in scope and report the failures; Jira is not contacted")
+	runPreflight := flag.Bool("preflight", false, "Run the cmd/verify permission checks first and stop if a required one fails")
+	debugEmails := flag.String("debug-email", "", "Comma-separated employee emails whose Jira requests, responses and diff are written to a debug bundle in SYNC_DEBUG_DIR; added to SYNC_DEBUG_EMAILS")
+	diffOut := flag.String("diff-out", "", "With -dry-run, write the per-employee attribute diff to this path (.json for JSON, otherwise text); overrides SYNC_DRY_RUN_DIFF_PATH")
+	flag.Parse()
+
 	// Load .env file. Not fatal if it doesn't exist.
 	err := godotenv.Load()
 	if err != nil {
@@ -33,202 +44,117 @@ func main() {
 	// =========================================================================
 	// Configuration Loading
 	// =========================================================================
-	cfg, err := config.Load()
+	cfg, err := psdi.LoadConfig()
 	if err != nil {
 		log.Fatalf("FATAL: Failed to load configuration: %v", err)
 	}
 	log.Println("INFO: Configuration loaded successfully.")
 
-	// Create a background context for our API calls
-	ctx := context.Background()
-
-	// =========================================================================
-	// Paycor Data Extraction
-	// =========================================================================
-	// Initialize Paycor client
-	paycorClient, err := paycor.NewClient(ctx, cfg.Paycor)
-	if err != nil {
-		log.Fatalf("FATAL: Failed to initialize Paycor client: %v", err)
+	if *target != "" {
+		if err := cfg.ApplyJiraTarget(*target); err != nil {
+			log.Fatalf("FATAL: %v", err)
+		}
 	}
-	log.Println("INFO: Paycor client initialized successfully.")
-
-	// Fetch all employees from Paycor
-	log.Println("INFO: Attempting to fetch all employees from Paycor...")
-	startTime := time.Now()
-	employees, err := paycorClient.FetchAllEmployees(ctx)
-	if err != nil {
-		log.Fatalf("FATAL: Failed to fetch employees from Paycor: %v", err)
+	cfg.AddSyncScope(*scope)
+	if *diffOut != "" {
+		cfg.SyncDryRunDiffPath = *diffOut
 	}
-	duration := time.Since(startTime)
-	log.Printf("INFO: Successfully fetched %d employees from Paycor in %v.", len(employees), duration)
-
-	// If no employees are found, there's nothing to sync. Exit gracefully.
-	if len(employees) == 0 {
-		log.Println("INFO: No employees found in Paycor. Nothing to sync to Jira. Exiting.")
-		return
+	if *dryRun {
+		cfg.Features.DryRun = true
 	}
+	cfg.SyncDebugEmails = append(cfg.SyncDebugEmails, strings.Split(*debugEmails, ",")...)
+	log.Printf("INFO: Features: %s", cfg.FeatureSummary())
 
-	// Optional: Save the fetched data to a local JSON file for debugging
-	saveDataToFile("paycor_employees.json", employees)
+	if cfg.Jira.JiraAttributeMapPath != "" {
+		loaded, err := psdi.LoadAttributeMap(cfg.Jira.JiraAttributeMapPath)
+		if err != nil {
+			log.Fatalf("FATAL: Failed to load attribute map: %v", err)
+		}
+		log.Printf("INFO: Loaded %d attribute IDs from %s.", loaded, cfg.Jira.JiraAttributeMapPath)
+	}
 
-	// =========================================================================
-	// Jira Integration and Syncing
-	// =========================================================================
-	log.Println("INFO: Beginning Jira integration phase...")
+	// Cancel the run on SIGINT/SIGTERM (e.g. pod eviction) so progress is checkpointed.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// 1. Initialize Jira Client using the Jira-specific config
-	jiraClient, err := jira.NewClient(cfg.Jira)
-	if err != nil {
-		log.Fatalf("FATAL: Failed to initialize Jira client: %v", err)
+	if *runPreflight {
+		results := preflight.Run(ctx, cfg)
+		preflight.PrintTable(os.Stderr, results)
+		if !preflight.Passed(results) {
+			log.Fatal("FATAL: Preflight checks failed. Fix the failures above or run without -preflight.")
+		}
+		log.Println("INFO: Preflight checks passed.")
 	}
-	log.Println("INFO: Jira client initialized successfully.")
 
-	// 2. Fetch all existing Employee Assets from Jira
-	// This is done once to avoid making a request for every single employee in the loop.
-	log.Println("INFO: Fetching all existing employee assets from Jira for comparison...")
-	existingJiraAssets, err := jiraClient.GetAllEmployeeAssets(ctx)
+	// =========================================================================
+	// Client Initialization
+	// =========================================================================
+	opts := psdi.Options{DryRun: cfg.Features.DryRun, Resume: *resume, Limit: *limit, Fields: strings.Split(*fields, ",")}
+	paycorClient, err := psdi.NewPaycorClient(ctx, cfg)
 	if err != nil {
-		log.Fatalf("FATAL: Failed to get employee assets from Jira: %v", err)
+		log.Fatalf("FATAL: Failed to initialize Paycor client: %v", err)
 	}
-	log.Printf("INFO: Found %d existing employee assets in Jira.", len(existingJiraAssets))
-
-	// 3. Create a map for efficient lookups using the employee's email as a unique key.
-	jiraAssetsMap := make(map[string]models.EmployeeAssets)
-	for _, asset := range existingJiraAssets {
-		// This is the correct way to get the email
-		if email := findEmailInAttributes(asset.Attributes); email != "" {
-			jiraAssetsMap[email] = asset
-		}
+	if err := paycorClient.CheckAccess(ctx); err != nil {
+		log.Fatalf("FATAL: Paycor access check failed: %v", err)
 	}
+	log.Println("INFO: Paycor client initialized successfully.")
 
-	// 4. Loop through Paycor employees and sync to Jira
-	log.Println("INFO: Starting sync process for each Paycor employee...")
-	for _, emp := range employees {
-		log.Printf("INFO: Processing Paycor employee: %s %s (Email: %s)", emp.FirstName, emp.LastName, emp.Email.EmailAddress)
-
-		roleKey, err := jiraClient.FindOrCreateRole(ctx, emp.PositionData.JobTitle)
+	if *validateOnly {
+		// Validation only reads Paycor, so no Jira client is needed.
+		engine, err := psdi.NewWithClients(cfg, paycorClient, nil, psdi.Options{DryRun: true})
 		if err != nil {
-			log.Printf("ERROR: Could not find or create Jira Role for '%s'. Skipping this employee. Error: %v", emp.PositionData.JobTitle, err)
-			continue // Skip to the next employee
+			log.Fatalf("FATAL: Invalid sync options: %v", err)
 		}
-		if roleKey == "" {
-			log.Printf("WARN: No role key was found or created for job title '%s'. The 'Job Role' field will be empty.", emp.PositionData.JobTitle)
+		validation, err := engine.Validate(ctx)
+		if err != nil {
+			log.Fatalf("FATAL: Validation failed: %v", err)
 		}
-
-		// Map Paycor data to the structure Jira expects
-		jiraAssetData := mapPaycorToJiraAsset(emp, roleKey)
-
-		// Check if an asset with this email already exists in our map
-		existingAsset, exists := jiraAssetsMap[emp.Email.EmailAddress]
-
-		if exists {
-			// UPDATE: The asset already exists, so we update it.
-			log.Printf("INFO: Employee exists in Jira. Updating asset ID %s.", existingAsset.ID)
-			err = jiraClient.UpdateEmployeeAsset(ctx, existingAsset.ID, jiraAssetData)
-			if err != nil {
-				log.Printf("ERROR: Failed to update Jira asset for employee %s: %v", emp.ID, err)
-			} else {
-				log.Printf("SUCCESS: Successfully updated Jira asset for employee %s.", emp.ID)
-			}
-		} else {
-			// CREATE: The asset does not exist, so we create a new one.
-			log.Println("INFO: Employee does not exist in Jira. Creating new asset.")
-			newAssetID, err := jiraClient.CreateEmployeeAsset(ctx, jiraAssetData)
-			if err != nil {
-				log.Printf("ERROR: Failed to create Jira asset for employee %s: %v", emp.ID, err)
-			} else {
-				log.Printf("SUCCESS: Successfully created new Jira asset for employee %s with ID %s.", emp.ID, newAssetID)
-			}
+		validation.LogSummary()
+		if len(validation.Invalid) > 0 {
+			log.Fatalf("FATAL: %d employees failed validation and would not be synced.", len(validation.Invalid))
 		}
+		log.Println("INFO: Validation finished. Exiting.")
+		return
 	}
 
-	log.Println("INFO: Jira integration phase completed.")
-	log.Println("INFO: Process finished successfully. Exiting.")
-}
+	jiraClient, err := psdi.NewJiraClient(cfg)
+	if err != nil {
+		log.Fatalf("FATAL: Failed to initialize Jira client: %v", err)
+	}
+	log.Println("INFO: Jira client initialized successfully.")
 
-// mapPaycorToJiraAsset converts a Paycor employee object to the Jira EmployeeAssets model.
-// !!! IMPORTANT !!!
-// You MUST customize the map keys (e.g., "Name", "First Name", "Last Name") to match
-//
-// mapPaycorToJiraAsset converts a Paycor employee object to the Jira EmployeeAssets model.
-// This function now builds the correct []AssetAttribute slice structure.
-func mapPaycorToJiraAsset(employee models.Employee, roleKey string) models.EmployeeAssets {
-	// !!! IMPORTANT !!!
-	// The 'ObjectTypeAttributeID' values below (e.g., "1086", "1093") are based on the
-	// 'jiraAssetMap.go' file you provided. You MUST verify these IDs are correct
-	// for your specific Jira Assets schema. You can find them in the Jira UI
-	// when configuring your object schema.
-	return models.EmployeeAssets{
-		Attributes: []models.AssetAttribute{
-			{
-				ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Name"]), // "1086"
-				Values: []models.Value{
-					{Value: fmt.Sprintf("%s %s", employee.FirstName, employee.LastName)},
-				},
-			},
-			{
-				ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Email"]), // "1093"
-				Values: []models.Value{
-					{Value: employee.Email.EmailAddress},
-				},
-			},
-			{
-				ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Start Date"]), // "1095"
-				Values: []models.Value{
-					{Value: employee.EmploymentDateData.HireDate},
-				},
-			},
-			{
-				ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Status"]), // "1096"
-				Values: []models.Value{
-					// This assumes you have a selectable status of "Active" in Jira.
-					{Value: "Active"},
-				},
-			},
-			{
-				ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Job Role"]), // "1091"
-				Values: []models.Value{
-					{Value: roleKey},
-				},
-			},
-		},
+	engine, err := psdi.NewWithClients(cfg, paycorClient, jiraClient, opts)
+	if err != nil {
+		log.Fatalf("FATAL: Invalid sync options: %v", err)
 	}
-}
 
-// findEmailInAttributes is a helper function to locate an email value within the Attributes slice.
-func findEmailInAttributes(attributes []models.AssetAttribute) string {
-	// Get the static ID for the "Email" attribute from our map
-	emailAttributeID := strconv.Itoa(models.AttributeID["Email"])
+	// Catch schema drift before we fire hundreds of failing writes.
+	if err := engine.CheckSchema(ctx); err != nil {
+		log.Fatalf("FATAL: Jira attribute schema validation failed: %v", err)
+	}
 
-	for _, attr := range attributes {
-		if attr.ObjectTypeAttributeID == emailAttributeID {
-			if len(attr.Values) > 0 {
-				return attr.Values[0].Value
-			}
+	// =========================================================================
+	// Sync
+	// =========================================================================
+	if *backfillPaycorIDs {
+		backfill, err := engine.BackfillPaycorIDs(ctx)
+		if err != nil {
+			log.Fatalf("FATAL: Paycor ID backfill failed: %v", err)
 		}
+		backfill.LogSummary()
+		log.Println("INFO: Backfill finished. Exiting.")
+		return
 	}
-	return ""
-}
 
-// saveDataToFile is a helper function to write data to a file for debugging.
-func saveDataToFile(filePath string, data interface{}) {
-	log.Printf("INFO: Attempting to save data to file: %s", filePath)
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+	// Interrupted runs still log their partial summary, then exit non-zero.
+	report, err := engine.Run(ctx)
+	report.LogSummary()
 	if err != nil {
-		log.Printf("WARN: Failed to marshal data to JSON for saving: %v", err)
-		return
+		log.Fatalf("FATAL: Sync failed: %v", err)
 	}
-	err = os.WriteFile(filePath, jsonData, 0644)
-	if err != nil {
-		log.Printf("WARN: Failed to write data to file '%s': %v", filePath, err)
-	} else {
-		log.Printf("INFO: Data successfully saved to %s", filePath)
+	if report.DeadlineExceeded {
+		log.Fatal("FATAL: Sync stopped at its time budget before finishing. Run again with -resume to continue.")
 	}
-}
 
-func safeSubstring(s string, length int) string {
-	if len(s) < length {
-		return s
-	}
-	return s[:length]
+	log.Println("INFO: Process finished successfully. Exiting.")
 }
@@ -44,7 +44,7 @@ func main() {
 	newRoleName := fmt.Sprintf("Test Role %d", time.Now().Unix())
 	log.Printf("INFO: Attempting to create a new Role asset named: '%s'", newRoleName)
 
-	roleObjectKey, err := jiraClient.FindOrCreateRole(ctx, newRoleName)
+	roleObjectKey, _, err := jiraClient.FindOrCreateRole(ctx, newRoleName)
 	if err != nil {
 		log.Fatalf("FATAL: Failed to create role asset '%s': %v", newRoleName, err)
 	}
@@ -66,7 +66,7 @@ func main() {
 		log.Fatal("FATAL: JIRA_ASSET_OBJECT_KEY_CUSTOM_FIELD_ID is not set in the configuration.")
 	}
 
-	issueResponse, err := jiraClient.CreateIssueWithAsset(ctx, cfg.Jira.JiraTestProjectKey, issueSummary, issueDescription, assetCustomFieldID, roleObjectKey)
+	issueResponse, err := jiraClient.CreateIssueWithAsset(ctx, cfg.Jira.JiraTestProjectKey, issueSummary, issueDescription, assetCustomFieldID, roleObjectKey, jira.CreateIssueOptions{})
 	if err != nil {
 		log.Fatalf("FATAL: Failed to create Jira issue: %v", err)
 	}
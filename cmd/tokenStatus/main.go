@@ -0,0 +1,67 @@
+// cmd/tokenStatus/main.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/paycor"
+	"github.com/joho/godotenv"
+)
+
+// tokenStatus exchanges the Paycor refresh token for an access token, without running a sync,
+// and prints the access token's expiry, whether the refresh token was rotated and the refresh
+// history recorded in PAYCOR_TOKEN_STATE_PATH. It exits non-zero if the refresh fails.
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("INFO: No .env file found, relying on OS environment variables.")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("FATAL: Failed to load configuration: %v", err)
+	}
+
+	paycorClient, err := paycor.NewClient(context.Background(), cfg.Paycor)
+	if err != nil {
+		log.Fatalf("FATAL: Failed to initialize Paycor client: %v", err)
+	}
+
+	status, refreshErr := paycorClient.TokenStatus()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if refreshErr != nil {
+		fmt.Fprintf(tw, "Refresh\tFAILED: %v\n", refreshErr)
+	} else {
+		fmt.Fprintln(tw, "Refresh\tOK")
+		fmt.Fprintf(tw, "Access token expires\t%s (in %v)\n", formatTime(status.AccessTokenExpiry, cfg.DisplayTimezone), time.Until(status.AccessTokenExpiry).Round(time.Second))
+	}
+	fmt.Fprintf(tw, "Refresh token rotated\t%t\n", status.Rotated)
+	fmt.Fprintf(tw, "Last successful refresh\t%s\n", formatTime(status.State.LastSuccessfulRefresh, cfg.DisplayTimezone))
+	fmt.Fprintf(tw, "Last rotation\t%s\n", formatTime(status.State.LastRotation, cfg.DisplayTimezone))
+	fmt.Fprintf(tw, "Last failure\t%s\n", formatTime(status.State.LastFailure, cfg.DisplayTimezone))
+	fmt.Fprintf(tw, "Consecutive failures\t%d\n", status.State.ConsecutiveFailures)
+	if cfg.Paycor.PaycorTokenStatePath == "" {
+		fmt.Fprintln(tw, "History\tnot tracked (PAYCOR_TOKEN_STATE_PATH is empty)")
+	}
+	tw.Flush()
+
+	if status.Rotated {
+		log.Println("WARN: Paycor issued a new refresh token. Replace PAYCOR_REFRESH_TOKEN with the value logged above before the next run.")
+	}
+	if refreshErr != nil {
+		os.Exit(1)
+	}
+}
+
+func formatTime(t time.Time, displayTimezone *time.Location) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.In(displayTimezone).Format(time.RFC3339)
+}
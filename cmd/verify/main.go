@@ -0,0 +1,42 @@
+// cmd/verify/main.go
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/preflight"
+	"github.com/joho/godotenv"
+)
+
+// verify runs the preflight checks and exits non-zero if any required check fails.
+// Nothing is written except the throwaway object of the optional Assets write check.
+func main() {
+	target := flag.String("target", "", "Named Jira target, defined by JIRA_TARGET_<NAME>_* variables")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("INFO: No .env file found, relying on OS environment variables.")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("FATAL: Failed to load configuration: %v", err)
+	}
+
+	if *target != "" {
+		if err := cfg.ApplyJiraTarget(*target); err != nil {
+			log.Fatalf("FATAL: %v", err)
+		}
+	}
+
+	results := preflight.Run(context.Background(), cfg)
+	preflight.PrintTable(os.Stdout, results)
+
+	if !preflight.Passed(results) {
+		os.Exit(1)
+	}
+}
@@ -4,6 +4,8 @@ go 1.23.0
 
 toolchain go1.23.9
 
-require golang.org/x/oauth2 v0.30.0
-
-require github.com/joho/godotenv v1.5.1
+require (
+	github.com/joho/godotenv v1.5.1
+	go.uber.org/goleak v1.3.0
+	golang.org/x/oauth2 v0.30.0
+)
@@ -1,13 +1,50 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
+// Version is the integration version reported in the default User-Agent.
+const Version = "0.2"
+
+// DefaultUserAgent identifies our traffic in Atlassian and Paycor logs when PSDI_USER_AGENT is not set.
+const DefaultUserAgent = "PSDI/" + Version + " (Paycor-Jira Assets sync)"
+
+// HTTPClientConfig controls how the outbound HTTP clients for Paycor and Jira are built.
+// Zero values keep Go's default transport behavior.
+type HTTPClientConfig struct {
+	Timeout       time.Duration // Overall per-request timeout
+	DialTimeout   time.Duration // TCP connect timeout
+	TLSMinVersion string        // "1.2" or "1.3"; empty uses Go's default
+	CABundlePath  string        // PEM file of additional trusted CAs, e.g. for an egress proxy
+	ProxyURL      string        // Explicit proxy (HTTP_PROXY_URL, or PAYCOR_/JIRA_PROXY_URL per client); empty falls back to HTTP(S)_PROXY
+	MaxIdleConns  int
+
+	// Connection reuse. The sync makes a burst of sequential calls to one host per client, and
+	// Go's default of 2 idle connections per host is too few once retries or paging overlap.
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+
+	// Transient failures are retried up to MaxRetries times per request, starting at RetryBackoff
+	// and doubling. RetryBudget caps retries across the whole run, per client; negative is unlimited.
+	MaxRetries   int
+	RetryBackoff time.Duration
+	RetryBudget  int
+
+	// InsecureSkipVerify disables TLS certificate verification entirely. Only for diagnosing
+	// an intercepting proxy; use CABundlePath for a private CA instead.
+	InsecureSkipVerify bool
+}
+
 type PaycorConfig struct {
 	// Paycor Configuration
+	PaycorEnvironment            string // "production", "sandbox" or "custom" (default); named environments fill in the URLs below
 	PaycorClientID               string
 	PaycorClientSecret           string
 	PaycorOcpApimSubscriptionKey string // Updated field name
@@ -16,10 +53,71 @@ type PaycorConfig struct {
 	PaycorAPIBaseURL             string
 	PaycorLegalEntityID          string
 	PaycorScopes                 []string
+	PaycorTokenAuthStyle         string // "query" (default), "header" or "both"; how the token request carries the subscription key
+	PaycorPagesInFlight          int    // Pages buffered between the fetch and decode stages and the consumer
+	PaycorMaxPages               int    // Upper bound on pages fetched in one run, as protection against a runaway continuation loop
+	PaycorMaxEmptyPages          int    // Consecutive empty pages with a continuation token that are treated as a fetch failure
+	PaycorMaxDecodeFailures      int    // Employee records that may fail to decode before the fetch aborts; negative means no limit
+	PaycorWriteBackObjectKey     bool   // After a Jira create/update, store the asset's object key on the Paycor employee
+	PaycorObjectKeyField         string // Name of the Paycor custom field that receives the object key
+	UserAgent                    string // User-Agent header sent on every Paycor request
+	HTTP                         HTTPClientConfig
+
+	// PaycorTimezone is the zone Paycor timestamps sent without an offset are read in
+	// (PAYCOR_TIMEZONE, default UTC), e.g. "America/New_York".
+	PaycorTimezone *time.Location
+
+	// Token Health
+	PaycorTokenStatePath            string        // File recording refresh successes and failures across runs; empty disables tracking
+	PaycorTokenRefreshWarnAfter     time.Duration // Warn when the last successful token refresh is older than this
+	PaycorTokenFailureEscalateAfter int           // Consecutive refresh failures after which they are logged as an alert
 }
 
+// Jira deployment types. Cloud is the default.
+const (
+	JiraDeploymentCloud  = "cloud"
+	JiraDeploymentServer = "server" // Jira Server / Data Center with on-prem Assets (Insight)
+)
+
+// Formats for the Jira "Name" attribute (and therefore the asset label).
+const (
+	NameFormatFirstLast     = "first-last"     // "First Last"
+	NameFormatPreferredLast = "preferred-last" // "Preferred Last", falling back to the legal first name
+	NameFormatLastFirst     = "last-first"     // "Last, First"
+	NameFormatMiddleInitial = "first-m-last"   // "First M. Last", or "First Last" without a middle name
+)
+
+// Ways of sending the subscription key to the Paycor token endpoint (see PaycorConfig.PaycorTokenAuthStyle).
+const (
+	PaycorTokenAuthQuery  = "query"  // subscription-key query parameter
+	PaycorTokenAuthHeader = "header" // Ocp-Apim-Subscription-Key header, expected by newer token endpoints
+	PaycorTokenAuthBoth   = "both"
+)
+
+// Write policies control when an attribute may be written (see JiraConfig.JiraWritePolicies).
+const (
+	WritePolicyAlways     = "always"      // Written on create and whenever it differs
+	WritePolicyCreateOnly = "create-only" // Written on create, never updated afterwards
+	WritePolicyIfEmpty    = "if-empty"    // Updated only while the asset has no value for it
+)
+
+// Ways to read the free-text manager name Paycor sends.
+const (
+	ManagerNameFormatAuto = "auto"  // "LAST, FIRST" is reordered to "First Last"; other names are kept
+	ManagerNameFormatAsIs = "as-is" // Only whitespace is tidied
+)
+
+// Jira authentication modes.
+const (
+	JiraAuthBasic  = "basic"  // Email + API token
+	JiraAuthBearer = "bearer" // Authorization: Bearer <token>, e.g. a PAT, scoped token or OAuth access token
+)
+
 type JiraConfig struct {
 	// Jira Configuration
+	JiraDeploymentType         string // "cloud" or "server"; selects API base paths and authentication
+	JiraAuthMode               string // "basic" or "bearer"; defaults to basic on Cloud and bearer on Server
+	JiraBearerToken            string // Token sent with the bearer auth mode
 	JiraAssetsURL              string // Base URL for Jira (e.g., https://your-domain.atlassian.net)
 	JiraAdminEmail             string
 	JiraOrgAPIKey              string
@@ -31,6 +129,10 @@ type JiraConfig struct {
 	JiraRoleObjectTypeName     string
 	JiraRoleObjectTypeID       string
 
+	// Locations, mapped to the Employee "Location" reference when the attribute map has one
+	JiraLocationObjectTypeName string // Name of the Location object type, e.g. "Location"
+	JiraLocationObjectTypeID   string // Location objects are created in this type; empty disables location references
+
 	// Jira Issue Creation & Linking Configuration
 	JiraTestProjectKey            string // Project key for creating linked Jira issues (e.g., "TEST")
 	JiraIssueTypeNameForAsset     string // Name of the issue type to create (e.g., "Task", "Story")
@@ -38,6 +140,72 @@ type JiraConfig struct {
 	JiraLinkTypeNameToAsset       string // Name of the issue link type (e.g., "Relates to", "Impacts")
 	JiraLinkTypeIDToAsset         string // Discovered or set via env
 	JiraAssetObjectKeyCustomField string // Custom field ID for storing Asset Object Key on Jira issue (e.g. "customfield_10050")
+	JiraOnboardingProjectKey      string // Project key for rehire onboarding issues; empty disables them
+	JiraFollowUpProjectKey        string // Project key for per-category follow-up issues about sync failures; empty disables them
+	JiraFollowUpIssueType         string // Issue type name for follow-up issues
+
+	// Rehire Onboarding Issues
+	JiraOnboardingTemplatePath string   // File holding a Go template for the onboarding issue description; empty uses the built-in text
+	JiraOnboardingLabels       []string // Labels added to onboarding issues
+	JiraOnboardingAssigneeID   string   // Atlassian account ID onboarding issues are assigned to; empty leaves them unassigned
+	JiraOnboardingDueDays      int      // Days after creation an onboarding issue is due; 0 sets no due date
+
+	// Attribute Mapping
+	JiraNameFormat   string // "first-last" (default), "preferred-last", which uses the preferred first name when set, "last-first" or "first-m-last"
+	JiraNameTemplate string // Go template for the display name, e.g. "{{or .PreferredName .FirstName}} {{.LastName}}"; overrides JiraNameFormat
+
+	JiraManagerNameFormat string // "auto" (default) or "as-is"; how Paycor's free-text manager name is normalized
+
+	// JiraWritePolicies maps attribute names to a write policy; attributes not listed are "always".
+	JiraWritePolicies map[string]string
+
+	// Create Handling
+	JiraCreateConflictFallback  bool // On a duplicate/409 create, look the employee up by email and update instead
+	JiraAutoCreateSelectOptions bool // Add missing select options to the attribute and retry the write once
+
+	// Rehire Handling
+	JiraRehireDateAttribute string // Attribute name (from models.AttributeID) that receives the rehire date; empty disables it
+
+	// Schema
+	JiraPaycorIDAttribute string // Employee attribute holding the Paycor employee ID, filled by the -backfill-paycor-ids mode
+	JiraAttributeMapPath  string // JSON file written by cmd/schemaBootstrap that overrides models.AttributeID; empty uses the built-in map
+
+	// Preflight
+	JiraSandboxObjectTypeID string // Object type the preflight check creates and deletes a test object in; empty skips the write check
+
+	// Source of Truth Markers
+	JiraLastSyncedAttribute string // Datetime attribute stamped with the sync time on every write; empty disables it
+	JiraManagedByAttribute  string // Attribute flagging assets the sync manages; empty disables it
+	JiraManagedByValue      string // Value written to JiraManagedByAttribute
+
+	// Change Comments
+	JiraChangeComments          bool     // Comment on an asset when a sync changes one of JiraChangeCommentAttributes
+	JiraChangeCommentAttributes []string // Attribute names whose changes are significant enough to comment on
+	JiraChangeCommentMaxObjects int      // Skip every comment in a run that changed more assets than this, e.g. a reorg; 0 means no limit
+
+	// Rate Limiting
+	JiraRateLimitMinRemaining int // X-RateLimit-Remaining at or below which requests wait for the window to reset; 0 disables
+
+	JiraTarget string // Named target selected with -target (see ApplyJiraTarget); empty for the unprefixed settings
+
+	UserAgent string // User-Agent header sent on every Jira request
+	HTTP      HTTPClientConfig
+}
+
+// OrgAdminConfig configures the Atlassian organization admin API, used to suspend the Atlassian
+// accounts of terminated employees. It authenticates with an organization API key, separate
+// from the Jira credentials.
+type OrgAdminConfig struct {
+	AtlassianOrgID         string // ATLASSIAN_ORG_ID
+	AtlassianOrgAdminToken string // Organization admin API key, ATLASSIAN_ORG_ADMIN_TOKEN
+	AtlassianAdminURL      string // Base URL of the admin API
+
+	// Account Suspension
+	AccountSuspensionEnabled bool // Suspend the Atlassian account of every terminated employee in scope; off by default
+	AccountSuspensionMaxRun  int  // Suspensions refused for the rest of a run once this many were made; must be positive
+
+	UserAgent string // User-Agent header sent on every admin API request
+	HTTP      HTTPClientConfig
 }
 
 // --- Configuration Struct (Combined for Paycor and Jira) ---
@@ -45,21 +213,64 @@ type AppConfig struct {
 	// Paycor Configuration
 	Paycor PaycorConfig // Embedded PaycorConfig struct for modularity
 	Jira   JiraConfig   // Embedded JiraConfig struct for modularity
+	// Atlassian Organization Administration
+	OrgAdmin OrgAdminConfig
 	// General
-	LogFilePath string
+	LogFilePath     string
+	DebugDumpPath   string         // Where the fetched Paycor employees are saved for debugging; empty (the default) disables the dump
+	DisplayTimezone *time.Location // Zone run and token timestamps are shown in (DISPLAY_TIMEZONE, default the local zone); reports store UTC
+
+	// Features are the sync engine's on/off switches; see features.go.
+	Features Features
+
+	SyncMaxDuration      time.Duration // Overall time budget for a run; 0 means unbounded
+	SyncCheckpointPath   string        // State file recording completed employees so an interrupted run can resume; empty (the default) disables it
+	SyncCheckpointEvery  int           // Completed employees between checkpoint flushes
+	SyncChunkSize        int           // Employees per chunk; progress is logged and the checkpoint and report flushed after each
+	SyncReportPath       string        // Where the JSON run report is written after each chunk and at the end; empty disables it
+	SyncFilter           string        // Restricts the sync to matching employees, e.g. "department=Engineering; state!=CA"; empty syncs everyone
+	SyncValidationRules  string        // JSON file of per-field validation rules overriding the defaults; empty uses the defaults
+	SyncHistoryDir       string        // Directory every run's report is kept in, for cmd/runs; empty disables the history
+	SyncHistoryKeep      int           // Runs kept in SyncHistoryDir; older ones are pruned at the start of a run. 0 keeps all
+	SyncDryRunDiffPath   string        // Where a dry run writes its per-employee attribute diff; a ".json" path gets JSON, any other text. Empty disables it
+	SyncRedactAttributes []string      // Attribute names whose values are masked in the dry-run diff and debug bundles, e.g. "Phone,Home Address"
+	SyncDebugEmails      []string      // Employees whose Jira requests, responses and diff are captured to a debug bundle
+	SyncDebugDir         string        // Directory the debug bundles are written to
 }
 
 // Load loads
 // For this focused task, it primarily loads
 func Load() (*AppConfig, error) {
-	// Read the PAYCOR_SCOPES environment variable and split it into a slice.
-	scopesString := getEnv("PAYCOR_SCOPES", "") // Read the new variable
-	var scopes []string
-	if scopesString != "" {
-		scopes = strings.Split(scopesString, ",")
+	userAgent := getEnv("PSDI_USER_AGENT", DefaultUserAgent)
+
+	// Transport settings are shared by both clients; only the overall timeout and, optionally, the proxy differ.
+	sharedHTTP := HTTPClientConfig{
+		DialTimeout:   getEnvAsDuration("HTTP_DIAL_TIMEOUT", 30*time.Second),
+		TLSMinVersion: getEnv("HTTP_TLS_MIN_VERSION", ""),
+		CABundlePath:  getEnv("HTTP_CA_BUNDLE_PATH", ""),
+		ProxyURL:      getEnv("HTTP_PROXY_URL", ""),
+		MaxIdleConns:  getEnvAsInt("HTTP_MAX_IDLE_CONNS", 100),
+		MaxRetries:    getEnvAsInt("HTTP_MAX_RETRIES", 3),
+		RetryBackoff:  getEnvAsDuration("HTTP_RETRY_BACKOFF", time.Second),
+		RetryBudget:   getEnvAsInt("HTTP_RETRY_BUDGET", 50),
+
+		MaxIdleConnsPerHost: getEnvAsInt("HTTP_MAX_IDLE_CONNS_PER_HOST", 10),
+		IdleConnTimeout:     getEnvAsDuration("HTTP_IDLE_CONN_TIMEOUT", 90*time.Second),
+
+		InsecureSkipVerify: getEnvAsBool("HTTP_INSECURE_SKIP_VERIFY", false),
 	}
+	paycorHTTP := sharedHTTP
+	paycorHTTP.Timeout = getEnvAsDuration("PAYCOR_HTTP_TIMEOUT", 90*time.Second)
+	paycorHTTP.ProxyURL = getEnv("PAYCOR_PROXY_URL", sharedHTTP.ProxyURL)
+	jiraHTTP := sharedHTTP
+	jiraHTTP.Timeout = getEnvAsDuration("JIRA_HTTP_TIMEOUT", 60*time.Second)
+	jiraHTTP.ProxyURL = getEnv("JIRA_PROXY_URL", sharedHTTP.ProxyURL)
+
+	// Read the PAYCOR_SCOPES environment variable and split it into a slice.
+	scopes := splitScopes(getEnv("PAYCOR_SCOPES", ""))
 	cfg := &AppConfig{
 		Paycor: PaycorConfig{
+			PaycorEnvironment:            strings.ToLower(getEnv("PAYCOR_ENVIRONMENT", PaycorEnvironmentCustom)),
 			PaycorClientID:               getEnv("PAYCOR_CLIENT_ID", ""),
 			PaycorClientSecret:           getEnv("PAYCOR_CLIENT_SECRET", ""),
 			PaycorOcpApimSubscriptionKey: getEnv("PAYCOR_OCP_APIM_SUBSCRIPTION_KEY", ""),
@@ -68,9 +279,25 @@ func Load() (*AppConfig, error) {
 			PaycorAPIBaseURL:             getEnv("PAYCOR_API_BASE_URL", ""),
 			PaycorLegalEntityID:          getEnv("PAYCOR_LEGAL_ENTITY_ID", ""),
 			PaycorScopes:                 scopes, // Use the split scopes
+			PaycorTokenAuthStyle:         strings.ToLower(getEnv("PAYCOR_TOKEN_AUTH_STYLE", PaycorTokenAuthQuery)),
+			PaycorPagesInFlight:          getEnvAsInt("PAYCOR_PAGES_IN_FLIGHT", 2),
+			PaycorMaxPages:               getEnvAsInt("PAYCOR_MAX_PAGES", 1000),
+			PaycorMaxEmptyPages:          getEnvAsInt("PAYCOR_MAX_EMPTY_PAGES", 3),
+			PaycorMaxDecodeFailures:      getEnvAsInt("PAYCOR_MAX_DECODE_FAILURES", 10),
+			PaycorWriteBackObjectKey:     getEnvAsBool("WRITE_BACK_OBJECT_KEY", false),
+			PaycorObjectKeyField:         getEnv("PAYCOR_OBJECT_KEY_FIELD", "Jira Asset Key"),
+			UserAgent:                    userAgent,
+			HTTP:                         paycorHTTP,
+
+			PaycorTokenStatePath:            getEnv("PAYCOR_TOKEN_STATE_PATH", "paycor_token_state.json"),
+			PaycorTokenRefreshWarnAfter:     getEnvAsDuration("PAYCOR_TOKEN_REFRESH_WARN_AFTER", 7*24*time.Hour),
+			PaycorTokenFailureEscalateAfter: getEnvAsInt("PAYCOR_TOKEN_FAILURE_ESCALATE_AFTER", 3),
 		},
 
 		Jira: JiraConfig{
+			JiraDeploymentType:            strings.ToLower(getEnv("JIRA_DEPLOYMENT_TYPE", JiraDeploymentCloud)),
+			JiraAuthMode:                  strings.ToLower(getEnv("JIRA_AUTH_MODE", "")),
+			JiraBearerToken:               getEnv("JIRA_BEARER_TOKEN", ""),
 			JiraSiteName:                  getEnv("JIRA_ORG_DOMAIN", ""),
 			JiraWorkspaceID:               getEnv("JIRA_WORKSPACE_ID", ""),
 			JiraAdminEmail:                getEnv("JIRA_ADMIN_EMAIL", ""),
@@ -82,11 +309,71 @@ func Load() (*AppConfig, error) {
 			JiraEmployeeObjectTypeID:      getEnv("JIRA_EMPLOYEE_OBJECT_TYPE_ID", ""),
 			JiraRoleObjectTypeName:        getEnv("JIRA_ROLE_OBJECT_TYPE_NAME", "Role"),
 			JiraRoleObjectTypeID:          getEnv("JIRA_ROLE_OBJECT_TYPE_ID", ""),
+			JiraLocationObjectTypeName:    getEnv("JIRA_LOCATION_OBJECT_TYPE_NAME", "Location"),
+			JiraLocationObjectTypeID:      getEnv("JIRA_LOCATION_OBJECT_TYPE_ID", ""),
+			JiraIssueTypeNameForAsset:     getEnv("JIRA_ISSUE_TYPE_NAME", "Task"),
+			JiraOnboardingProjectKey:      getEnv("JIRA_ONBOARDING_PROJECT_KEY", ""),
+			JiraOnboardingTemplatePath:    getEnv("JIRA_ONBOARDING_TEMPLATE_PATH", ""),
+			JiraOnboardingLabels:          splitScopes(getEnv("JIRA_ONBOARDING_LABELS", "")),
+			JiraOnboardingAssigneeID:      getEnv("JIRA_ONBOARDING_ASSIGNEE_ACCOUNT_ID", ""),
+			JiraOnboardingDueDays:         getEnvAsInt("JIRA_ONBOARDING_DUE_DAYS", 0),
+			JiraFollowUpProjectKey:        getEnv("JIRA_FOLLOW_UP_PROJECT_KEY", ""),
+			JiraFollowUpIssueType:         getEnv("JIRA_FOLLOW_UP_ISSUE_TYPE", "Task"),
+			JiraRehireDateAttribute:       getEnv("JIRA_REHIRE_DATE_ATTRIBUTE", ""),
+			JiraNameFormat:                strings.ToLower(getEnv("JIRA_NAME_FORMAT", NameFormatFirstLast)),
+			JiraNameTemplate:              getEnv("JIRA_NAME_TEMPLATE", ""),
+			JiraManagerNameFormat:         strings.ToLower(getEnv("JIRA_MANAGER_NAME_FORMAT", ManagerNameFormatAuto)),
+			JiraWritePolicies:             parseWritePolicies(getEnv("JIRA_WRITE_POLICIES", "Start Date=create-only,Atlassian Account ID=create-only")),
+			JiraCreateConflictFallback:    getEnvAsBool("JIRA_CREATE_CONFLICT_FALLBACK", false),
+			JiraAutoCreateSelectOptions:   getEnvAsBool("AUTO_CREATE_SELECT_OPTIONS", false),
+			JiraAttributeMapPath:          getEnv("JIRA_ATTRIBUTE_MAP_PATH", ""),
+			JiraPaycorIDAttribute:         getEnv("JIRA_PAYCOR_ID_ATTRIBUTE", "Paycor ID"),
+			JiraSandboxObjectTypeID:       getEnv("JIRA_SANDBOX_OBJECT_TYPE_ID", ""),
+			JiraRateLimitMinRemaining:     getEnvAsInt("JIRA_RATE_LIMIT_MIN_REMAINING", 10),
+			JiraLastSyncedAttribute:       getEnv("JIRA_LAST_SYNCED_ATTRIBUTE", ""),
+			JiraManagedByAttribute:        getEnv("JIRA_MANAGED_BY_ATTRIBUTE", ""),
+			JiraManagedByValue:            getEnv("JIRA_MANAGED_BY_VALUE", "PSDI"),
+			JiraChangeComments:            getEnvAsBool("JIRA_CHANGE_COMMENTS", false),
+			JiraChangeCommentAttributes:   splitList(getEnv("JIRA_CHANGE_COMMENT_ATTRIBUTES", "Status,Department")),
+			JiraChangeCommentMaxObjects:   getEnvAsInt("JIRA_CHANGE_COMMENT_MAX_OBJECTS", 25),
+			UserAgent:                     userAgent,
+			HTTP:                          jiraHTTP,
+		},
+		OrgAdmin: OrgAdminConfig{
+			AtlassianOrgID:           getEnv("ATLASSIAN_ORG_ID", ""),
+			AtlassianOrgAdminToken:   getEnv("ATLASSIAN_ORG_ADMIN_TOKEN", ""),
+			AtlassianAdminURL:        getEnv("ATLASSIAN_ADMIN_API_URL", "https://api.atlassian.com"),
+			AccountSuspensionEnabled: getEnvAsBool("ENABLE_ACCOUNT_SUSPENSION", false),
+			AccountSuspensionMaxRun:  getEnvAsInt("ACCOUNT_SUSPENSION_MAX_PER_RUN", 5),
+			UserAgent:                userAgent,
+			HTTP:                     jiraHTTP,
 		},
+		DebugDumpPath:        getEnv("DEBUG_DUMP_PATH", ""),
+		Features:             loadFeatures(),
+		SyncMaxDuration:      getEnvAsDuration("SYNC_MAX_DURATION", 0),
+		SyncCheckpointPath:   getEnv("SYNC_CHECKPOINT_PATH", ""),
+		SyncCheckpointEvery:  getEnvAsInt("SYNC_CHECKPOINT_EVERY", 50),
+		SyncChunkSize:        getEnvAsInt("SYNC_CHUNK_SIZE", 100),
+		SyncReportPath:       getEnv("SYNC_REPORT_PATH", ""),
+		SyncFilter:           getEnv("SYNC_FILTER", ""),
+		SyncValidationRules:  getEnv("SYNC_VALIDATION_RULES_PATH", ""),
+		SyncHistoryDir:       getEnv("SYNC_HISTORY_DIR", ""),
+		SyncHistoryKeep:      getEnvAsInt("SYNC_HISTORY_KEEP", 100),
+		SyncDryRunDiffPath:   getEnv("SYNC_DRY_RUN_DIFF_PATH", ""),
+		SyncRedactAttributes: splitList(getEnv("SYNC_REDACT_ATTRIBUTES", "")),
+		SyncDebugEmails:      splitList(getEnv("SYNC_DEBUG_EMAILS", "")),
+		SyncDebugDir:         getEnv("SYNC_DEBUG_DIR", "debug_bundles"),
 		// Initialize other AppConfig fields
 		// DatabaseURL: getEnv("DATABASE_URL", ""),
 		// ServerPort:  getEnv("SERVER_PORT", "8080"), // Default port
 	}
+	if err := resolvePaycorEnvironment(&cfg.Paycor); err != nil {
+		return nil, err
+	}
+	log.Printf("CONFIG INFO: Paycor environment: %s (API %s).", strings.ToUpper(cfg.Paycor.PaycorEnvironmentLabel()), cfg.Paycor.PaycorAPIBaseURL)
+	if cfg.Paycor.HTTP.InsecureSkipVerify {
+		log.Println("CONFIG WARNING: !!! HTTP_INSECURE_SKIP_VERIFY is set. TLS certificates will NOT be verified for Paycor or Jira, exposing credentials and employee data to interception. Use HTTP_CA_BUNDLE_PATH instead. !!!")
+	}
 	// Validate Paycor configuration
 	if cfg.Paycor.PaycorClientID == "" {
 		log.Println("CONFIG WARNING: PAYCOR_CLIENT_ID environment variable is not set.")
@@ -109,6 +396,23 @@ func Load() (*AppConfig, error) {
 	if cfg.Paycor.PaycorLegalEntityID == "" {
 		log.Println("CONFIG WARNING: PAYCOR_LEGAL_ENTITY_ID environment variable is not set.")
 	}
+	switch cfg.Paycor.PaycorTokenAuthStyle {
+	case PaycorTokenAuthQuery, PaycorTokenAuthHeader, PaycorTokenAuthBoth:
+	default:
+		log.Printf("CONFIG WARNING: PAYCOR_TOKEN_AUTH_STYLE '%s' is not recognised, expected '%s', '%s' or '%s'.", cfg.Paycor.PaycorTokenAuthStyle, PaycorTokenAuthQuery, PaycorTokenAuthHeader, PaycorTokenAuthBoth)
+	}
+	if cfg.Jira.JiraDeploymentType != JiraDeploymentCloud && cfg.Jira.JiraDeploymentType != JiraDeploymentServer {
+		log.Printf("CONFIG WARNING: JIRA_DEPLOYMENT_TYPE '%s' is not recognised, expected '%s' or '%s'.", cfg.Jira.JiraDeploymentType, JiraDeploymentCloud, JiraDeploymentServer)
+	}
+	switch cfg.Jira.JiraNameFormat {
+	case NameFormatFirstLast, NameFormatPreferredLast, NameFormatLastFirst, NameFormatMiddleInitial:
+	default:
+		log.Printf("CONFIG WARNING: JIRA_NAME_FORMAT '%s' is not recognised, expected '%s', '%s', '%s' or '%s'.",
+			cfg.Jira.JiraNameFormat, NameFormatFirstLast, NameFormatPreferredLast, NameFormatLastFirst, NameFormatMiddleInitial)
+	}
+	if cfg.Jira.JiraManagerNameFormat != ManagerNameFormatAuto && cfg.Jira.JiraManagerNameFormat != ManagerNameFormatAsIs {
+		log.Printf("CONFIG WARNING: JIRA_MANAGER_NAME_FORMAT '%s' is not recognised, expected '%s' or '%s'.", cfg.Jira.JiraManagerNameFormat, ManagerNameFormatAuto, ManagerNameFormatAsIs)
+	}
 	if cfg.Jira.JiraSiteName == "" {
 		log.Println("CONFIG WARNING: JIRA_ORG_DOMAIN environment variable is not set.")
 	}
@@ -142,11 +446,76 @@ func Load() (*AppConfig, error) {
 	if cfg.Jira.JiraRoleObjectTypeID == "" {
 		log.Println("CONFIG WARNING: JIRA_ROLE_OBJECT_TYPE_ID environment variable is not set.")
 	}
+	if cfg.OrgAdmin.AccountSuspensionEnabled {
+		if cfg.OrgAdmin.AtlassianOrgID == "" || cfg.OrgAdmin.AtlassianOrgAdminToken == "" {
+			return nil, fmt.Errorf("ENABLE_ACCOUNT_SUSPENSION is set but ATLASSIAN_ORG_ID or ATLASSIAN_ORG_ADMIN_TOKEN is not")
+		}
+		if cfg.OrgAdmin.AccountSuspensionMaxRun <= 0 {
+			return nil, fmt.Errorf("ACCOUNT_SUSPENSION_MAX_PER_RUN must be positive when ENABLE_ACCOUNT_SUSPENSION is set, got %d", cfg.OrgAdmin.AccountSuspensionMaxRun)
+		}
+		log.Printf("CONFIG INFO: Account suspension is enabled for Atlassian organization %s, at most %d per run.", cfg.OrgAdmin.AtlassianOrgID, cfg.OrgAdmin.AccountSuspensionMaxRun)
+	}
+	var err error
+	if cfg.Paycor.PaycorTimezone, err = loadTimezone("PAYCOR_TIMEZONE", "UTC"); err != nil {
+		return nil, err
+	}
+	if cfg.DisplayTimezone, err = loadTimezone("DISPLAY_TIMEZONE", "Local"); err != nil {
+		return nil, err
+	}
 	// Add more validation as needed for other fields
 
 	return cfg, nil
 }
 
+// parseWritePolicies parses "Attribute=policy" pairs separated by commas, e.g.
+// "Start Date=create-only,Email=if-empty". Malformed entries and unknown policies are skipped with a warning.
+func parseWritePolicies(value string) map[string]string {
+	policies := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, policy, ok := strings.Cut(pair, "=")
+		name, policy = strings.TrimSpace(name), strings.ToLower(strings.TrimSpace(policy))
+		if !ok || name == "" {
+			log.Printf("CONFIG WARNING: JIRA_WRITE_POLICIES entry '%s' is not in the form Attribute=policy, ignoring it.", strings.TrimSpace(pair))
+			continue
+		}
+		switch policy {
+		case WritePolicyAlways, WritePolicyCreateOnly, WritePolicyIfEmpty:
+			policies[name] = policy
+		default:
+			log.Printf("CONFIG WARNING: JIRA_WRITE_POLICIES policy '%s' for '%s' is not recognised, expected '%s', '%s' or '%s'.",
+				policy, name, WritePolicyAlways, WritePolicyCreateOnly, WritePolicyIfEmpty)
+		}
+	}
+	return policies
+}
+
+// splitScopes splits a scope list on commas, whitespace or newlines, so "a, b c" and a
+// one-per-line list all work. Empty entries are dropped; an empty list returns nil.
+func splitScopes(value string) []string {
+	scopes := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if len(scopes) == 0 {
+		return nil
+	}
+	return scopes
+}
+
+// splitList splits a comma-separated list, for entries that may contain spaces such as
+// attribute names. Entries are trimmed and empty ones dropped.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getEnv(key string, defaultValue string) string {
 	value, exists := os.LookupEnv(key)
 	if !exists {
@@ -162,5 +531,58 @@ func getEnv(key string, defaultValue string) string {
 	return value
 }
 
-// getEnvAsInt and getEnvAsDuration can be added back if other config sections need them.
-// For this specific task, they are not immediately required by PaycorConfig.
+// loadTimezone reads an IANA timezone name such as "America/New_York" from the environment.
+// "Local" is the host's zone.
+func loadTimezone(key, defaultValue string) (*time.Location, error) {
+	name := strings.TrimSpace(getEnv(key, defaultValue))
+	if name == "" {
+		name = defaultValue
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s '%s' is not a known timezone: %w", key, name, err)
+	}
+	return loc, nil
+}
+
+// getEnvAsBool reads a boolean environment variable ("true", "1", "false", "0", ...), falling back to the default if it is unset or malformed.
+func getEnvAsBool(key string, defaultValue bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		log.Printf("CONFIG WARNING: Environment variable %s has invalid boolean value '%s', using default %t.", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvAsInt reads an integer environment variable, falling back to the default if it is unset or malformed.
+func getEnvAsInt(key string, defaultValue int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		log.Printf("CONFIG WARNING: Environment variable %s has invalid integer value '%s', using default %d.", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvAsDuration reads a duration (e.g. "90s", "5m") environment variable, falling back to the default if it is unset or malformed.
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		log.Printf("CONFIG WARNING: Environment variable %s has invalid duration value '%s', using default %v.", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	// Load logs a warning for every unset variable.
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// unsetenv unsets key for the rest of the test, so a variable set on the host can't leak in.
+func unsetenv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "") // Registers the restore
+	os.Unsetenv(key)
+}
+
+func TestSplitScopes(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{name: "commas", value: "a,b,c", want: []string{"a", "b", "c"}},
+		{name: "whitespace", value: "a b\tc\nd", want: []string{"a", "b", "c", "d"}},
+		{name: "commas and spaces", value: "a, b ,c", want: []string{"a", "b", "c"}},
+		{name: "surrounding spaces", value: "  a,b  ", want: []string{"a", "b"}},
+		{name: "empty entries", value: ",a,,b,", want: []string{"a", "b"}},
+		{name: "empty", value: "", want: nil},
+		{name: "only separators", value: " , \n", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitScopes(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitScopes(%q) = %#v, want %#v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadScopes(t *testing.T) {
+	t.Setenv("PAYCOR_SCOPES", " openid, offline_access  employees ")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if want := []string{"openid", "offline_access", "employees"}; !reflect.DeepEqual(cfg.Paycor.PaycorScopes, want) {
+		t.Errorf("PaycorScopes = %#v, want %#v", cfg.Paycor.PaycorScopes, want)
+	}
+
+	unsetenv(t, "PAYCOR_SCOPES")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Paycor.PaycorScopes != nil {
+		t.Errorf("PaycorScopes = %#v with PAYCOR_SCOPES unset, want nil", cfg.Paycor.PaycorScopes)
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	unsetenv(t, "JIRA_EMPLOYEE_OBJECT_TYPE_NAME")
+	unsetenv(t, "PAYCOR_TOKEN_AUTH_STYLE")
+	unsetenv(t, "DEBUG_DUMP_PATH")
+	unsetenv(t, "SYNC_CHECKPOINT_PATH")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Jira.JiraEmployeeObjectTypeName != "Employees" {
+		t.Errorf("JiraEmployeeObjectTypeName = %q, want Employees", cfg.Jira.JiraEmployeeObjectTypeName)
+	}
+	if cfg.Paycor.PaycorTokenAuthStyle != PaycorTokenAuthQuery {
+		t.Errorf("PaycorTokenAuthStyle = %q, want %q", cfg.Paycor.PaycorTokenAuthStyle, PaycorTokenAuthQuery)
+	}
+	if cfg.DebugDumpPath != "" {
+		t.Errorf("DebugDumpPath = %q, want the dump off by default", cfg.DebugDumpPath)
+	}
+	if cfg.SyncCheckpointPath != "" {
+		t.Errorf("SyncCheckpointPath = %q, want checkpointing off by default", cfg.SyncCheckpointPath)
+	}
+
+	t.Setenv("PAYCOR_TOKEN_AUTH_STYLE", "Header")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Paycor.PaycorTokenAuthStyle != PaycorTokenAuthHeader {
+		t.Errorf("PaycorTokenAuthStyle = %q, want %q", cfg.Paycor.PaycorTokenAuthStyle, PaycorTokenAuthHeader)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "suspension without org credentials",
+			env:     map[string]string{"ENABLE_ACCOUNT_SUSPENSION": "true", "ATLASSIAN_ORG_ID": "org", "ATLASSIAN_ORG_ADMIN_TOKEN": ""},
+			wantErr: "ATLASSIAN_ORG_ID or ATLASSIAN_ORG_ADMIN_TOKEN",
+		},
+		{
+			name:    "suspension with a zero cap",
+			env:     map[string]string{"ENABLE_ACCOUNT_SUSPENSION": "true", "ATLASSIAN_ORG_ID": "org", "ATLASSIAN_ORG_ADMIN_TOKEN": "token", "ACCOUNT_SUSPENSION_MAX_PER_RUN": "0"},
+			wantErr: "ACCOUNT_SUSPENSION_MAX_PER_RUN must be positive",
+		},
+		{
+			name:    "unknown Paycor timezone",
+			env:     map[string]string{"PAYCOR_TIMEZONE": "Mars/Olympus_Mons"},
+			wantErr: "PAYCOR_TIMEZONE 'Mars/Olympus_Mons' is not a known timezone",
+		},
+		{
+			name:    "unknown display timezone",
+			env:     map[string]string{"DISPLAY_TIMEZONE": "Nowhere/Special"},
+			wantErr: "DISPLAY_TIMEZONE 'Nowhere/Special' is not a known timezone",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			cfg, err := Load()
+			if err == nil {
+				t.Fatalf("Load returned %+v and no error, want %q", cfg, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetEnvFallsBackOnMalformedValues(t *testing.T) {
+	const key = "PSDI_TEST_VALUE"
+	tests := []struct {
+		value    string
+		wantBool bool
+		wantInt  int
+		wantDur  time.Duration
+	}{
+		// Empty and malformed values give the defaults: true, 7 and 5s.
+		{value: "", wantBool: true, wantInt: 7, wantDur: 5 * time.Second},
+		{value: "maybe", wantBool: true, wantInt: 7, wantDur: 5 * time.Second},
+		{value: "1.5", wantBool: true, wantInt: 7, wantDur: 5 * time.Second},
+		{value: "30", wantBool: true, wantInt: 30, wantDur: 5 * time.Second},
+		{value: "0", wantBool: false, wantInt: 0, wantDur: 0},
+		{value: " false ", wantBool: false, wantInt: 7, wantDur: 5 * time.Second},
+		{value: " 12 ", wantBool: true, wantInt: 12, wantDur: 5 * time.Second},
+		{value: "90s", wantBool: true, wantInt: 7, wantDur: 90 * time.Second},
+		{value: "-2m", wantBool: true, wantInt: 7, wantDur: -2 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv(key, tt.value)
+			if got := getEnvAsBool(key, true); got != tt.wantBool {
+				t.Errorf("getEnvAsBool(%q) = %t, want %t", tt.value, got, tt.wantBool)
+			}
+			if got := getEnvAsInt(key, 7); got != tt.wantInt {
+				t.Errorf("getEnvAsInt(%q) = %d, want %d", tt.value, got, tt.wantInt)
+			}
+			if got := getEnvAsDuration(key, 5*time.Second); got != tt.wantDur {
+				t.Errorf("getEnvAsDuration(%q) = %v, want %v", tt.value, got, tt.wantDur)
+			}
+		})
+	}
+
+	unsetenv(t, key)
+	if getEnvAsBool(key, true) != true || getEnvAsInt(key, 7) != 7 || getEnvAsDuration(key, 5*time.Second) != 5*time.Second {
+		t.Error("an unset variable did not give the defaults")
+	}
+}
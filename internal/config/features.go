@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Features are the sync engine's own on/off switches, read from the environment in one place.
+// Switches that belong to a client, such as JIRA_CHANGE_COMMENTS, stay in that client's config
+// but are listed in featureRegistry too, so every toggle is documented and logged together.
+type Features struct {
+	DryRun             bool // SYNC_DRY_RUN: log and report changes without writing to Jira; -dry-run also sets it
+	IncludeTerminated  bool // SYNC_INCLUDE_TERMINATED: also sync terminated employees; by default only current staff, and assets still to be marked terminated, are synced
+	StripEmailPlusTags bool // SYNC_STRIP_EMAIL_PLUS_TAGS: match "jane+hr@x.com" to "jane@x.com" when pairing employees with assets
+}
+
+func loadFeatures() Features {
+	return Features{
+		DryRun:             getEnvAsBool("SYNC_DRY_RUN", false),
+		IncludeTerminated:  getEnvAsBool("SYNC_INCLUDE_TERMINATED", false),
+		StripEmailPlusTags: getEnvAsBool("SYNC_STRIP_EMAIL_PLUS_TAGS", false),
+	}
+}
+
+// featureRegistry lists every behavior toggle by environment variable, in the order they are logged.
+var featureRegistry = []struct {
+	env   string
+	value func(cfg *AppConfig) interface{}
+}{
+	{"SYNC_DRY_RUN", func(cfg *AppConfig) interface{} { return cfg.Features.DryRun }},
+	{"SYNC_INCLUDE_TERMINATED", func(cfg *AppConfig) interface{} { return cfg.Features.IncludeTerminated }},
+	{"SYNC_STRIP_EMAIL_PLUS_TAGS", func(cfg *AppConfig) interface{} { return cfg.Features.StripEmailPlusTags }},
+	{"WRITE_BACK_OBJECT_KEY", func(cfg *AppConfig) interface{} { return cfg.Paycor.PaycorWriteBackObjectKey }},
+	{"JIRA_CHANGE_COMMENTS", func(cfg *AppConfig) interface{} { return cfg.Jira.JiraChangeComments }},
+	{"AUTO_CREATE_SELECT_OPTIONS", func(cfg *AppConfig) interface{} { return cfg.Jira.JiraAutoCreateSelectOptions }},
+	{"JIRA_CREATE_CONFLICT_FALLBACK", func(cfg *AppConfig) interface{} { return cfg.Jira.JiraCreateConflictFallback }},
+	{"ENABLE_ACCOUNT_SUSPENSION", func(cfg *AppConfig) interface{} { return cfg.OrgAdmin.AccountSuspensionEnabled }},
+	{"ACCOUNT_SUSPENSION_MAX_PER_RUN", func(cfg *AppConfig) interface{} { return cfg.OrgAdmin.AccountSuspensionMaxRun }},
+}
+
+// FeatureSummary renders every registered toggle as "NAME=value", for a single startup log line.
+func (c *AppConfig) FeatureSummary() string {
+	parts := make([]string, 0, len(featureRegistry))
+	for _, feature := range featureRegistry {
+		parts = append(parts, fmt.Sprintf("%s=%v", feature.env, feature.value(c)))
+	}
+	return strings.Join(parts, " ")
+}
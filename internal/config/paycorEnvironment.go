@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Paycor environments (see PaycorConfig.PaycorEnvironment).
+const (
+	PaycorEnvironmentProduction = "production"
+	PaycorEnvironmentSandbox    = "sandbox"
+	PaycorEnvironmentCustom     = "custom" // PAYCOR_TOKEN_URL_BASE and PAYCOR_API_BASE_URL are set by hand
+)
+
+// paycorEnvironmentURLs are the token and API base URLs filled in for each named environment.
+var paycorEnvironmentURLs = map[string]struct{ tokenURL, apiBaseURL string }{
+	PaycorEnvironmentProduction: {"https://apis.paycor.com/sts/v1/common/token", "https://apis.paycor.com/v1"},
+	PaycorEnvironmentSandbox:    {"https://apis-sandbox.paycor.com/sts/v1/common/token", "https://apis-sandbox.paycor.com/v1"},
+}
+
+// resolvePaycorEnvironment fills in the Paycor URLs for a named environment and checks that
+// the URLs in use belong to one environment. A URL set explicitly for a named environment
+// must point at that environment's host; in the custom environment, the token and API URLs
+// must not mix sandbox and production hosts. Hosts that aren't Paycor's, such as a gateway,
+// are accepted as they are.
+func resolvePaycorEnvironment(cfg *PaycorConfig) error {
+	switch cfg.PaycorEnvironment {
+	case PaycorEnvironmentProduction, PaycorEnvironmentSandbox:
+		urls := paycorEnvironmentURLs[cfg.PaycorEnvironment]
+		if cfg.PaycorTokenURLBase == "" {
+			cfg.PaycorTokenURLBase = urls.tokenURL
+		}
+		if cfg.PaycorAPIBaseURL == "" {
+			cfg.PaycorAPIBaseURL = urls.apiBaseURL
+		}
+		if family := paycorHostFamily(cfg.PaycorTokenURLBase); family != "" && family != cfg.PaycorEnvironment {
+			return fmt.Errorf("PAYCOR_TOKEN_URL_BASE '%s' is a Paycor %s URL, but PAYCOR_ENVIRONMENT is %s", cfg.PaycorTokenURLBase, family, cfg.PaycorEnvironment)
+		}
+		if family := paycorHostFamily(cfg.PaycorAPIBaseURL); family != "" && family != cfg.PaycorEnvironment {
+			return fmt.Errorf("PAYCOR_API_BASE_URL '%s' is a Paycor %s URL, but PAYCOR_ENVIRONMENT is %s", cfg.PaycorAPIBaseURL, family, cfg.PaycorEnvironment)
+		}
+	case PaycorEnvironmentCustom:
+		tokenFamily, apiFamily := paycorHostFamily(cfg.PaycorTokenURLBase), paycorHostFamily(cfg.PaycorAPIBaseURL)
+		if tokenFamily != "" && apiFamily != "" && tokenFamily != apiFamily {
+			return fmt.Errorf("PAYCOR_TOKEN_URL_BASE '%s' is a Paycor %s URL but PAYCOR_API_BASE_URL '%s' is a Paycor %s URL; a token from one environment is rejected by the other",
+				cfg.PaycorTokenURLBase, tokenFamily, cfg.PaycorAPIBaseURL, apiFamily)
+		}
+	default:
+		return fmt.Errorf("PAYCOR_ENVIRONMENT '%s' is not recognised, expected '%s', '%s' or '%s'",
+			cfg.PaycorEnvironment, PaycorEnvironmentProduction, PaycorEnvironmentSandbox, PaycorEnvironmentCustom)
+	}
+	return nil
+}
+
+// PaycorEnvironmentLabel describes the Paycor environment in use, for logs and reports. For the
+// custom environment it adds the environment its Paycor hosts belong to, when known.
+func (cfg PaycorConfig) PaycorEnvironmentLabel() string {
+	if cfg.PaycorEnvironment != PaycorEnvironmentCustom {
+		return cfg.PaycorEnvironment
+	}
+	if family := paycorHostFamily(cfg.PaycorAPIBaseURL); family != "" {
+		return fmt.Sprintf("%s (%s hosts)", PaycorEnvironmentCustom, family)
+	}
+	return PaycorEnvironmentCustom
+}
+
+// paycorHostFamily returns the environment a URL's host belongs to, or "" if it isn't a Paycor host.
+func paycorHostFamily(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if host != "paycor.com" && !strings.HasSuffix(host, ".paycor.com") {
+		return ""
+	}
+	if strings.Contains(host, "sandbox") {
+		return PaycorEnvironmentSandbox
+	}
+	return PaycorEnvironmentProduction
+}
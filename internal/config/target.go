@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// targetNamePattern restricts target names to what can appear in an environment variable name.
+var targetNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// targetSettings are the per-target settings, by the suffix of their JIRA_TARGET_<NAME>_ variable.
+// Anything not set for a target keeps its unprefixed value.
+var targetSettings = map[string]func(cfg *AppConfig) *string{
+	"WORKSPACE_ID":              func(cfg *AppConfig) *string { return &cfg.Jira.JiraWorkspaceID },
+	"OBJECT_SCHEMA_KEY":         func(cfg *AppConfig) *string { return &cfg.Jira.JiraObjectSchemaKey },
+	"EMPLOYEE_OBJECT_TYPE_NAME": func(cfg *AppConfig) *string { return &cfg.Jira.JiraEmployeeObjectTypeName },
+	"EMPLOYEE_OBJECT_TYPE_ID":   func(cfg *AppConfig) *string { return &cfg.Jira.JiraEmployeeObjectTypeID },
+	"ROLE_OBJECT_TYPE_NAME":     func(cfg *AppConfig) *string { return &cfg.Jira.JiraRoleObjectTypeName },
+	"ROLE_OBJECT_TYPE_ID":       func(cfg *AppConfig) *string { return &cfg.Jira.JiraRoleObjectTypeID },
+	"LOCATION_OBJECT_TYPE_NAME": func(cfg *AppConfig) *string { return &cfg.Jira.JiraLocationObjectTypeName },
+	"LOCATION_OBJECT_TYPE_ID":   func(cfg *AppConfig) *string { return &cfg.Jira.JiraLocationObjectTypeID },
+	"ATTRIBUTE_MAP_PATH":        func(cfg *AppConfig) *string { return &cfg.Jira.JiraAttributeMapPath },
+	"ONBOARDING_PROJECT_KEY":    func(cfg *AppConfig) *string { return &cfg.Jira.JiraOnboardingProjectKey },
+	"FOLLOW_UP_PROJECT_KEY":     func(cfg *AppConfig) *string { return &cfg.Jira.JiraFollowUpProjectKey },
+	"ONBOARDING_TEMPLATE_PATH":  func(cfg *AppConfig) *string { return &cfg.Jira.JiraOnboardingTemplatePath },
+	"CHECKPOINT_PATH":           func(cfg *AppConfig) *string { return &cfg.SyncCheckpointPath },
+}
+
+// AddSyncScope narrows SYNC_FILTER with a further filter expression, such as the -scope flag.
+// Both must match, since filter clauses are combined with AND.
+func (cfg *AppConfig) AddSyncScope(scope string) {
+	if strings.TrimSpace(scope) == "" {
+		return
+	}
+	if strings.TrimSpace(cfg.SyncFilter) != "" {
+		cfg.SyncFilter += "; "
+	}
+	cfg.SyncFilter += scope
+}
+
+// ApplyJiraTarget switches the configuration to a named Jira target, such as a staging and a
+// production Assets schema on the same site. A target is defined by JIRA_TARGET_<NAME>_<SETTING>
+// variables, e.g. JIRA_TARGET_STAGING_OBJECT_SCHEMA_KEY=HRITBETA; see targetSettings for the
+// settings a target can override. Unless the target sets its own CHECKPOINT_PATH, the checkpoint
+// file is suffixed with the target name so one target's progress never resumes another's run.
+func (cfg *AppConfig) ApplyJiraTarget(name string) error {
+	if !targetNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid Jira target name '%s': use letters, digits and underscores", name)
+	}
+	prefix := "JIRA_TARGET_" + strings.ToUpper(name) + "_"
+
+	applied := 0
+	checkpointSet := false
+	for suffix, setting := range targetSettings {
+		value, ok := os.LookupEnv(prefix + suffix)
+		if !ok {
+			continue
+		}
+		*setting(cfg) = value
+		applied++
+		if suffix == "CHECKPOINT_PATH" {
+			checkpointSet = true
+		}
+	}
+	if applied == 0 {
+		return fmt.Errorf("Jira target '%s' is not defined: no %s* environment variables are set", name, prefix)
+	}
+	if !checkpointSet && cfg.SyncCheckpointPath != "" {
+		ext := filepath.Ext(cfg.SyncCheckpointPath)
+		cfg.SyncCheckpointPath = strings.TrimSuffix(cfg.SyncCheckpointPath, ext) + "-" + strings.ToLower(name) + ext
+	}
+
+	cfg.Jira.JiraTarget = strings.ToLower(name)
+	log.Printf("CONFIG INFO: Using Jira target '%s' (%d settings overridden): schema %s, Employee object type %s.",
+		cfg.Jira.JiraTarget, applied, cfg.Jira.JiraObjectSchemaKey, cfg.Jira.JiraEmployeeObjectTypeID)
+	return nil
+}
@@ -0,0 +1,140 @@
+// internal/httpclient/httpclient.go
+
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+)
+
+// NewTransport builds an http.Transport from the shared HTTP client settings.
+// It starts from Go's default transport so unset fields keep their usual behavior.
+func NewTransport(cfg config.HTTPClientConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.DialTimeout > 0 {
+		dialer := &net.Dialer{Timeout: cfg.DialTimeout, KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+	}
+	if cfg.MaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.MaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	if cfg.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+
+	// The transport only requests gzip and decompresses transparently when the request has no
+	// Accept-Encoding of its own, so none of the wrapping transports (oauth2, User-Agent, retry)
+	// may set one. Bulk AQL pages and Paycor include=All pages compress well.
+	transport.DisableCompression = false
+
+	// An explicit proxy wins; otherwise the cloned transport keeps honouring HTTP(S)_PROXY.
+	if cfg.ProxyURL != "" {
+		proxyURL, err := ParseProxyURL(cfg.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// maxDrainBytes bounds how much of an unread body is discarded to keep its connection reusable.
+// Larger remainders are cheaper to abandon with the connection than to download.
+const maxDrainBytes = 256 << 10
+
+// DrainAndClose reads what is left of a response body and closes it. A body closed before EOF
+// takes its connection with it, so every new request would pay for another TCP and TLS handshake.
+func DrainAndClose(body io.ReadCloser) {
+	io.Copy(io.Discard, io.LimitReader(body, maxDrainBytes))
+	body.Close()
+}
+
+// ParseProxyURL parses and validates an explicit proxy URL. url.Parse accepts almost anything,
+// so a scheme the transport supports and a host are required as well.
+func ParseProxyURL(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL '%s': %w", raw, err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL '%s': scheme must be http, https or socks5", raw)
+	}
+	if proxyURL.Hostname() == "" {
+		return nil, fmt.Errorf("invalid proxy URL '%s': missing host", raw)
+	}
+	return proxyURL, nil
+}
+
+// New builds an http.Client with the configured timeout and a retrying transport, which is
+// also returned so the caller can report the retries it made.
+func New(name string, cfg config.HTTPClientConfig) (*http.Client, *RetryTransport, error) {
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	retries := NewRetryTransport(name, transport, cfg)
+	return &http.Client{Timeout: cfg.Timeout, Transport: retries}, retries, nil
+}
+
+// newTLSConfig applies the minimum TLS version, any additional CA bundle and, if requested,
+// disables certificate verification.
+func newTLSConfig(cfg config.HTTPClientConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+
+	switch cfg.TLSMinVersion {
+	case "":
+		// Keep Go's default minimum.
+	case "1.2":
+		tlsConfig.MinVersion = tls.VersionTLS12
+	case "1.3":
+		tlsConfig.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("unsupported TLS minimum version '%s' (expected 1.2 or 1.3)", cfg.TLSMinVersion)
+	}
+
+	if cfg.CABundlePath != "" {
+		pemData, err := os.ReadFile(cfg.CABundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle '%s': %w", cfg.CABundlePath, err)
+		}
+		// Extend the system pool rather than replacing it, so public endpoints keep working.
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pemData) {
+			return nil, fmt.Errorf("CA bundle '%s' contains no valid PEM certificates", cfg.CABundlePath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.InsecureSkipVerify {
+		log.Println("WARN: [HTTPClient] TLS certificate verification is DISABLED (HTTP_INSECURE_SKIP_VERIFY).")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,157 @@
+package httpclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// largeJSON is a response body the size of an include=All Paycor page.
+var largeJSON = []byte(`{"records":[` + strings.TrimSuffix(strings.Repeat(`{"id":"0000","firstName":"Jane","lastName":"Doe"},`, 4000), ",") + `]}`)
+
+// newGzipTLSServer serves largeJSON gzip-compressed to clients that ask for it.
+func newGzipTLSServer(t testing.TB) *httptest.Server {
+	t.Helper()
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	zw.Write(largeJSON)
+	zw.Close()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(compressed.Bytes())
+			return
+		}
+		w.Write(largeJSON)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// trustServer writes the test server's certificate to a CA bundle for the client config.
+func trustServer(t testing.TB, server *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// connCounter counts the connections and TLS handshakes requests traced with it make.
+type connCounter struct {
+	newConns   atomic.Int64
+	handshakes atomic.Int64
+}
+
+func (c *connCounter) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if !info.Reused {
+				c.newConns.Add(1)
+			}
+		},
+		TLSHandshakeStart: func() { c.handshakes.Add(1) },
+	}
+}
+
+// get fetches url with the trace attached, reads the whole body as the clients do, and
+// reports whether the transport decompressed it.
+func get(t testing.TB, client *http.Client, url string, counter *connCounter) bool {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), counter.trace()))
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(body, largeJSON) {
+		t.Fatalf("got a %d byte body, want the %d byte document", len(body), len(largeJSON))
+	}
+	return resp.Uncompressed
+}
+
+func TestClientReusesConnectionsAndDecompresses(t *testing.T) {
+	server := newGzipTLSServer(t)
+	client, _, err := New("test", config.HTTPClientConfig{CABundlePath: trustServer(t, server), MaxIdleConnsPerHost: 4})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var counter connCounter
+	for i := 0; i < 20; i++ {
+		if !get(t, client, server.URL, &counter) {
+			t.Fatal("the response was not requested and decompressed as gzip")
+		}
+	}
+	if got := counter.newConns.Load(); got != 1 {
+		t.Errorf("20 sequential requests opened %d connections, want 1", got)
+	}
+	if got := counter.handshakes.Load(); got != 1 {
+		t.Errorf("20 sequential requests made %d TLS handshakes, want 1", got)
+	}
+}
+
+// BenchmarkSequentialRequests compares the configured client with one that opens a connection
+// per request, which is what a burst of Jira calls costs without reuse. Run with
+// "go test ./internal/httpclient -bench SequentialRequests" and compare ns/op and handshakes/op.
+func BenchmarkSequentialRequests(b *testing.B) {
+	server := newGzipTLSServer(b)
+	cfg := config.HTTPClientConfig{CABundlePath: trustServer(b, server), MaxIdleConnsPerHost: 4}
+
+	reused, _, err := New("bench", cfg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	transport.DisableKeepAlives = true
+	perRequest := &http.Client{Transport: transport}
+
+	for _, bench := range []struct {
+		name   string
+		client *http.Client
+	}{
+		{"keep-alive", reused},
+		{"connection-per-request", perRequest},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			var counter connCounter
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				get(b, bench.client, server.URL, &counter)
+			}
+			b.ReportMetric(float64(counter.newConns.Load())/float64(b.N), "conns/op")
+			b.ReportMetric(float64(counter.handshakes.Load())/float64(b.N), "handshakes/op")
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// internal/httpclient/retry.go
+
+package httpclient
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+)
+
+// maxRetryAfter caps how long a Retry-After header can make a request wait.
+const maxRetryAfter = time.Minute
+
+// RetryTransport retries requests that failed transiently: network errors and 502/503/504 for
+// idempotent methods, and 429 for any method, since a throttled request was not processed.
+// Every retry is taken from a run-wide budget shared by all requests through the transport;
+// once it is spent, failures are returned immediately so a degraded dependency can't consume
+// the whole run.
+type RetryTransport struct {
+	base        http.RoundTripper
+	name        string // Used in log lines, e.g. "Jira"
+	maxAttempts int
+	backoff     time.Duration
+
+	budget    int64 // Retries allowed across all requests; negative means unlimited
+	used      atomic.Int64
+	exhausted atomic.Bool
+}
+
+// NewRetryTransport wraps base with retries configured by HTTP_MAX_RETRIES, HTTP_RETRY_BACKOFF
+// and HTTP_RETRY_BUDGET.
+func NewRetryTransport(name string, base http.RoundTripper, cfg config.HTTPClientConfig) *RetryTransport {
+	return &RetryTransport{
+		base:        base,
+		name:        name,
+		maxAttempts: cfg.MaxRetries + 1,
+		backoff:     cfg.RetryBackoff,
+		budget:      int64(cfg.RetryBudget),
+	}
+}
+
+// RetriesUsed returns how many retries have been made so far.
+func (t *RetryTransport) RetriesUsed() int {
+	return int(t.used.Load())
+}
+
+// BudgetExhausted reports whether retries were skipped because the budget ran out.
+func (t *RetryTransport) BudgetExhausted() bool {
+	return t.exhausted.Load()
+}
+
+// takeRetry reserves one retry from the budget.
+func (t *RetryTransport) takeRetry() bool {
+	if t.budget < 0 {
+		t.used.Add(1)
+		return true
+	}
+	for {
+		used := t.used.Load()
+		if used >= t.budget {
+			if !t.exhausted.Swap(true) {
+				log.Printf("WARN: [HTTPClient] %s retry budget of %d (HTTP_RETRY_BUDGET) is exhausted. Further failures will not be retried this run.", t.name, t.budget)
+			}
+			return false
+		}
+		if t.used.CompareAndSwap(used, used+1) {
+			return true
+		}
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay := t.backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.maxAttempts || !retryable(req, resp, err) {
+			return resp, err
+		}
+		// The body has been consumed, so a retry needs a fresh copy.
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		if !t.takeRetry() {
+			return resp, err
+		}
+
+		wait := delay
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = retryAfter
+			}
+			DrainAndClose(resp.Body)
+		}
+		log.Printf("WARN: [HTTPClient] %s %s %s failed (%s), retrying in %v (attempt %d/%d).", t.name, req.Method, req.URL.Redacted(), reason, wait, attempt+1, t.maxAttempts)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%s request cancelled while waiting to retry: %w", t.name, req.Context().Err())
+		}
+		delay *= 2
+	}
+}
+
+// retryable reports whether a failed attempt is worth repeating.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return idempotent(req.Method)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent(req.Method)
+	}
+	return false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter reads a Retry-After header in seconds or as an HTTP date, capped at maxRetryAfter.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		wait = time.Until(at)
+	} else {
+		return 0, false
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	if wait > maxRetryAfter {
+		wait = maxRetryAfter
+	}
+	return wait, true
+}
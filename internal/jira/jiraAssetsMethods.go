@@ -4,20 +4,48 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 
+	"github.com/Devon-ODell/PSDIv0.2/internal/httpclient"
 	"github.com/Devon-ODell/PSDIv0.2/internal/models"
 )
 
 // makeAPIRequest is a generic helper to make authenticated requests to the Jira Assets API.
 func (c *Client) makeAPIRequest(ctx context.Context, method, path string, queryParams url.Values, body io.Reader) ([]byte, int, error) {
-	apiURL, err := url.Parse(c.cfg.JiraAssetsURL)
+	resp, err := c.sendAPIRequest(ctx, method, path, queryParams, body)
 	if err != nil {
-		return nil, 0, fmt.Errorf("invalid Jira Assets URL from config: %w", err)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			return nil, apiErr.StatusCode, err
+		}
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("failed to read Jira API response body: %w", err)
+	}
+
+	return responseBody, resp.StatusCode, nil
+}
+
+// sendAPIRequest sends an authenticated Jira Assets API request and returns the response for
+// the caller to read and close, so large responses can be decoded as they stream in. Every
+// Assets request goes through here, so they all get the same headers, retries and rate limiting.
+// A non-2xx response is returned as an *APIError, with its body already read and closed.
+func (c *Client) sendAPIRequest(ctx context.Context, method, path string, queryParams url.Values, body io.Reader) (*http.Response, error) {
+	apiURL, err := url.Parse(c.assetsBaseURL())
+	if err != nil {
+		return nil, fmt.Errorf("invalid Jira Assets URL from config: %w", err)
 	}
 
 	apiURL = apiURL.JoinPath(path)
@@ -25,184 +53,374 @@ func (c *Client) makeAPIRequest(ctx context.Context, method, path string, queryP
 		apiURL.RawQuery = queryParams.Encode()
 	}
 
+	// A captured request's body is read up front so it can be both sent and recorded.
+	capture := captureFrom(ctx)
+	var exchange Exchange
+	if capture != nil {
+		exchange = Exchange{Method: method, URL: apiURL.String()}
+		if body != nil {
+			data, err := io.ReadAll(body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read Jira API request body: %w", err)
+			}
+			exchange.RequestBody = redactCaptured(data)
+			body = bytes.NewReader(data)
+		}
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, apiURL.String(), body)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create Jira API request: %w", err)
+		return nil, fmt.Errorf("failed to create Jira API request: %w", err)
 	}
 
-	req.SetBasicAuth(c.cfg.JiraAdminEmail, c.cfg.JiraOrgAPIKey)
+	c.authorize(req)
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.cfg.UserAgent)
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
 	log.Printf("INFO: [JiraClient] Making %s request to: %s", method, apiURL.String())
 
+	if err := c.rateLimit.wait(ctx); err != nil {
+		return nil, err
+	}
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to execute Jira API request: %w", err)
+		if capture != nil {
+			exchange.Error = err.Error()
+			capture.add(exchange)
+		}
+		return nil, fmt.Errorf("failed to execute Jira API request: %w", err)
 	}
-	defer resp.Body.Close()
+	c.rateLimit.observe(resp.Header)
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("ERROR: [JiraClient] Jira API returned non-2xx status: %s, body: %s", resp.Status, string(bodyBytes))
-		return nil, resp.StatusCode, fmt.Errorf("Jira API returned non-2xx status: %s", resp.Status)
+		resp.Body.Close()
+		log.Printf("ERROR: [JiraClient] Jira API returned non-2xx status: %s, body: %s", resp.Status, loggableBody(bodyBytes))
+		if capture != nil {
+			exchange.Status = resp.StatusCode
+			exchange.ResponseBody = redactCaptured(bodyBytes)
+			capture.add(exchange)
+		}
+		return nil, &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: bodyBytes}
 	}
-
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("failed to read Jira API response body: %w", err)
+	if capture != nil {
+		captureResponse(capture, exchange, resp)
 	}
+	return resp, nil
+}
 
-	return responseBody, resp.StatusCode, nil
+// maxLoggedBodyBytes caps how much of a response body is written to the log.
+// Bulk AQL responses can run to tens of megabytes, which is useless noise in DEBUG output.
+const maxLoggedBodyBytes = 4096
+
+// bulkLoadPageSize is the number of objects requested per page during the initial asset load.
+const bulkLoadPageSize = 100
+
+// aqlPageResponse models a single page of results from the aql/objects endpoint.
+type aqlPageResponse struct {
+	Entries          []models.EmployeeAssets `json:"objectEntries"`
+	TotalFilterCount int                     `json:"totalFilterCount"`
+	PageNumber       int                     `json:"pageNumber"`
+	PageSize         int                     `json:"pageSize"` // Total number of pages, not objects per page
+	IsLast           bool                    `json:"isLast"`
+}
+
+// isFinalPage reports whether page is the last page of an AQL result. Not every Assets version
+// sends isLast or pageSize, so an empty page also ends the result; a short page doesn't, since
+// Jira may cap resultsPerPage below what was asked for.
+func (r *aqlPageResponse) isFinalPage(page int) bool {
+	return r.IsLast || len(r.Entries) == 0 || r.PageSize > 0 && page >= r.PageSize
+}
+
+// loggableBody returns the body as a string if it is small enough to log, or a size marker otherwise.
+func loggableBody(body []byte) string {
+	if len(body) > maxLoggedBodyBytes {
+		return fmt.Sprintf("<%d bytes, omitted>", len(body))
+	}
+	return string(body)
 }
 
 // GetAllEmployeeAssets fetches all objects of the configured Employee type from Jira Assets.
-func (c *Client) GetAllEmployeeAssets(ctx context.Context) ([]models.EmployeeAssets, error) {
+// The load is paged and only requests the matching and managed attributes, so the
+// payload stays small even for large schemas. Each page is decoded straight from the
+// response stream rather than being buffered in full. extraAttributeIDs are projected as well,
+// for callers that read attributes resolved from the discovered schema rather than AttributeID.
+func (c *Client) GetAllEmployeeAssets(ctx context.Context, extraAttributeIDs ...string) ([]models.EmployeeAssets, error) {
 	// Construct the AQL (Assets Query Language) query to find all "Employee" objects.
 	// We use the configured object type name to make it flexible.
 	aql := fmt.Sprintf(`objectType = "%s"`, c.cfg.JiraEmployeeObjectTypeName)
 
-	apiURL, err := url.Parse(c.cfg.JiraAssetsURL)
-	if err != nil {
-		// Now using the full URL from config, so this validates it.
-		return nil, fmt.Errorf("invalid Jira Assets URL from config: %w", err)
-	}
-
-	// Append the specific path for AQL queries
-	apiURL = apiURL.JoinPath("aql/objects") // Correct path relative to the v1 base
-	// --- END: MODIFICATION ---
-
-	q := apiURL.Query()
-	q.Set("aql", aql)
-	q.Set("resultsPerPage", "100") // Set a reasonable page size
-	apiURL.RawQuery = q.Encode()
+	log.Printf("INFO: [JiraClient] Fetching employee assets with AQL: %s (attributes: %v)", aql, c.projectedAttributeNames())
+
+	var allAssets []models.EmployeeAssets
+	for page := 1; ; page++ {
+		q := url.Values{}
+		q.Set("aql", aql)
+		q.Set("page", strconv.Itoa(page))
+		q.Set("resultsPerPage", strconv.Itoa(bulkLoadPageSize))
+		q.Set("includeAttributes", "true")
+		q.Set("includeAttributesDeep", "0")
+		q.Set("includeTypeAttributes", "false")
+		q.Set("attributesToDisplay", c.projectedAttributeIDs(extraAttributeIDs))
+
+		pageResponse, err := c.fetchAQLPage(ctx, q)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch employee assets page %d: %w", page, err)
+		}
+		allAssets = append(allAssets, pageResponse.Entries...)
+		log.Printf("INFO: [JiraClient] Fetched %d employee assets on page %d (%d of %d total).",
+			len(pageResponse.Entries), page, len(allAssets), pageResponse.TotalFilterCount)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Jira API request: %w", err)
+		if pageResponse.isFinalPage(page) {
+			break
+		}
 	}
 
-	// Set required headers for Jira Cloud API
-	req.SetBasicAuth(c.cfg.JiraAdminEmail, c.cfg.JiraOrgAPIKey)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-
-	log.Printf("INFO: [JiraClient] Fetching employee assets with AQL: %s", aql)
+	log.Printf("INFO: [JiraClient] Successfully unmarshalled %d employee assets from Jira.", len(allAssets))
+	return allAssets, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+// fetchAQLPage performs a single AQL page request and decodes the response directly from the body stream.
+func (c *Client) fetchAQLPage(ctx context.Context, queryParams url.Values) (*aqlPageResponse, error) {
+	resp, err := c.sendAPIRequest(ctx, http.MethodGet, "aql/objects", queryParams, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute Jira API request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	// The decoder stops at the end of the JSON value, so drain the rest to keep the connection.
+	defer httpclient.DrainAndClose(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Jira API returned non-200 status: %s, body: %s", resp.Status, string(bodyBytes))
+	var pageResponse aqlPageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&pageResponse); err != nil {
+		log.Printf("ERROR: [JiraClient] Failed to decode Jira response: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal jira response: %w", err)
 	}
+	return &pageResponse, nil
+}
 
-	// Here you would unmarshal the response and map it to your internal models.
-	// The Jira Assets API response can be complex. You will need to define structs
-	// to match the response and then map the attributes to your `models.EmployeeAsset`.
-	// As a placeholder, we'll just log the body.
-
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	log.Printf("INFO: [JiraClient] Successfully received data from Jira. Body length: %d bytes", len(bodyBytes))
-	log.Printf("DEBUG: [JiraClient] Response Body: %s", string(bodyBytes))
-
-	// 1. Define a struct that matches the Jira AQL response structure.
-	type JiraAQLResponse struct {
-		Entries []models.EmployeeAssets `json:"objectEntries"`
+// projectedAttributeNames lists the attributes requested during the bulk load: the matching
+// keys, every attribute the sync manages, and the configured attributes that are read back
+// from existing assets (Managed By, Paycor ID, rehire date, last synced and those under a
+// write policy).
+func (c *Client) projectedAttributeNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	candidates := append(append([]string{}, models.MatchingAttributes...), models.ManagedAttributes...)
+	for _, name := range []string{c.cfg.JiraManagedByAttribute, c.cfg.JiraPaycorIDAttribute, c.cfg.JiraRehireDateAttribute, c.cfg.JiraLastSyncedAttribute} {
+		if name != "" {
+			candidates = append(candidates, name)
+		}
 	}
-
-	// 2. Unmarshal the response body into this new struct.
-	var jiraResponse JiraAQLResponse
-	if err := json.Unmarshal(bodyBytes, &jiraResponse); err != nil {
-		log.Printf("ERROR: [JiraClient] Failed to unmarshal Jira response: %v", err)
-		return nil, fmt.Errorf("failed to unmarshal jira response: %w", err)
+	for name := range c.cfg.JiraWritePolicies {
+		candidates = append(candidates, name)
 	}
-
-	// 3. Return the entries from the parsed response.
-	log.Printf("INFO: [JiraClient] Successfully unmarshalled %d employee assets from Jira.", len(jiraResponse.Entries))
-	return jiraResponse.Entries, nil
+	for _, name := range candidates {
+		if _, ok := models.AttributeID[name]; ok && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
-// FindObjectsByAQL fetches objects from Jira Assets using a given AQL query.
-func (c *Client) FindObjectsByAQL(ctx context.Context, aql string) ([]models.EmployeeAssets, error) {
-	queryParams := url.Values{}
-	queryParams.Set("aql", aql)
-	queryParams.Set("resultsPerPage", "100")
-
-	body, statusCode, err := c.makeAPIRequest(ctx, http.MethodGet, "aql/objects", queryParams, nil)
-	if err != nil {
-		return nil, err
+// projectedAttributeIDs returns the projected attribute IDs, plus any extra IDs not already
+// among them, as the comma-separated list the Assets API expects.
+func (c *Client) projectedAttributeIDs(extraAttributeIDs []string) string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, name := range c.projectedAttributeNames() {
+		id := strconv.Itoa(models.AttributeID[name])
+		seen[id] = true
+		ids = append(ids, id)
 	}
+	for _, id := range extraAttributeIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return strings.Join(ids, ",")
+}
 
-	// Log the raw response body from Jira for diagnosis
+// aqlResultsPerPage is the page size used when following AQL result pages.
+const aqlResultsPerPage = 100
+
+// FindObjectsByAQL fetches every object matching an AQL query from Jira Assets, following
+// the result pages to completion. It also returns the total match count reported by Jira.
+func (c *Client) FindObjectsByAQL(ctx context.Context, aql string) ([]models.EmployeeAssets, int, error) {
 	log.Printf("DEBUG: [FindObjectsByAQL] AQL Query: %s", aql)
-	log.Printf("DEBUG: [FindObjectsByAQL] Raw Response Body: %s", string(body))
-	// --- END DEBUG LOGGING ---
 
-	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("Jira API returned non-200 status for AQL query: %d, body: %s", statusCode, string(body))
-	}
+	var entries []models.EmployeeAssets
+	total := 0
+	for page := 1; ; page++ {
+		queryParams := url.Values{}
+		queryParams.Set("aql", aql)
+		queryParams.Set("page", strconv.Itoa(page))
+		queryParams.Set("resultsPerPage", strconv.Itoa(aqlResultsPerPage))
+
+		body, statusCode, err := c.makeAPIRequest(ctx, http.MethodGet, "aql/objects", queryParams, nil)
+		if err != nil {
+			return nil, 0, err
+		}
 
-	var response struct {
-		Entries []models.EmployeeAssets `json:"objectEntries"`
-	}
+		// Log the raw response body from Jira for diagnosis
+		log.Printf("DEBUG: [FindObjectsByAQL] Raw Response Body (page %d): %s", page, loggableBody(body))
+
+		if statusCode != http.StatusOK {
+			return nil, 0, fmt.Errorf("Jira API returned non-200 status for AQL query: %d, body: %s", statusCode, loggableBody(body))
+		}
+
+		var response aqlPageResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, 0, fmt.Errorf("failed to unmarshal AQL response: %w. Body: %s", err, loggableBody(body))
+		}
+		entries = append(entries, response.Entries...)
+		total = response.TotalFilterCount
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal AQL response: %w. Body: %s", err, string(body))
+		if response.isFinalPage(page) {
+			break
+		}
 	}
 
-	return response.Entries, nil
+	if total < len(entries) {
+		// Older Assets versions omit totalFilterCount; fall back to what we actually received.
+		total = len(entries)
+	}
+	return entries, total, nil
 }
 
-// REVISED FindOrCreateRole with verification logic
-func (c *Client) FindOrCreateRole(ctx context.Context, roleName string) (string, error) {
+// FindRole looks up an existing Role object by name without creating one.
+// It returns an empty key if no matching role exists.
+func (c *Client) FindRole(ctx context.Context, roleName string) (string, error) {
 	if roleName == "" {
 		return "", nil
 	}
 
-	aql := fmt.Sprintf(`objectType = "%s" AND "Name" = "%s"`, c.cfg.JiraRoleObjectTypeName, roleName)
+	aql := fmt.Sprintf(`objectType = %s AND "Name" = %s`, aqlString(c.cfg.JiraRoleObjectTypeName), aqlString(roleName))
 
-	existingAssets, err := c.FindObjectsByAQL(ctx, aql)
+	existingAssets, total, err := c.FindObjectsByAQL(ctx, aql)
 	if err != nil {
 		return "", fmt.Errorf("error searching for role '%s': %w", roleName, err)
 	}
+	if total > 1 {
+		log.Printf("WARN: [JiraMethods] Found %d objects matching role '%s'; using the first valid one.", total, roleName)
+	}
 
-	// NEW: Verify the results from the AQL query.
+	// Verify the results from the AQL query.
 	// Do not trust the API blindly; check that the returned object is actually a Role.
 	for _, asset := range existingAssets {
 		if asset.ObjectType.Name == c.cfg.JiraRoleObjectTypeName {
 			log.Printf("INFO: [JiraMethods] Verified and found existing role '%s' with key %s", roleName, asset.ObjectKey)
 			return asset.ObjectKey, nil // Found a valid role, return its key.
-		} else {
-			// This log will fire if the AQL query is still misbehaving.
-			log.Printf("WARN: [JiraMethods] AQL query for Roles returned an object of the WRONG TYPE. Got ObjectKey: %s, Type: '%s'. Expected Type: '%s'. Discarding this result.", asset.ObjectKey, asset.ObjectType.Name, c.cfg.JiraRoleObjectTypeName)
 		}
+		// This log will fire if the AQL query is still misbehaving.
+		log.Printf("WARN: [JiraMethods] AQL query for Roles returned an object of the WRONG TYPE. Got ObjectKey: %s, Type: '%s'. Expected Type: '%s'. Discarding this result.", asset.ObjectKey, asset.ObjectType.Name, c.cfg.JiraRoleObjectTypeName)
+	}
+	return "", nil
+}
+
+// FindOrCreateRole returns the key of the named Role object, creating it if it doesn't exist.
+// created reports whether the role was created by this call.
+func (c *Client) FindOrCreateRole(ctx context.Context, roleName string) (roleKey string, created bool, err error) {
+	if roleName == "" {
+		return "", false, nil
+	}
+
+	roleKey, err = c.FindRole(ctx, roleName)
+	if err != nil {
+		return "", false, err
+	}
+	if roleKey != "" {
+		return roleKey, false, nil
 	}
 
 	// If no valid role was found in the loop, create a new one.
 	log.Printf("INFO: [JiraMethods] No valid role '%s' found. Creating new asset.", roleName)
+	roleKey, err = c.CreateRole(ctx, roleName)
+	if err != nil {
+		return "", false, err
+	}
+	return roleKey, true, nil
+}
+
+// CreateRole creates a Role object without looking for an existing one first, and returns its key.
+func (c *Client) CreateRole(ctx context.Context, roleName string) (string, error) {
 	newRole, err := c.CreateRoleAsset(ctx, roleName)
 	if err != nil {
 		return "", fmt.Errorf("failed to create new role asset for '%s': %w", roleName, err)
 	}
-	if newRole == nil {
-		return "", fmt.Errorf("creation of role '%s' returned a nil object", roleName)
+	if newRole == nil || newRole.ObjectKey == "" {
+		return "", fmt.Errorf("creation of role '%s' returned no object key", roleName)
 	}
 
 	log.Printf("SUCCESS: [JiraMethods] Successfully created new role '%s' with key %s.", roleName, newRole.ObjectKey)
 	return newRole.ObjectKey, nil
 }
 
+// Names are looked up in batches of at most this many names, and the IN list of a batch
+// is kept under roleBatchMaxChars so the AQL fits in the request URL.
+const (
+	roleBatchSize     = 50
+	roleBatchMaxChars = 2000
+)
+
+// FindRoles looks up many Role objects by name with one AQL "IN" query per batch of names.
+// The result maps each found name, lowercased, to its object key; names with no role are absent.
+func (c *Client) FindRoles(ctx context.Context, roleNames []string) (map[string]string, error) {
+	return c.findObjectsByName(ctx, c.cfg.JiraRoleObjectTypeName, "role", roleNames)
+}
+
+// findObjectsByName looks up many objects of one type by name, as FindRoles does for Roles.
+// kind names the objects in errors and logs.
+func (c *Client) findObjectsByName(ctx context.Context, objectTypeName, kind string, names []string) (map[string]string, error) {
+	found := make(map[string]string)
+	for start := 0; start < len(names); {
+		var quoted []string
+		length := 0
+		end := start
+		for end < len(names) && len(quoted) < roleBatchSize {
+			name := aqlString(names[end])
+			if len(quoted) > 0 && length+len(name)+2 > roleBatchMaxChars {
+				break
+			}
+			quoted = append(quoted, name)
+			length += len(name) + 2
+			end++
+		}
+
+		aql := fmt.Sprintf(`objectType = %s AND "Name" IN (%s)`, aqlString(objectTypeName), strings.Join(quoted, ", "))
+		assets, _, err := c.FindObjectsByAQL(ctx, aql)
+		if err != nil {
+			return nil, fmt.Errorf("error searching for %ss %d-%d of %d: %w", kind, start+1, end, len(names), err)
+		}
+		for _, asset := range assets {
+			// As in FindRole, only trust objects of the type that was asked for.
+			if asset.ObjectType.Name != objectTypeName {
+				continue
+			}
+			name := strings.ToLower(asset.Label)
+			if _, seen := found[name]; seen {
+				log.Printf("WARN: [JiraMethods] Found several objects matching %s '%s'; using %s.", kind, asset.Label, found[name])
+				continue
+			}
+			found[name] = asset.ObjectKey
+		}
+		start = end
+	}
+	return found, nil
+}
+
+// aqlString quotes a value for use in an AQL query.
+func aqlString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
 // CreateRoleAsset creates a new Role asset.
 func (c *Client) CreateRoleAsset(ctx context.Context, roleName string) (*models.EmployeeAssets, error) {
-	// The "Name" attribute ID for a Role object might be different from an Employee's.
-	// You must find this in your Jira Schema configuration. Using a placeholder.
-	const roleNameAttributeID = "78" // VERIFY THIS ID
+	// The "Name" attribute ID for a Role object is different from an Employee's.
+	roleNameAttributeID := strconv.Itoa(models.AttributeID[models.RoleNameAttribute])
 
 	attributes := []models.AssetAttribute{
 		{ObjectTypeAttributeID: roleNameAttributeID, Values: []models.Value{{Value: roleName}}},
@@ -210,15 +428,67 @@ func (c *Client) CreateRoleAsset(ctx context.Context, roleName string) (*models.
 	return c.createObject(ctx, c.cfg.JiraRoleObjectTypeID, attributes)
 }
 
-// CreateEmployeeAsset creates a new Employee asset.
-func (c *Client) CreateEmployeeAsset(ctx context.Context, assetData models.EmployeeAssets) (*models.EmployeeAssets, error) {
-	return c.createObject(ctx, c.cfg.JiraEmployeeObjectTypeID, assetData.Attributes)
+// CreateOutcome says how CreateEmployeeAsset ended up with its object.
+type CreateOutcome string
+
+const (
+	CreateOutcomeCreated         CreateOutcome = "created"          // A new object was created
+	CreateOutcomeUpdatedExisting CreateOutcome = "updated-existing" // The create conflicted and the existing object was updated instead
+)
+
+// CreateEmployeeAsset creates a new Employee asset and reports whether it was created.
+// If Jira rejects the create because the employee already exists (for example when an earlier
+// bulk load missed it) and the conflict fallback is enabled, the existing object is looked up
+// by email and updated instead, and CreateOutcomeUpdatedExisting is returned with it.
+func (c *Client) CreateEmployeeAsset(ctx context.Context, assetData models.EmployeeAssets) (*models.EmployeeAssets, CreateOutcome, error) {
+	var newObject *models.EmployeeAssets
+	err := c.writeWithOptionRetry(ctx, c.cfg.JiraEmployeeObjectTypeID, func() error {
+		var createErr error
+		newObject, createErr = c.createObject(ctx, c.cfg.JiraEmployeeObjectTypeID, assetData.Attributes)
+		return createErr
+	})
+	if err == nil {
+		return newObject, CreateOutcomeCreated, nil
+	}
+	if !c.cfg.JiraCreateConflictFallback || !IsDuplicateError(err) {
+		return nil, "", err
+	}
+
+	email := models.AttributeValue(assetData.Attributes, "Email")
+	if email == "" {
+		return nil, "", fmt.Errorf("create conflicted but the asset has no email to look it up by: %w", err)
+	}
+	log.Printf("WARN: [JiraMethods] Create conflicted for '%s'. Looking up the existing object to update instead.", email)
+
+	aql := fmt.Sprintf(`objectType = %s AND "Email" = %s`, aqlString(c.cfg.JiraEmployeeObjectTypeName), aqlString(email))
+	matches, _, findErr := c.FindObjectsByAQL(ctx, aql)
+	if findErr != nil {
+		return nil, "", fmt.Errorf("create conflicted and the lookup of '%s' failed: %v: %w", email, findErr, err)
+	}
+	if len(matches) == 0 {
+		return nil, "", fmt.Errorf("create conflicted but no existing object was found for '%s': %w", email, err)
+	}
+
+	existing := matches[0]
+	if err := c.UpdateEmployeeAsset(ctx, existing.ID, assetData); err != nil {
+		return nil, "", fmt.Errorf("create conflicted and the fallback update of %s failed: %w", existing.ObjectKey, err)
+	}
+	log.Printf("SUCCESS: [JiraMethods] Updated existing object %s after create conflict for '%s'.", existing.ObjectKey, email)
+	return &existing, CreateOutcomeUpdatedExisting, nil
 }
 
 // UpdateEmployeeAsset updates an existing Employee asset in Jira.
+// Missing select options are handled as described on writeWithOptionRetry.
 func (c *Client) UpdateEmployeeAsset(ctx context.Context, objectID string, assetData models.EmployeeAssets) error {
+	return c.writeWithOptionRetry(ctx, c.cfg.JiraEmployeeObjectTypeID, func() error {
+		return c.updateObject(ctx, objectID, assetData.Attributes)
+	})
+}
+
+// updateObject is a generic helper to update the attributes of any asset object.
+func (c *Client) updateObject(ctx context.Context, objectID string, attributes []models.AssetAttribute) error {
 	path := fmt.Sprintf("object/%s", objectID)
-	reqBody := map[string]interface{}{"attributes": assetData.Attributes}
+	reqBody := map[string]interface{}{"attributes": attributes}
 
 	bodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
@@ -228,6 +498,7 @@ func (c *Client) UpdateEmployeeAsset(ctx context.Context, objectID string, asset
 	_, statusCode, err := c.makeAPIRequest(ctx, http.MethodPut, path, nil, bytes.NewReader(bodyBytes))
 
 	if err != nil {
+		logFailedPayload(fmt.Sprintf("Update of object %s", objectID), bodyBytes, err)
 		return err
 	}
 	if statusCode != http.StatusOK {
@@ -249,6 +520,7 @@ func (c *Client) createObject(ctx context.Context, objectTypeID string, attribut
 
 	respBody, statusCode, err := c.makeAPIRequest(ctx, http.MethodPost, "object/create", nil, bytes.NewReader(bodyBytes))
 	if err != nil {
+		logFailedPayload(fmt.Sprintf("Create in object type %s", objectTypeID), bodyBytes, err)
 		return nil, err
 	}
 	if statusCode != http.StatusCreated {
@@ -259,6 +531,108 @@ func (c *Client) createObject(ctx context.Context, objectTypeID string, attribut
 	if err := json.Unmarshal(respBody, &newObject); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal create response: %w. Body: %s", err, string(respBody))
 	}
+
+	// Some Assets versions answer a create with a sparse object. Callers rely on the key
+	// (a new role's key becomes the employee's Job Role), so fetch the full object if needed.
+	if newObject.ObjectKey == "" || newObject.Label == "" {
+		if newObject.ID == "" {
+			return nil, fmt.Errorf("create response has neither an object key nor an ID. Body: %s", loggableBody(respBody))
+		}
+		log.Printf("INFO: [JiraMethods] Create response for object %s was sparse; fetching the full object.", newObject.ID)
+		hydrated, err := c.GetObject(ctx, newObject.ID)
+		if err != nil {
+			return nil, fmt.Errorf("object %s was created but could not be read back: %w", newObject.ID, err)
+		}
+		if hydrated.ObjectKey == "" {
+			return nil, fmt.Errorf("object %s was created but Jira returned no object key for it", newObject.ID)
+		}
+		newObject = *hydrated
+	}
 	log.Printf("SUCCESS: [JiraMethods] Successfully created object with key %s.", newObject.ObjectKey)
 	return &newObject, nil
 }
+
+// GetObject fetches a single asset object, with all its attributes, by ID.
+// It returns a *NotFoundError if the object doesn't exist.
+func (c *Client) GetObject(ctx context.Context, objectID string) (*models.EmployeeAssets, error) {
+	if objectID == "" {
+		return nil, fmt.Errorf("cannot get object: object ID is empty")
+	}
+	respBody, _, err := c.makeAPIRequest(ctx, http.MethodGet, fmt.Sprintf("object/%s", objectID), nil, nil)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, &NotFoundError{Object: objectID}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", objectID, err)
+	}
+	var object models.EmployeeAssets
+	if err := json.Unmarshal(respBody, &object); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal object %s: %w", objectID, err)
+	}
+	return &object, nil
+}
+
+// objectKeyPattern matches an Assets object key such as "HR-123".
+var objectKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*-[0-9]+$`)
+
+// GetObjectByKey fetches a single asset object, with all its attributes, by object key.
+// Each attribute carries its definition, so models.EmployeeAssets.AttributesByName can name
+// them. It returns a *NotFoundError if no object has the key.
+func (c *Client) GetObjectByKey(ctx context.Context, objectKey string) (*models.EmployeeAssets, error) {
+	if !objectKeyPattern.MatchString(objectKey) {
+		return nil, fmt.Errorf("invalid object key '%s'", objectKey)
+	}
+	// The object endpoint only accepts IDs, so resolve the key with AQL first.
+	matches, _, err := c.FindObjectsByAQL(ctx, fmt.Sprintf(`Key = "%s"`, objectKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up object %s: %w", objectKey, err)
+	}
+	for _, match := range matches {
+		if strings.EqualFold(match.ObjectKey, objectKey) {
+			object, err := c.GetObject(ctx, match.ID)
+			if IsNotFound(err) {
+				// Deleted between the lookup and the read.
+				return nil, &NotFoundError{Object: objectKey}
+			}
+			return object, err
+		}
+	}
+	return nil, &NotFoundError{Object: objectKey}
+}
+
+// DeleteObject permanently removes an asset object from Jira Assets.
+func (c *Client) DeleteObject(ctx context.Context, objectID string) error {
+	if objectID == "" {
+		return fmt.Errorf("cannot delete object: object ID is empty")
+	}
+	path := fmt.Sprintf("object/%s", objectID)
+
+	_, _, err := c.makeAPIRequest(ctx, http.MethodDelete, path, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete object %s: %w", objectID, err)
+	}
+	log.Printf("SUCCESS: [JiraMethods] Successfully deleted object with ID %s.", objectID)
+	return nil
+}
+
+// AddObjectComment posts a comment on an Assets object, visible in the object's Comments tab.
+func (c *Client) AddObjectComment(ctx context.Context, objectID, comment string) error {
+	if objectID == "" {
+		return fmt.Errorf("cannot comment on object: object ID is empty")
+	}
+	payload := struct {
+		ObjectID string `json:"objectId"`
+		Comment  string `json:"comment"`
+		Role     int    `json:"role"` // 0 makes the comment visible to everyone who can see the object
+	}{ObjectID: objectID, Comment: comment}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal object comment: %w", err)
+	}
+
+	if _, _, err := c.makeAPIRequest(ctx, http.MethodPost, "comment/create", nil, bytes.NewReader(body)); err != nil {
+		return fmt.Errorf("failed to comment on object %s: %w", objectID, err)
+	}
+	return nil
+}
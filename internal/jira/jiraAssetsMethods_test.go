@@ -0,0 +1,278 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+func TestCreateEmployeeAssetConflictQuotesEmail(t *testing.T) {
+	email := `o"brien\x@example.com`
+	var gotAQL string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /object/create", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errorMessages":["duplicate"]}`, http.StatusConflict)
+	})
+	mux.HandleFunc("GET /aql/objects", func(w http.ResponseWriter, r *http.Request) {
+		gotAQL = r.URL.Query().Get("aql")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"objectEntries": []models.EmployeeAssets{{ID: "42", ObjectKey: "EMP-42"}},
+			"isLast":        true,
+		})
+	})
+	mux.HandleFunc("PUT /object/42", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	client := newTestClient(t, mux)
+	client.cfg.JiraCreateConflictFallback = true
+
+	asset := models.EmployeeAssets{Attributes: []models.AssetAttribute{
+		{ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Email"]), Values: []models.Value{{Value: email}}},
+	}}
+	existing, outcome, err := client.CreateEmployeeAsset(context.Background(), asset)
+	if err != nil {
+		t.Fatalf("CreateEmployeeAsset: %v", err)
+	}
+	if outcome != CreateOutcomeUpdatedExisting || existing.ObjectKey != "EMP-42" {
+		t.Errorf("got %s %s, want %s EMP-42", outcome, existing.ObjectKey, CreateOutcomeUpdatedExisting)
+	}
+	if want := `objectType = "Employees" AND "Email" = "o\"brien\\x@example.com"`; gotAQL != want {
+		t.Errorf("AQL = %s, want %s", gotAQL, want)
+	}
+}
+
+func TestFindRoleQuotesTitle(t *testing.T) {
+	var gotAQL string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /aql/objects", func(w http.ResponseWriter, r *http.Request) {
+		gotAQL = r.URL.Query().Get("aql")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"objectEntries": []models.EmployeeAssets{{ObjectKey: "ROLE-1", ObjectType: models.ObjectTypeInfo{Name: "Role"}}},
+			"isLast":        true,
+		})
+	})
+	client := newTestClient(t, mux)
+
+	key, err := client.FindRole(context.Background(), `Engineer "Lead"`)
+	if err != nil {
+		t.Fatalf("FindRole: %v", err)
+	}
+	if key != "ROLE-1" {
+		t.Errorf("key = %q, want ROLE-1", key)
+	}
+	if want := `objectType = "Role" AND "Name" = "Engineer \"Lead\""`; gotAQL != want {
+		t.Errorf("AQL = %s, want %s", gotAQL, want)
+	}
+}
+
+// aqlPages serves fixed AQL result pages, numbered from 1. With paging metadata each page
+// carries isLast and pageSize; without it only the entries are sent, as on older Assets versions.
+// Requests past the last page get an empty page.
+type aqlPages struct {
+	pages    [][]models.EmployeeAssets
+	metadata bool
+	requests []url.Values
+}
+
+func (p *aqlPages) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	p.requests = append(p.requests, query)
+	page, _ := strconv.Atoi(query.Get("page"))
+	response := map[string]interface{}{"objectEntries": []models.EmployeeAssets{}}
+	if page >= 1 && page <= len(p.pages) {
+		response["objectEntries"] = p.pages[page-1]
+	}
+	if p.metadata {
+		total := 0
+		for _, entries := range p.pages {
+			total += len(entries)
+		}
+		response["isLast"] = page >= len(p.pages)
+		response["pageSize"] = len(p.pages)
+		response["totalFilterCount"] = total
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// assetPage returns n assets with sequential IDs starting at first.
+func assetPage(first, n int) []models.EmployeeAssets {
+	assets := make([]models.EmployeeAssets, n)
+	for i := range assets {
+		id := strconv.Itoa(first + i)
+		assets[i] = models.EmployeeAssets{ID: id, ObjectKey: "EMP-" + id, ObjectType: models.ObjectTypeInfo{Name: "Employees"}}
+	}
+	return assets
+}
+
+func TestAQLPagingWithoutMetadata(t *testing.T) {
+	// A short first page must not end the result: Jira may cap resultsPerPage.
+	pages := &aqlPages{pages: [][]models.EmployeeAssets{assetPage(1, 50), assetPage(51, 20)}}
+	client := newTestClient(t, pages)
+
+	entries, total, err := client.FindObjectsByAQL(context.Background(), `objectType = "Employees"`)
+	if err != nil {
+		t.Fatalf("FindObjectsByAQL: %v", err)
+	}
+	if len(entries) != 70 || total != 70 {
+		t.Errorf("FindObjectsByAQL returned %d entries, total %d; want 70, 70", len(entries), total)
+	}
+	if len(pages.requests) != 3 {
+		t.Errorf("FindObjectsByAQL made %d requests, want 3 (two pages and an empty one)", len(pages.requests))
+	}
+
+	pages.requests = nil
+	assets, err := client.GetAllEmployeeAssets(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllEmployeeAssets: %v", err)
+	}
+	if len(assets) != 70 {
+		t.Errorf("GetAllEmployeeAssets returned %d assets, want 70", len(assets))
+	}
+}
+
+func TestFindObjectsByAQLFollowsPages(t *testing.T) {
+	pages := &aqlPages{pages: [][]models.EmployeeAssets{assetPage(1, aqlResultsPerPage), assetPage(aqlResultsPerPage+1, 7)}, metadata: true}
+	client := newTestClient(t, pages)
+
+	entries, total, err := client.FindObjectsByAQL(context.Background(), `objectType = "Role"`)
+	if err != nil {
+		t.Fatalf("FindObjectsByAQL: %v", err)
+	}
+	if len(entries) != aqlResultsPerPage+7 || total != aqlResultsPerPage+7 {
+		t.Errorf("got %d entries, total %d; want %d", len(entries), total, aqlResultsPerPage+7)
+	}
+	// Both pages are merged in order.
+	if entries[0].ObjectKey != "EMP-1" || entries[len(entries)-1].ObjectKey != "EMP-107" {
+		t.Errorf("entries run %s..%s, want EMP-1..EMP-107", entries[0].ObjectKey, entries[len(entries)-1].ObjectKey)
+	}
+	// isLast on page 2 stops the paging without asking for a third page.
+	if len(pages.requests) != 2 {
+		t.Fatalf("made %d requests, want 2", len(pages.requests))
+	}
+	for i, query := range pages.requests {
+		if got, want := query.Get("page"), strconv.Itoa(i+1); got != want {
+			t.Errorf("request %d asked for page %s, want %s", i+1, got, want)
+		}
+	}
+}
+
+func TestGetAllEmployeeAssetsFollowsPages(t *testing.T) {
+	pages := &aqlPages{pages: [][]models.EmployeeAssets{assetPage(1, bulkLoadPageSize), assetPage(bulkLoadPageSize+1, bulkLoadPageSize)}, metadata: true}
+	client := newTestClient(t, pages)
+
+	assets, err := client.GetAllEmployeeAssets(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllEmployeeAssets: %v", err)
+	}
+	if len(assets) != 2*bulkLoadPageSize {
+		t.Errorf("got %d assets, want %d", len(assets), 2*bulkLoadPageSize)
+	}
+	if len(pages.requests) != 2 {
+		t.Errorf("made %d requests, want 2", len(pages.requests))
+	}
+	if got := pages.requests[0].Get("attributesToDisplay"); got == "" {
+		t.Error("the bulk load did not project its attributes")
+	}
+}
+
+// BenchmarkGetAllEmployeeAssets measures the bulk load's allocations for 2,000 employees with
+// a dozen attributes each, served as pre-encoded pages.
+func BenchmarkGetAllEmployeeAssets(b *testing.B) {
+	const pageCount = 20
+	bodies := make([][]byte, pageCount)
+	for page := range bodies {
+		entries := assetPage(page*bulkLoadPageSize+1, bulkLoadPageSize)
+		for i := range entries {
+			for attribute := 0; attribute < 12; attribute++ {
+				entries[i].Attributes = append(entries[i].Attributes, models.AssetAttribute{
+					ObjectTypeAttributeID: strconv.Itoa(80 + attribute),
+					Values:                []models.Value{{Value: strings.Repeat("x", 40), DisplayValue: strings.Repeat("x", 40)}},
+				})
+			}
+		}
+		body, err := json.Marshal(map[string]interface{}{
+			"objectEntries": entries,
+			"isLast":        page == pageCount-1,
+			"pageSize":      pageCount,
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		bodies[page] = body
+	}
+	client := newTestClient(b, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.Write(bodies[page-1])
+	}))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		assets, err := client.GetAllEmployeeAssets(context.Background())
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(assets) != pageCount*bulkLoadPageSize {
+			b.Fatalf("got %d assets, want %d", len(assets), pageCount*bulkLoadPageSize)
+		}
+	}
+}
+
+func TestCreateRoleReturnsObjectKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		createBody   string
+		wantHydrated bool
+	}{
+		{name: "full create response", createBody: `{"id":"55","objectKey":"ROLE-55","label":"Engineer"}`},
+		{name: "sparse create response", createBody: `{"id":"55"}`, wantHydrated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hydrated := false
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /object/create", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(tt.createBody))
+			})
+			mux.HandleFunc("GET /object/55", func(w http.ResponseWriter, r *http.Request) {
+				hydrated = true
+				w.Write([]byte(`{"id":"55","objectKey":"ROLE-55","label":"Engineer","attributes":[]}`))
+			})
+			client := newTestClient(t, mux)
+
+			key, err := client.CreateRole(context.Background(), "Engineer")
+			if err != nil {
+				t.Fatalf("CreateRole: %v", err)
+			}
+			if key != "ROLE-55" {
+				t.Errorf("key = %q, want ROLE-55", key)
+			}
+			if hydrated != tt.wantHydrated {
+				t.Errorf("object read back = %t, want %t", hydrated, tt.wantHydrated)
+			}
+		})
+	}
+}
+
+func TestCreateRoleFailsWithoutObjectKey(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /object/create", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"55"}`))
+	})
+	mux.HandleFunc("GET /object/55", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"55","attributes":[]}`))
+	})
+	client := newTestClient(t, mux)
+
+	if key, err := client.CreateRole(context.Background(), "Engineer"); err == nil {
+		t.Errorf("CreateRole returned key %q and no error, want an error", key)
+	}
+}
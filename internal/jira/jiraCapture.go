@@ -0,0 +1,76 @@
+// internal/jira/jiraCapture.go
+
+package jira
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Exchange is one captured Assets API request and its response. Bodies pass through the same
+// redaction as logged payloads, and large ones are replaced with a size marker.
+type Exchange struct {
+	Method       string `json:"method"`
+	URL          string `json:"url"`
+	RequestBody  string `json:"requestBody,omitempty"`
+	Status       int    `json:"status,omitempty"` // 0 when the request never got a response
+	ResponseBody string `json:"responseBody,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Capture collects the Assets API exchanges made with a context returned by WithCapture, so the
+// requests for a single employee can be inspected without logging everyone's.
+type Capture struct {
+	mu        sync.Mutex
+	exchanges []Exchange
+}
+
+// Exchanges returns the exchanges captured so far, in the order they were made.
+func (c *Capture) Exchanges() []Exchange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Exchange(nil), c.exchanges...)
+}
+
+func (c *Capture) add(exchange Exchange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.exchanges = append(c.exchanges, exchange)
+}
+
+type captureKey struct{}
+
+// WithCapture returns a context whose Assets API requests are recorded in capture.
+func WithCapture(ctx context.Context, capture *Capture) context.Context {
+	return context.WithValue(ctx, captureKey{}, capture)
+}
+
+func captureFrom(ctx context.Context) *Capture {
+	capture, _ := ctx.Value(captureKey{}).(*Capture)
+	return capture
+}
+
+// captureResponse records a successful response, replacing its body with a copy so the caller
+// can still read it.
+func captureResponse(capture *Capture, exchange Exchange, resp *http.Response) {
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	exchange.Status = resp.StatusCode
+	exchange.ResponseBody = redactCaptured(data)
+	if err != nil {
+		exchange.Error = err.Error()
+	}
+	capture.add(exchange)
+}
+
+// redactCaptured redacts a captured body, leaving empty bodies empty.
+func redactCaptured(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+	return redactPayload(body)
+}
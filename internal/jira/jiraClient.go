@@ -3,35 +3,109 @@
 package jira
 
 import (
-	/* 	"context"
-	   	"encoding/json" */
 	"fmt"
-	/* "io"
-	"log"
-	"net/url" */
 	"net/http"
-
-	"time"
+	"sync"
 
 	"github.com/Devon-ODell/PSDIv0.2/internal/config"
-	/* "github.com/Devon-ODell/PSDIv0.2/internal/models" */)
+	"github.com/Devon-ODell/PSDIv0.2/internal/httpclient"
+)
 
 // Client manages communication with the Jira API.
 type Client struct {
 	cfg        config.JiraConfig
 	httpClient *http.Client
+	retries    *httpclient.RetryTransport
+	rateLimit  *rateLimiter
+
+	// issueTypes caches the issue type names of each project issues have been created in,
+	// by project key.
+	issueTypesMu sync.Mutex
+	issueTypes   map[string][]string
 }
 
 // NewClient creates a new Jira API client.
 func NewClient(cfg config.JiraConfig) (*Client, error) {
-	if cfg.JiraAdminEmail == "" || cfg.JiraOrgAPIKey == "" || cfg.JiraSiteName == "" || cfg.JiraWorkspaceID == "" {
-		return nil, fmt.Errorf("Jira client configuration is incomplete (Email, API Key, Site Name, Workspace ID are required)")
+	switch cfg.JiraDeploymentType {
+	case config.JiraDeploymentCloud, "":
+		cfg.JiraDeploymentType = config.JiraDeploymentCloud
+		if cfg.JiraSiteName == "" || cfg.JiraWorkspaceID == "" {
+			return nil, fmt.Errorf("Jira client configuration is incomplete (Site Name and Workspace ID are required)")
+		}
+	case config.JiraDeploymentServer:
+		if cfg.JiraSiteName == "" {
+			return nil, fmt.Errorf("Jira Server client configuration is incomplete (Site Name is required)")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported Jira deployment type '%s'", cfg.JiraDeploymentType)
+	}
+
+	// Default the auth mode from the deployment type to preserve existing behavior.
+	if cfg.JiraAuthMode == "" {
+		cfg.JiraAuthMode = config.JiraAuthBasic
+		if cfg.JiraDeploymentType == config.JiraDeploymentServer {
+			cfg.JiraAuthMode = config.JiraAuthBearer
+		}
+	}
+	switch cfg.JiraAuthMode {
+	case config.JiraAuthBasic:
+		if cfg.JiraAdminEmail == "" || cfg.JiraOrgAPIKey == "" {
+			return nil, fmt.Errorf("Jira basic auth requires JIRA_ADMIN_EMAIL and JIRA_ORG_API_KEY")
+		}
+	case config.JiraAuthBearer:
+		if cfg.JiraBearerToken == "" {
+			return nil, fmt.Errorf("Jira bearer auth requires JIRA_BEARER_TOKEN")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported Jira auth mode '%s' (expected '%s' or '%s')", cfg.JiraAuthMode, config.JiraAuthBasic, config.JiraAuthBearer)
+	}
+
+	httpClient, retries, err := httpclient.New("Jira", cfg.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Jira HTTP client configuration: %w", err)
 	}
 
 	return &Client{
-		cfg: cfg,
-		httpClient: &http.Client{
-			Timeout: 60 * time.Second,
-		},
+		cfg:        cfg,
+		httpClient: httpClient,
+		retries:    retries,
+		rateLimit:  newRateLimiter(cfg.JiraRateLimitMinRemaining),
+		issueTypes: make(map[string][]string),
 	}, nil
 }
+
+// RetriesUsed returns how many request retries the client has made, out of HTTP_RETRY_BUDGET.
+func (c *Client) RetriesUsed() int {
+	return c.retries.RetriesUsed()
+}
+
+// isServer reports whether the client talks to Jira Server / Data Center rather than Cloud.
+func (c *Client) isServer() bool {
+	return c.cfg.JiraDeploymentType == config.JiraDeploymentServer
+}
+
+// assetsBaseURL returns the Assets REST base URL. On Server it defaults to the
+// Insight endpoint on the Jira site when JIRA_ASSETS_URL is not set.
+func (c *Client) assetsBaseURL() string {
+	if c.cfg.JiraAssetsURL == "" && c.isServer() {
+		return fmt.Sprintf("https://%s/rest/insight/1.0", c.cfg.JiraSiteName)
+	}
+	return c.cfg.JiraAssetsURL
+}
+
+// standardAPIPath returns the REST API path segments: v3 on Cloud, v2 on Server.
+func (c *Client) standardAPIPath() []string {
+	if c.isServer() {
+		return []string{"rest", "api", "2"}
+	}
+	return []string{"rest", "api", "3"}
+}
+
+// authorize sets the authentication header for the configured auth mode.
+func (c *Client) authorize(req *http.Request) {
+	if c.cfg.JiraAuthMode == config.JiraAuthBearer {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.JiraBearerToken)
+		return
+	}
+	req.SetBasicAuth(c.cfg.JiraAdminEmail, c.cfg.JiraOrgAPIKey)
+}
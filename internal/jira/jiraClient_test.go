@@ -0,0 +1,40 @@
+package jira
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+)
+
+func TestMain(m *testing.M) {
+	// The client logs every request; keep test output readable.
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// newTestClient returns a Cloud client whose Assets and standard API requests go to handler.
+func newTestClient(t testing.TB, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := NewClient(config.JiraConfig{
+		JiraSiteName:               "example.atlassian.net",
+		JiraWorkspaceID:            "workspace",
+		JiraAdminEmail:             "admin@example.com",
+		JiraOrgAPIKey:              "api-key",
+		JiraAssetsURL:              server.URL,
+		JiraEmployeeObjectTypeName: "Employees",
+		JiraEmployeeObjectTypeID:   "7",
+		JiraRoleObjectTypeName:     "Role",
+		JiraRoleObjectTypeID:       "8",
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
@@ -0,0 +1,537 @@
+// internal/jira/jiraDuplicateMethods.go
+
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// DuplicateCluster is a group of Employee assets that share a matching key.
+type DuplicateCluster struct {
+	Key    string                  // The matching keys the assets share, e.g. "email:jane.doe@example.com, employee number:1001"
+	Assets []models.EmployeeAssets // Every asset in the cluster, always more than one
+}
+
+// FindDuplicateAssets loads all Employee assets and groups them by email and employee number.
+// Emails are compared as the sync pairs them, with models.NormalizeEmail; pass the sync's
+// SYNC_STRIP_EMAIL_PLUS_TAGS setting as stripPlusTags.
+// Groups that share an asset are joined, so every asset is in at most one cluster and can't be
+// kept by one merge and deleted by another. Only clusters containing more than one object are
+// returned. This is cleanup tooling for instances that were polluted by earlier email-only matching.
+func (c *Client) FindDuplicateAssets(ctx context.Context, stripPlusTags bool) ([]DuplicateCluster, error) {
+	assets, err := c.GetAllEmployeeAssets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load employee assets for duplicate detection: %w", err)
+	}
+	clusters := clusterDuplicates(assets, stripPlusTags)
+	// Sort for stable, reviewable output.
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Key < clusters[j].Key })
+
+	log.Printf("INFO: [JiraDuplicates] Found %d duplicate clusters across %d employee assets.", len(clusters), len(assets))
+	return clusters, nil
+}
+
+// clusterDuplicates groups assets that share any matching key, directly or through another
+// asset, and returns the groups of more than one.
+func clusterDuplicates(assets []models.EmployeeAssets, stripPlusTags bool) []DuplicateCluster {
+	// Union-find over asset indexes: assets sharing a key end up with the same root.
+	parent := make([]int, len(assets))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	firstWithKey := make(map[string]int)
+	sharedKeys := make(map[string]bool)
+	for i, asset := range assets {
+		for _, key := range duplicateKeys(asset, stripPlusTags) {
+			first, seen := firstWithKey[key]
+			if !seen {
+				firstWithKey[key] = i
+				continue
+			}
+			if first == i {
+				continue
+			}
+			sharedKeys[key] = true
+			parent[find(i)] = find(first)
+		}
+	}
+
+	members := make(map[int][]models.EmployeeAssets)
+	keys := make(map[int][]string)
+	for i, asset := range assets {
+		members[find(i)] = append(members[find(i)], asset)
+	}
+	for key := range sharedKeys {
+		root := find(firstWithKey[key])
+		keys[root] = append(keys[root], key)
+	}
+
+	var clusters []DuplicateCluster
+	for root, group := range members {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Strings(keys[root])
+		clusters = append(clusters, DuplicateCluster{Key: strings.Join(keys[root], ", "), Assets: group})
+	}
+	return clusters
+}
+
+// AttributeDiff is an attribute whose values differ between the assets of a cluster.
+type AttributeDiff struct {
+	Attribute string            `json:"attribute"`
+	Values    map[string]string `json:"values"` // Object key to the asset's value; empty when the asset has none
+}
+
+// MergePlan is how MergeAssets resolves a cluster: the survivor is kept, the attributes it lacks
+// are copied onto it from the duplicates, whatever references a duplicate is pointed at the
+// survivor, and the duplicates are deleted.
+type MergePlan struct {
+	Key        string                  `json:"key"`
+	Survivor   models.EmployeeAssets   `json:"survivor"`
+	Duplicates []models.EmployeeAssets `json:"duplicates"`
+	Diffs      []AttributeDiff         `json:"diffs"`
+	Copied     []models.AssetAttribute `json:"copied"`             // Values copied onto the survivor
+	Relinked   []string                `json:"relinked,omitempty"` // Objects and issues repointed from a duplicate to the survivor
+}
+
+// PlanMerge chooses the cluster's survivor and works out what merging it would change.
+func PlanMerge(cluster DuplicateCluster) (*MergePlan, error) {
+	if len(cluster.Assets) < 2 {
+		return nil, fmt.Errorf("cluster '%s' has fewer than two assets, nothing to merge", cluster.Key)
+	}
+	survivor := chooseSurvivor(cluster.Assets)
+	plan := &MergePlan{Key: cluster.Key, Survivor: survivor, Diffs: diffAssets(cluster.Assets)}
+	for _, asset := range cluster.Assets {
+		if asset.ID != survivor.ID {
+			plan.Duplicates = append(plan.Duplicates, asset)
+		}
+	}
+	// The most complete duplicates are consulted first, so a copied value comes from the best record.
+	sort.SliceStable(plan.Duplicates, func(i, j int) bool { return survivorLess(plan.Duplicates[j], plan.Duplicates[i]) })
+	plan.Copied = missingAttributes(survivor, plan.Duplicates)
+	return plan, nil
+}
+
+// MergeAssets merges a cluster as planned by PlanMerge: the attributes the survivor lacks are
+// copied onto it, the objects and issues that reference a duplicate are repointed at the
+// survivor, and then the duplicates are deleted. The cluster's assets are read in full first,
+// since the bulk load only carries the synced attributes. Nothing is changed unless confirm is
+// true; without it the planned actions are only logged. If copying or relinking fails nothing
+// is deleted.
+func (c *Client) MergeAssets(ctx context.Context, cluster DuplicateCluster, confirm bool) (*MergePlan, error) {
+	full := DuplicateCluster{Key: cluster.Key, Assets: make([]models.EmployeeAssets, 0, len(cluster.Assets))}
+	for _, asset := range cluster.Assets {
+		object, err := c.GetObject(ctx, asset.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge cluster '%s': reading %s: %w", cluster.Key, asset.ObjectKey, err)
+		}
+		full.Assets = append(full.Assets, *object)
+	}
+	plan, err := PlanMerge(full)
+	if err != nil {
+		return nil, err
+	}
+	survivor := plan.Survivor
+
+	if len(plan.Copied) > 0 {
+		if !confirm {
+			log.Printf("INFO: [JiraDuplicates] (unconfirmed) Would copy %d attributes onto %s (ID %s) for '%s'.", len(plan.Copied), survivor.ObjectKey, survivor.ID, cluster.Key)
+		} else {
+			log.Printf("INFO: [JiraDuplicates] Copying %d attributes onto %s (ID %s) for '%s'.", len(plan.Copied), survivor.ObjectKey, survivor.ID, cluster.Key)
+			if err := c.updateObject(ctx, survivor.ID, plan.Copied); err != nil {
+				return plan, fmt.Errorf("failed to merge cluster '%s': copying attributes onto %s: %w", cluster.Key, survivor.ObjectKey, err)
+			}
+		}
+	}
+	for _, asset := range plan.Duplicates {
+		relinked, err := c.relinkReferences(ctx, asset, survivor, confirm)
+		plan.Relinked = append(plan.Relinked, relinked...)
+		if err != nil {
+			return plan, fmt.Errorf("failed to merge cluster '%s': relinking references to %s: %w", cluster.Key, asset.ObjectKey, err)
+		}
+	}
+	for _, asset := range plan.Duplicates {
+		if !confirm {
+			log.Printf("INFO: [JiraDuplicates] (unconfirmed) Would delete duplicate %s (ID %s) in favour of %s (ID %s) for '%s'.",
+				asset.ObjectKey, asset.ID, survivor.ObjectKey, survivor.ID, cluster.Key)
+			continue
+		}
+		log.Printf("INFO: [JiraDuplicates] Deleting duplicate %s (ID %s) in favour of %s (ID %s) for '%s'.",
+			asset.ObjectKey, asset.ID, survivor.ObjectKey, survivor.ID, cluster.Key)
+		if err := c.DeleteObject(ctx, asset.ID); err != nil {
+			return plan, fmt.Errorf("failed to merge cluster '%s': %w", cluster.Key, err)
+		}
+	}
+	return plan, nil
+}
+
+// relinkReferences points every object attribute and, when JIRA_ASSET_OBJECT_KEY_CUSTOM_FIELD_ID
+// is set, every issue's asset field that references duplicate at survivor instead. It returns the
+// keys of the objects and issues that were, or without confirm would be, changed.
+func (c *Client) relinkReferences(ctx context.Context, duplicate, survivor models.EmployeeAssets, confirm bool) ([]string, error) {
+	var relinked []string
+	referrers, _, err := c.FindObjectsByAQL(ctx, "object HAVING outboundReferences(Key = "+aqlString(duplicate.ObjectKey)+")")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find objects referencing %s: %w", duplicate.ObjectKey, err)
+	}
+	for _, referrer := range referrers {
+		if referrer.ID == duplicate.ID {
+			continue
+		}
+		object, err := c.GetObject(ctx, referrer.ID)
+		if err != nil {
+			return relinked, err
+		}
+		attributes := repointedAttributes(*object, duplicate.ObjectKey, survivor.ObjectKey)
+		if len(attributes) == 0 {
+			continue
+		}
+		relinked = append(relinked, object.ObjectKey)
+		if !confirm {
+			log.Printf("INFO: [JiraDuplicates] (unconfirmed) Would repoint %d attributes of %s from %s to %s.", len(attributes), object.ObjectKey, duplicate.ObjectKey, survivor.ObjectKey)
+			continue
+		}
+		log.Printf("INFO: [JiraDuplicates] Repointing %d attributes of %s from %s to %s.", len(attributes), object.ObjectKey, duplicate.ObjectKey, survivor.ObjectKey)
+		if err := c.updateObject(ctx, object.ID, attributes); err != nil {
+			return relinked, err
+		}
+	}
+
+	fieldID := c.cfg.JiraAssetObjectKeyCustomField
+	if fieldID == "" {
+		return relinked, nil
+	}
+	issues, err := c.issuesLinkedToObject(ctx, fieldID, duplicate.ObjectKey)
+	if err != nil {
+		return relinked, err
+	}
+	for _, issue := range issues {
+		relinked = append(relinked, issue.Key)
+		if !confirm {
+			log.Printf("INFO: [JiraDuplicates] (unconfirmed) Would relink issue %s from %s to %s.", issue.Key, duplicate.ObjectKey, survivor.ObjectKey)
+			continue
+		}
+		log.Printf("INFO: [JiraDuplicates] Relinking issue %s from %s to %s.", issue.Key, duplicate.ObjectKey, survivor.ObjectKey)
+		if err := c.setIssueAssets(ctx, issue.Key, fieldID, repointedIssueAssets(issue.Assets, duplicate, survivor.ObjectKey)); err != nil {
+			return relinked, err
+		}
+	}
+	return relinked, nil
+}
+
+// repointedAttributes returns the attributes of object that reference fromKey, with those
+// references replaced by toKey, ready to be written back.
+func repointedAttributes(object models.EmployeeAssets, fromKey, toKey string) []models.AssetAttribute {
+	var attributes []models.AssetAttribute
+	for _, attr := range object.Attributes {
+		references := false
+		seen := make(map[string]bool)
+		var values []models.Value
+		for _, value := range attr.Values {
+			if value.ReferencedObject != nil && strings.EqualFold(value.ReferencedObject.ObjectKey, fromKey) {
+				references = true
+				value = models.Value{Value: toKey}
+			} else {
+				value = writableValue(value)
+			}
+			if value.Value != "" && !seen[value.Value] {
+				seen[value.Value] = true
+				values = append(values, value)
+			}
+		}
+		if references {
+			attributes = append(attributes, models.AssetAttribute{ObjectTypeAttributeID: attr.ObjectTypeAttributeID, Values: values})
+		}
+	}
+	return attributes
+}
+
+// linkedIssue is an issue whose Assets custom field references an object.
+type linkedIssue struct {
+	Key    string
+	Assets []json.RawMessage // The field's current values, in the shape Jira returned them
+}
+
+// issuesLinkedToObject returns the issues whose Assets custom field references the object.
+func (c *Client) issuesLinkedToObject(ctx context.Context, fieldID, objectKey string) ([]linkedIssue, error) {
+	path := "search/jql"
+	if c.isServer() {
+		path = "search"
+	}
+	fieldNumber := strings.TrimPrefix(fieldID, "customfield_")
+	payload := map[string]interface{}{
+		"jql":        fmt.Sprintf(`cf[%s] IN aqlFunction(%s)`, fieldNumber, strconv.Quote("Key = "+aqlString(objectKey))),
+		"maxResults": 100,
+		"fields":     []string{fieldID},
+	}
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal linked issue search payload: %w", err)
+	}
+	respBody, _, err := c.makeStandardAPIRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues linked to %s: %w", objectKey, err)
+	}
+	var searchResponse struct {
+		Issues []struct {
+			Key    string                     `json:"key"`
+			Fields map[string]json.RawMessage `json:"fields"`
+		} `json:"issues"`
+	}
+	if err := json.Unmarshal(respBody, &searchResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal linked issue search response: %w", err)
+	}
+	issues := make([]linkedIssue, 0, len(searchResponse.Issues))
+	for _, issue := range searchResponse.Issues {
+		var assets []json.RawMessage
+		if raw, ok := issue.Fields[fieldID]; ok && string(raw) != "null" {
+			if err := json.Unmarshal(raw, &assets); err != nil {
+				return nil, fmt.Errorf("failed to read %s on issue %s: %w", fieldID, issue.Key, err)
+			}
+		}
+		issues = append(issues, linkedIssue{Key: issue.Key, Assets: assets})
+	}
+	return issues, nil
+}
+
+// repointedIssueAssets replaces the values of an issue's Assets field that refer to duplicate,
+// by key or by object ID, with toKey. Other values are kept as Jira returned them.
+func repointedIssueAssets(values []json.RawMessage, duplicate models.EmployeeAssets, toKey string) []json.RawMessage {
+	replacement, _ := json.Marshal(toKey)
+	repointed := make([]json.RawMessage, 0, len(values))
+	replaced := false
+	for _, raw := range values {
+		var key string
+		var object struct {
+			Key       string `json:"key"`
+			ObjectKey string `json:"objectKey"`
+			ObjectID  string `json:"objectId"`
+		}
+		refersToDuplicate := json.Unmarshal(raw, &key) == nil && strings.EqualFold(key, duplicate.ObjectKey) ||
+			json.Unmarshal(raw, &object) == nil && (strings.EqualFold(object.Key, duplicate.ObjectKey) ||
+				strings.EqualFold(object.ObjectKey, duplicate.ObjectKey) || object.ObjectID != "" && object.ObjectID == duplicate.ID)
+		if !refersToDuplicate {
+			repointed = append(repointed, raw)
+			continue
+		}
+		if !replaced {
+			repointed = append(repointed, replacement)
+			replaced = true
+		}
+	}
+	if !replaced {
+		repointed = append(repointed, replacement)
+	}
+	return repointed
+}
+
+// setIssueAssets overwrites an issue's Assets custom field.
+func (c *Client) setIssueAssets(ctx context.Context, issueKey, fieldID string, values []json.RawMessage) error {
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"fields": map[string]interface{}{fieldID: values},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal issue asset update: %w", err)
+	}
+	path := fmt.Sprintf("issue/%s", issueKey)
+	if _, _, err := c.makeStandardAPIRequest(ctx, http.MethodPut, path, bytes.NewReader(bodyBytes)); err != nil {
+		return fmt.Errorf("failed to relink issue %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+// chooseSurvivor picks the asset to keep: the most complete one, then the most recently updated,
+// then the one created first.
+func chooseSurvivor(assets []models.EmployeeAssets) models.EmployeeAssets {
+	survivor := assets[0]
+	for _, asset := range assets[1:] {
+		if survivorLess(survivor, asset) {
+			survivor = asset
+		}
+	}
+	return survivor
+}
+
+// survivorLess reports whether b is a better survivor than a.
+func survivorLess(a, b models.EmployeeAssets) bool {
+	if ca, cb := filledAttributes(a), filledAttributes(b); ca != cb {
+		return cb > ca
+	}
+	ua, errA := time.Parse(time.RFC3339, a.Updated)
+	ub, errB := time.Parse(time.RFC3339, b.Updated)
+	if errA == nil && errB == nil && !ua.Equal(ub) {
+		return ub.After(ua)
+	}
+	return objectIDLess(b.ID, a.ID)
+}
+
+// filledAttributes counts the attributes of an asset that hold a value.
+func filledAttributes(asset models.EmployeeAssets) int {
+	filled := 0
+	for _, attr := range asset.Attributes {
+		if attributeText(attr) != "" {
+			filled++
+		}
+	}
+	return filled
+}
+
+// attributeText renders an attribute's values for comparison and display.
+func attributeText(attr models.AssetAttribute) string {
+	values := make([]string, 0, len(attr.Values))
+	for _, value := range attr.Values {
+		if text := writableValue(value).Value; text != "" {
+			values = append(values, text)
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+// writableValue converts a value as Assets returns it into the form it accepts on a write:
+// references by object key, statuses by ID and users by account key.
+func writableValue(value models.Value) models.Value {
+	switch {
+	case value.Value != "":
+		return models.Value{Value: value.Value}
+	case value.ReferencedObject != nil:
+		return models.Value{Value: value.ReferencedObject.ObjectKey}
+	case value.Status != nil:
+		return models.Value{Value: value.Status.ID}
+	case value.User != nil:
+		return models.Value{Value: value.User.Key}
+	}
+	return models.Value{}
+}
+
+// systemAttributes are the server-managed attributes in models.AttributeID. A full object read
+// carries them, and they differ between any two assets.
+var systemAttributes = map[string]bool{"Key": true, "Created": true, "Updated": true}
+
+// diffAssets lists the synced attributes whose values are not the same on every asset. Only the
+// attributes in models.AttributeID are compared, leaving out the system ones and any attribute
+// the sync doesn't write.
+func diffAssets(assets []models.EmployeeAssets) []AttributeDiff {
+	compared := make(map[string]bool, len(models.AttributeID))
+	for name, id := range models.AttributeID {
+		if !systemAttributes[name] {
+			compared[strconv.Itoa(id)] = true
+		}
+	}
+	values := make(map[string]map[string]string) // attribute ID -> object key -> text
+	for _, asset := range assets {
+		for _, attr := range asset.Attributes {
+			if !compared[attr.ObjectTypeAttributeID] {
+				continue
+			}
+			if values[attr.ObjectTypeAttributeID] == nil {
+				values[attr.ObjectTypeAttributeID] = make(map[string]string)
+			}
+			values[attr.ObjectTypeAttributeID][asset.ObjectKey] = attributeText(attr)
+		}
+	}
+
+	var diffs []AttributeDiff
+	for attributeID, byKey := range values {
+		distinct := make(map[string]bool)
+		for _, asset := range assets {
+			distinct[byKey[asset.ObjectKey]] = true
+		}
+		if len(distinct) < 2 {
+			continue
+		}
+		diff := AttributeDiff{Attribute: attributeNameForID(attributeID), Values: make(map[string]string, len(assets))}
+		for _, asset := range assets {
+			diff.Values[asset.ObjectKey] = byKey[asset.ObjectKey]
+		}
+		diffs = append(diffs, diff)
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Attribute < diffs[j].Attribute })
+	return diffs
+}
+
+// missingAttributes returns the attributes the survivor has no value for, filled from the first
+// duplicate that has one.
+func missingAttributes(survivor models.EmployeeAssets, duplicates []models.EmployeeAssets) []models.AssetAttribute {
+	filled := make(map[string]bool)
+	for _, attr := range survivor.Attributes {
+		if attributeText(attr) != "" {
+			filled[attr.ObjectTypeAttributeID] = true
+		}
+	}
+	var copied []models.AssetAttribute
+	for _, asset := range duplicates {
+		for _, attr := range asset.Attributes {
+			if filled[attr.ObjectTypeAttributeID] || attributeText(attr) == "" {
+				continue
+			}
+			values := make([]models.Value, 0, len(attr.Values))
+			for _, value := range attr.Values {
+				if value = writableValue(value); value.Value != "" {
+					values = append(values, value)
+				}
+			}
+			copied = append(copied, models.AssetAttribute{ObjectTypeAttributeID: attr.ObjectTypeAttributeID, Values: values})
+			filled[attr.ObjectTypeAttributeID] = true
+		}
+	}
+	sort.Slice(copied, func(i, j int) bool {
+		return objectIDLess(copied[i].ObjectTypeAttributeID, copied[j].ObjectTypeAttributeID)
+	})
+	return copied
+}
+
+// objectIDLess compares object IDs numerically, falling back to string order if they aren't numbers.
+func objectIDLess(a, b string) bool {
+	ai, errA := strconv.Atoi(a)
+	bi, errB := strconv.Atoi(b)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return ai < bi
+}
+
+// duplicateKeys returns the matching keys for an asset: its email, normalized as by the sync, and,
+// when the schema has one, its employee number.
+func duplicateKeys(asset models.EmployeeAssets, stripPlusTags bool) []string {
+	var keys []string
+	for _, name := range []string{"Email", "Employee Number"} {
+		id, ok := models.AttributeID[name]
+		if !ok {
+			continue
+		}
+		attributeID := strconv.Itoa(id)
+		for _, attr := range asset.Attributes {
+			if attr.ObjectTypeAttributeID != attributeID || len(attr.Values) == 0 {
+				continue
+			}
+			value := strings.ToLower(strings.TrimSpace(attr.Values[0].Value))
+			if name == "Email" {
+				value = models.NormalizeEmail(value, stripPlusTags)
+			}
+			if value != "" {
+				keys = append(keys, strings.ToLower(name)+":"+value)
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,135 @@
+package jira
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// employeeAsset returns an asset with the given email and, if set, employee number.
+func employeeAsset(id, email, number string) models.EmployeeAssets {
+	asset := models.EmployeeAssets{ID: id, ObjectKey: "EMP-" + id}
+	asset.Attributes = append(asset.Attributes, models.AssetAttribute{
+		ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Email"]),
+		Values:                []models.Value{{Value: email}},
+	})
+	if number != "" {
+		asset.Attributes = append(asset.Attributes, models.AssetAttribute{
+			ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Employee Number"]),
+			Values:                []models.Value{{Value: number}},
+		})
+	}
+	return asset
+}
+
+func TestClusterDuplicatesJoinsOverlappingKeys(t *testing.T) {
+	models.AttributeID["Employee Number"] = 94
+	t.Cleanup(func() { delete(models.AttributeID, "Employee Number") })
+
+	// 1 and 2 share an email, 2 and 3 share an employee number: one cluster of three.
+	assets := []models.EmployeeAssets{
+		employeeAsset("1", "jane@example.com", "100"),
+		employeeAsset("2", "Jane@example.com", "200"),
+		employeeAsset("3", "jane.doe@example.com", "200"),
+		employeeAsset("4", "john@example.com", "300"),
+	}
+	clusters := clusterDuplicates(assets, false)
+	if len(clusters) != 1 {
+		t.Fatalf("got %d clusters, want 1: %+v", len(clusters), clusters)
+	}
+	if got := len(clusters[0].Assets); got != 3 {
+		t.Errorf("cluster has %d assets, want 3", got)
+	}
+	if want := "email:jane@example.com, employee number:200"; clusters[0].Key != want {
+		t.Errorf("Key = %q, want %q", clusters[0].Key, want)
+	}
+}
+
+func TestClusterDuplicatesNormalizesEmails(t *testing.T) {
+	assets := []models.EmployeeAssets{
+		employeeAsset("1", " Jane.Doe@Example.com", ""),
+		employeeAsset("2", "jane.doe+payroll@example.com", ""),
+	}
+	tests := []struct {
+		stripPlusTags bool
+		wantKey       string // Empty when the assets are not duplicates
+	}{
+		{stripPlusTags: false},
+		{stripPlusTags: true, wantKey: "email:jane.doe@example.com"},
+	}
+	for _, tt := range tests {
+		clusters := clusterDuplicates(assets, tt.stripPlusTags)
+		if tt.wantKey == "" {
+			if len(clusters) != 0 {
+				t.Errorf("stripPlusTags=%t: got clusters %+v, want none", tt.stripPlusTags, clusters)
+			}
+			continue
+		}
+		if len(clusters) != 1 || clusters[0].Key != tt.wantKey {
+			t.Errorf("stripPlusTags=%t: got clusters %+v, want one keyed %q", tt.stripPlusTags, clusters, tt.wantKey)
+		}
+	}
+}
+
+func TestRepointedAttributes(t *testing.T) {
+	object := models.EmployeeAssets{
+		ObjectKey: "TEAM-1",
+		Attributes: []models.AssetAttribute{
+			{ObjectTypeAttributeID: "10", Values: []models.Value{{Value: "Platform"}}},
+			{ObjectTypeAttributeID: "11", Values: []models.Value{
+				{ReferencedObject: &models.ReferencedObject{ObjectKey: "EMP-2"}},
+				{ReferencedObject: &models.ReferencedObject{ObjectKey: "EMP-7"}},
+			}},
+			{ObjectTypeAttributeID: "12", Values: []models.Value{
+				{ReferencedObject: &models.ReferencedObject{ObjectKey: "EMP-2"}},
+				{ReferencedObject: &models.ReferencedObject{ObjectKey: "EMP-1"}},
+			}},
+		},
+	}
+	got := repointedAttributes(object, "EMP-2", "EMP-1")
+	if len(got) != 2 {
+		t.Fatalf("got %d attributes, want 2: %+v", len(got), got)
+	}
+	want := map[string][]string{"11": {"EMP-1", "EMP-7"}, "12": {"EMP-1"}}
+	for _, attr := range got {
+		var values []string
+		for _, value := range attr.Values {
+			values = append(values, value.Value)
+		}
+		if len(values) != len(want[attr.ObjectTypeAttributeID]) {
+			t.Errorf("attribute %s = %v, want %v", attr.ObjectTypeAttributeID, values, want[attr.ObjectTypeAttributeID])
+			continue
+		}
+		for i := range values {
+			if values[i] != want[attr.ObjectTypeAttributeID][i] {
+				t.Errorf("attribute %s = %v, want %v", attr.ObjectTypeAttributeID, values, want[attr.ObjectTypeAttributeID])
+				break
+			}
+		}
+	}
+}
+
+func TestDiffAssetsComparesOnlySyncedAttributes(t *testing.T) {
+	attribute := func(id, value string) models.AssetAttribute {
+		return models.AssetAttribute{ObjectTypeAttributeID: id, Values: []models.Value{{Value: value}}}
+	}
+	// As read with GetObject: Key (81), Created (83) and Updated (84) always differ, and 999 is an
+	// attribute the sync doesn't know.
+	jane := models.EmployeeAssets{ObjectKey: "EMP-1", Attributes: []models.AssetAttribute{
+		attribute("81", "EMP-1"), attribute("83", "2023-02-13T09:00:00Z"), attribute("84", "2024-07-01T09:00:00Z"),
+		attribute("82", "Jane Doe"), attribute("88", "Engineering"), attribute("999", "a"),
+	}}
+	duplicate := models.EmployeeAssets{ObjectKey: "EMP-2", Attributes: []models.AssetAttribute{
+		attribute("81", "EMP-2"), attribute("83", "2024-01-08T09:00:00Z"), attribute("84", "2024-01-08T09:00:00Z"),
+		attribute("82", "Jane Doe"), attribute("88", "Finance"), attribute("999", "b"),
+	}}
+
+	diffs := diffAssets([]models.EmployeeAssets{jane, duplicate})
+	if len(diffs) != 1 || diffs[0].Attribute != "Dept" {
+		t.Fatalf("diffs = %+v, want only Dept", diffs)
+	}
+	if got := diffs[0].Values; got["EMP-1"] != "Engineering" || got["EMP-2"] != "Finance" {
+		t.Errorf("Dept values = %v, want EMP-1 Engineering and EMP-2 Finance", got)
+	}
+}
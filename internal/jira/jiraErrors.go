@@ -0,0 +1,62 @@
+// internal/jira/jiraErrors.go
+
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when the Jira API responds with a non-2xx status.
+// The response body is kept so callers can inspect Jira's error details.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Jira API returned non-2xx status: %s", e.Status)
+}
+
+// NotFoundError is returned when a requested asset object does not exist.
+type NotFoundError struct {
+	Object string // The object key or ID that was looked up
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("Jira Assets object %s was not found", e.Object)
+}
+
+// IsNotFound reports whether err means the requested object does not exist.
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	return errors.As(err, &notFound)
+}
+
+// duplicateMarkers are fragments of the messages Assets returns when a unique attribute value is already taken.
+var duplicateMarkers = []string{"already exists", "must be unique", "is not unique", "duplicate"}
+
+// IsDuplicateError reports whether err is Jira rejecting a create because the object already exists.
+// Assets signals this with 409 Conflict, or with a 400 whose message names the unique attribute.
+func IsDuplicateError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.StatusCode == http.StatusConflict {
+		return true
+	}
+	if apiErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	body := strings.ToLower(string(apiErr.Body))
+	for _, marker := range duplicateMarkers {
+		if strings.Contains(body, marker) {
+			return true
+		}
+	}
+	return false
+}
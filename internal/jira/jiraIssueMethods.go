@@ -0,0 +1,127 @@
+// internal/jira/jiraIssueMethods.go
+
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// SearchIssues returns up to maxResults issues matching a JQL query, with their summary,
+// status and labels.
+func (c *Client) SearchIssues(ctx context.Context, jql string, maxResults int) ([]models.JiraIssue, error) {
+	// Cloud has replaced /search with /search/jql; Server only has /search. Both accept the same POST body.
+	path := "search/jql"
+	if c.isServer() {
+		path = "search"
+	}
+	payload := map[string]interface{}{
+		"jql":        jql,
+		"maxResults": maxResults,
+		"fields":     []string{"summary", "status", "labels"},
+	}
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal issue search payload: %w", err)
+	}
+
+	respBody, _, err := c.makeStandardAPIRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues with JQL '%s': %w", jql, err)
+	}
+	var searchResponse struct {
+		Issues []models.JiraIssue `json:"issues"`
+	}
+	if err := json.Unmarshal(respBody, &searchResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal issue search response: %w", err)
+	}
+	return searchResponse.Issues, nil
+}
+
+// AddComment adds a rich text comment to an issue.
+func (c *Client) AddComment(ctx context.Context, issueKey string, body *models.JiraIssueDescription) error {
+	bodyBytes, err := json.Marshal(map[string]interface{}{"body": body})
+	if err != nil {
+		return fmt.Errorf("failed to marshal comment payload: %w", err)
+	}
+	path := fmt.Sprintf("issue/%s/comment", issueKey)
+	if _, _, err := c.makeStandardAPIRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyBytes)); err != nil {
+		return fmt.Errorf("failed to comment on issue %s: %w", issueKey, err)
+	}
+	log.Printf("SUCCESS: [JiraMethods] Added a comment to issue %s.", issueKey)
+	return nil
+}
+
+// AddLabels adds labels to an issue, keeping the labels it already has.
+func (c *Client) AddLabels(ctx context.Context, issueKey string, labels ...string) error {
+	operations := make([]map[string]string, 0, len(labels))
+	for _, label := range labels {
+		operations = append(operations, map[string]string{"add": label})
+	}
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"update": map[string]interface{}{"labels": operations},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal label update payload: %w", err)
+	}
+	path := fmt.Sprintf("issue/%s", issueKey)
+	if _, _, err := c.makeStandardAPIRequest(ctx, http.MethodPut, path, bytes.NewReader(bodyBytes)); err != nil {
+		return fmt.Errorf("failed to add labels to issue %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+// GetIssueTypeNames returns the names of the issue types that can be created in a project,
+// from its create metadata.
+func (c *Client) GetIssueTypeNames(ctx context.Context, projectKey string) ([]string, error) {
+	path := fmt.Sprintf("issue/createmeta/%s/issuetypes", projectKey)
+	respBody, _, err := c.makeStandardAPIRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue types for project %s: %w", projectKey, err)
+	}
+	// Cloud lists the types under "issueTypes", Server under "values".
+	var metaResponse struct {
+		IssueTypes []models.JiraIssueType `json:"issueTypes"`
+		Values     []models.JiraIssueType `json:"values"`
+	}
+	if err := json.Unmarshal(respBody, &metaResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal issue types for project %s: %w", projectKey, err)
+	}
+	names := make([]string, 0, len(metaResponse.IssueTypes)+len(metaResponse.Values))
+	for _, issueType := range append(metaResponse.IssueTypes, metaResponse.Values...) {
+		names = append(names, issueType.Name)
+	}
+	return names, nil
+}
+
+// checkIssueType returns an error if the project has no issue type with the given name. The
+// project's types are fetched before its first issue is created and cached for the client's
+// lifetime. If they can't be fetched the check is skipped, leaving Jira to reject the create.
+func (c *Client) checkIssueType(ctx context.Context, projectKey, issueType string) error {
+	c.issueTypesMu.Lock()
+	defer c.issueTypesMu.Unlock()
+
+	names, ok := c.issueTypes[projectKey]
+	if !ok {
+		var err error
+		names, err = c.GetIssueTypeNames(ctx, projectKey)
+		if err != nil {
+			log.Printf("WARN: [JiraMethods] Could not check issue type '%s' in project %s: %v", issueType, projectKey, err)
+			return nil
+		}
+		c.issueTypes[projectKey] = names
+	}
+	for _, name := range names {
+		if strings.EqualFold(name, issueType) {
+			return nil
+		}
+	}
+	return fmt.Errorf("issue type '%s' does not exist in project %s; available types: %s", issueType, projectKey, strings.Join(names, ", "))
+}
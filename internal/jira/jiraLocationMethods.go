@@ -0,0 +1,83 @@
+// internal/jira/jiraLocationMethods.go
+
+package jira
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// Location objects mirror Role objects: they live in their own object type
+// (JIRA_LOCATION_OBJECT_TYPE_ID), are matched by their Name attribute, and are referenced from
+// the Employee "Location" attribute.
+
+// FindLocation looks up an existing Location object by name without creating one.
+// It returns an empty key if no matching location exists.
+func (c *Client) FindLocation(ctx context.Context, locationName string) (string, error) {
+	if locationName == "" {
+		return "", nil
+	}
+	found, err := c.findObjectsByName(ctx, c.cfg.JiraLocationObjectTypeName, "location", []string{locationName})
+	if err != nil {
+		return "", err
+	}
+	return found[strings.ToLower(locationName)], nil
+}
+
+// FindLocations looks up many Location objects by name, as FindRoles does for Roles.
+func (c *Client) FindLocations(ctx context.Context, locationNames []string) (map[string]string, error) {
+	return c.findObjectsByName(ctx, c.cfg.JiraLocationObjectTypeName, "location", locationNames)
+}
+
+// FindOrCreateLocation returns the key of the named Location object, creating it if it doesn't
+// exist. created reports whether the location was created by this call.
+func (c *Client) FindOrCreateLocation(ctx context.Context, locationName string) (locationKey string, created bool, err error) {
+	if locationName == "" {
+		return "", false, nil
+	}
+
+	locationKey, err = c.FindLocation(ctx, locationName)
+	if err != nil {
+		return "", false, err
+	}
+	if locationKey != "" {
+		return locationKey, false, nil
+	}
+
+	log.Printf("INFO: [JiraMethods] No valid location '%s' found. Creating new asset.", locationName)
+	locationKey, err = c.CreateLocation(ctx, locationName)
+	if err != nil {
+		return "", false, err
+	}
+	return locationKey, true, nil
+}
+
+// CreateLocation creates a Location object without looking for an existing one first, and
+// returns its key.
+func (c *Client) CreateLocation(ctx context.Context, locationName string) (string, error) {
+	if c.cfg.JiraLocationObjectTypeID == "" {
+		return "", fmt.Errorf("cannot create location '%s': JIRA_LOCATION_OBJECT_TYPE_ID is not set", locationName)
+	}
+	id, ok := models.AttributeID[models.LocationNameAttribute]
+	if !ok {
+		return "", fmt.Errorf("cannot create location '%s': the attribute map has no '%s' entry", locationName, models.LocationNameAttribute)
+	}
+	attributes := []models.AssetAttribute{
+		{ObjectTypeAttributeID: strconv.Itoa(id), Values: []models.Value{{Value: locationName}}},
+	}
+	newLocation, err := c.createObject(ctx, c.cfg.JiraLocationObjectTypeID, attributes)
+	if err != nil {
+		return "", fmt.Errorf("failed to create new location asset for '%s': %w", locationName, err)
+	}
+	if newLocation == nil || newLocation.ObjectKey == "" {
+		return "", fmt.Errorf("creation of location '%s' returned no object key", locationName)
+	}
+
+	log.Printf("SUCCESS: [JiraMethods] Successfully created new location '%s' with key %s.", locationName, newLocation.ObjectKey)
+	return newLocation.ObjectKey, nil
+}
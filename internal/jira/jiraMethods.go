@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 
@@ -17,34 +18,49 @@ import (
 
 // --- NEW METHODS FOR STANDARD JIRA API ---
 
-// makeStandardAPIRequest is a generic helper for the standard v3 Jira Cloud API.
+// makeStandardAPIRequest is a generic helper for the standard Jira REST API (v3 on Cloud, v2 on Server).
 // It uses a different base URL than the Assets API.
 func (c *Client) makeStandardAPIRequest(ctx context.Context, method, path string, body io.Reader) ([]byte, int, error) {
-	// Construct the URL for the standard Jira Cloud API (e.g., https://your-domain.atlassian.net/rest/api/3)
+	headers := http.Header{}
+	if body != nil {
+		headers.Set("Content-Type", "application/json")
+	}
+	return c.sendStandardAPIRequest(ctx, method, path, body, headers)
+}
+
+// sendStandardAPIRequest sends a standard Jira API request with the given extra headers,
+// for requests whose body is not JSON.
+func (c *Client) sendStandardAPIRequest(ctx context.Context, method, path string, body io.Reader, headers http.Header) ([]byte, int, error) {
+	// Construct the URL for the standard Jira API (e.g., https://your-domain.atlassian.net/rest/api/3)
 	fullURL, err := url.Parse(fmt.Sprintf("https://%s", c.cfg.JiraSiteName))
 	if err != nil {
 		return nil, 0, fmt.Errorf("invalid Jira Site Name from config: %w", err)
 	}
-	fullURL = fullURL.JoinPath("rest", "api", "3", path)
+	fullURL = fullURL.JoinPath(append(c.standardAPIPath(), path)...)
 
 	req, err := http.NewRequestWithContext(ctx, method, fullURL.String(), body)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to create standard Jira API request: %w", err)
 	}
 
-	req.SetBasicAuth(c.cfg.JiraAdminEmail, c.cfg.JiraOrgAPIKey)
+	c.authorize(req)
 	req.Header.Set("Accept", "application/json")
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.cfg.UserAgent)
+	for key, values := range headers {
+		req.Header[key] = values
 	}
 
 	log.Printf("INFO: [JiraClient] Making %s request to standard API: %s", method, fullURL.String())
 
+	if err := c.rateLimit.wait(ctx); err != nil {
+		return nil, 0, err
+	}
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to execute standard Jira API request: %w", err)
 	}
 	defer resp.Body.Close()
+	c.rateLimit.observe(resp.Header)
 
 	responseBody, readErr := io.ReadAll(resp.Body)
 	if readErr != nil {
@@ -53,59 +69,71 @@ func (c *Client) makeStandardAPIRequest(ctx context.Context, method, path string
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		log.Printf("ERROR: [JiraClient] Standard Jira API returned non-2xx status: %s, body: %s", resp.Status, string(responseBody))
-		return responseBody, resp.StatusCode, fmt.Errorf("standard Jira API returned non-2xx status: %s", resp.Status)
+		return responseBody, resp.StatusCode, &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: responseBody}
 	}
 
 	return responseBody, resp.StatusCode, nil
 }
 
-// CreateIssueWithAsset creates a new Jira issue and links it to an asset.
-func (c *Client) CreateIssueWithAsset(ctx context.Context, projectKey, summary, description, assetCustomFieldID, assetObjectKey string) (*models.JiraIssueResponse, error) {
+// CreateIssueOptions are the optional standard fields of an issue created by CreateIssueWithAsset.
+type CreateIssueOptions struct {
+	IssueType         string   // Issue type name; empty uses JIRA_ISSUE_TYPE_NAME
+	Labels            []string // Labels to add to the issue
+	AssigneeAccountID string   // Atlassian account ID of the assignee; empty leaves the issue unassigned
+	DueDate           string   // Due date as YYYY-MM-DD; empty sets none
+}
 
-	// Construct the payload for the Jira issue.
+// CreateIssueWithAsset creates a new Jira issue and links it to an asset. Each line of the
+// description becomes a paragraph.
+func (c *Client) CreateIssueWithAsset(ctx context.Context, projectKey, summary, description, assetCustomFieldID, assetObjectKey string, opts CreateIssueOptions) (*models.JiraIssueResponse, error) {
+	issueType := opts.IssueType
+	if issueType == "" {
+		issueType = c.cfg.JiraIssueTypeNameForAsset
+	}
+	if issueType == "" {
+		issueType = "Task"
+	}
+
+	// Construct the fields for the Jira issue.
 	// The structure must match the Jira API format exactly.
-	issuePayload := models.JiraIssueRequest{
-		Fields: models.JiraIssueFields{
-			Project: models.JiraProject{
-				Key: projectKey,
-			},
-			Summary: summary,
-			IssueType: models.JiraIssueType{
-				Name: "Task", // Or use a configurable value from cfg
-			},
-			Description: models.JiraIssueDescription{
-				Type:    "doc",
-				Version: 1,
-				Content: []models.JiraDescriptionContent{
-					{
-						Type: "paragraph",
-						Content: []models.JiraDescriptionText{
-							{
-								Type: "text",
-								Text: description,
-							},
-						},
-					},
-				},
-			},
-			// This is how you set the custom field for the Asset object.
-			// The key must be the custom field ID, e.g., "customfield_10050".
-			CustomFields: map[string]interface{}{
-				assetCustomFieldID: []string{assetObjectKey},
-			},
+	fields := models.JiraIssueFields{
+		Project: models.JiraProject{
+			Key: projectKey,
+		},
+		Summary: summary,
+		IssueType: models.JiraIssueType{
+			Name: issueType,
 		},
+		Description: models.NewADFDocument(models.ADFParagraphs(description)...),
+		Labels:      opts.Labels,
+		DueDate:     opts.DueDate,
+	}
+	if opts.AssigneeAccountID != "" {
+		fields.Assignee = &models.JiraUser{AccountID: opts.AssigneeAccountID}
+	}
+	// The key must be the custom field ID, e.g., "customfield_10050".
+	fields.CustomFieldAssets(assetCustomFieldID, assetObjectKey)
+
+	return c.CreateIssue(ctx, fields)
+}
+
+// CreateIssue creates a Jira issue from the given fields. The issue type is first checked
+// against the project's create metadata, so a misconfigured type fails with the valid names.
+func (c *Client) CreateIssue(ctx context.Context, fields models.JiraIssueFields) (*models.JiraIssueResponse, error) {
+	if err := c.checkIssueType(ctx, fields.Project.Key, fields.IssueType.Name); err != nil {
+		return nil, err
 	}
 
 	// Marshal the payload into JSON.
-	bodyBytes, err := json.Marshal(issuePayload)
+	bodyBytes, err := json.Marshal(models.JiraIssueRequest{Fields: fields})
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal issue creation payload: %w", err)
 	}
-	log.Printf("DEBUG: [JiraClient] Issue Creation Payload: %s", string(bodyBytes))
 
 	// Make the API call to create the issue.
 	respBody, _, err := c.makeStandardAPIRequest(ctx, http.MethodPost, "issue", bytes.NewReader(bodyBytes))
 	if err != nil {
+		logFailedPayload(fmt.Sprintf("Issue creation in project %s", fields.Project.Key), bodyBytes, err)
 		return nil, err
 	}
 
@@ -117,3 +145,47 @@ func (c *Client) CreateIssueWithAsset(ctx context.Context, projectKey, summary,
 
 	return &issueResponse, nil
 }
+
+// AddAttachment uploads a file to an issue and returns the new attachment's ID.
+func (c *Client) AddAttachment(ctx context.Context, issueKey string, filename string, content io.Reader) (string, error) {
+	if issueKey == "" || filename == "" {
+		return "", fmt.Errorf("issue key and filename are required to add an attachment")
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to create attachment form part: %w", err)
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return "", fmt.Errorf("failed to read attachment content for %s: %w", filename, err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to finish attachment form: %w", err)
+	}
+
+	headers := http.Header{}
+	headers.Set("Content-Type", writer.FormDataContentType())
+	// Jira rejects multipart uploads without this as a CSRF precaution.
+	headers.Set("X-Atlassian-Token", "no-check")
+
+	path := fmt.Sprintf("issue/%s/attachments", issueKey)
+	respBody, _, err := c.sendStandardAPIRequest(ctx, http.MethodPost, path, &body, headers)
+	if err != nil {
+		return "", fmt.Errorf("failed to attach %s to issue %s: %w", filename, issueKey, err)
+	}
+
+	var attachments []struct {
+		ID       string `json:"id"`
+		Filename string `json:"filename"`
+	}
+	if err := json.Unmarshal(respBody, &attachments); err != nil {
+		return "", fmt.Errorf("failed to unmarshal attachment response: %w", err)
+	}
+	if len(attachments) == 0 {
+		return "", fmt.Errorf("Jira returned no attachment for %s on issue %s", filename, issueKey)
+	}
+	log.Printf("SUCCESS: [JiraMethods] Attached %s to issue %s (attachment ID %s).", filename, issueKey, attachments[0].ID)
+	return attachments[0].ID, nil
+}
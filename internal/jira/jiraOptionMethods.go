@@ -0,0 +1,182 @@
+// internal/jira/jiraOptionMethods.go
+
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// MissingOption is a value that Jira rejected because it isn't one of a select attribute's options.
+type MissingOption struct {
+	AttributeID string // Empty if Jira only named the attribute
+	Attribute   string // Attribute name, resolved from models.AttributeID where possible
+	Value       string
+}
+
+// MissingOptionError wraps a write that failed because of one or more missing select options.
+type MissingOptionError struct {
+	Options []MissingOption
+	Err     error
+}
+
+func (e *MissingOptionError) Error() string {
+	var parts []string
+	for _, option := range e.Options {
+		parts = append(parts, fmt.Sprintf("'%s' is not a valid option for %s", option.Value, option.Attribute))
+	}
+	return fmt.Sprintf("%s: %v", strings.Join(parts, "; "), e.Err)
+}
+
+func (e *MissingOptionError) Unwrap() error { return e.Err }
+
+var (
+	// quotedValuePattern captures the rejected value from messages such as
+	// "value 'Engineering - Platform' is not a valid option for attribute Dept".
+	quotedValuePattern = regexp.MustCompile(`(?i)'([^']*)'\s+is not a valid option`)
+	// attributeNamePattern captures the attribute name when the message names it.
+	attributeNamePattern = regexp.MustCompile(`(?i)not a valid option for attribute\s+'?([^'.]+)'?`)
+	// attributeKeyPattern matches the keys Assets uses in its "errors" map, e.g. "rlabs-insight-attribute-88".
+	attributeKeyPattern = regexp.MustCompile(`attribute-(\d+)$`)
+)
+
+// assetsErrorBody is the error shape returned by the Assets API.
+type assetsErrorBody struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
+
+// ParseMissingOptions extracts missing select options from a failed Assets write.
+// It returns nil if err is not a 400 describing invalid options.
+func ParseMissingOptions(err error) []MissingOption {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		return nil
+	}
+	var body assetsErrorBody
+	if json.Unmarshal(apiErr.Body, &body) != nil {
+		return nil
+	}
+
+	var options []MissingOption
+	for key, message := range body.Errors {
+		option, ok := parseMissingOptionMessage(message)
+		if !ok {
+			continue
+		}
+		if match := attributeKeyPattern.FindStringSubmatch(key); match != nil {
+			option.AttributeID = match[1]
+			option.Attribute = attributeNameForID(match[1])
+		}
+		options = append(options, option)
+	}
+	for _, message := range body.ErrorMessages {
+		if option, ok := parseMissingOptionMessage(message); ok {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+// parseMissingOptionMessage parses a single "not a valid option" message.
+func parseMissingOptionMessage(message string) (MissingOption, bool) {
+	valueMatch := quotedValuePattern.FindStringSubmatch(message)
+	if valueMatch == nil {
+		return MissingOption{}, false
+	}
+	option := MissingOption{Value: valueMatch[1]}
+	if nameMatch := attributeNamePattern.FindStringSubmatch(message); nameMatch != nil {
+		option.Attribute = strings.TrimSpace(nameMatch[1])
+		if id, ok := models.AttributeID[option.Attribute]; ok {
+			option.AttributeID = fmt.Sprint(id)
+		}
+	}
+	return option, true
+}
+
+// attributeNameForID resolves an attribute ID to its name in models.AttributeID, or returns the ID.
+func attributeNameForID(attributeID string) string {
+	for name, id := range models.AttributeID {
+		if fmt.Sprint(id) == attributeID {
+			return name
+		}
+	}
+	return attributeID
+}
+
+// AddSelectOption adds a value to a select attribute's option list, if it isn't already there.
+func (c *Client) AddSelectOption(ctx context.Context, objectTypeID, attributeID, option string) error {
+	attributes, err := c.GetObjectTypeAttributes(ctx, objectTypeID)
+	if err != nil {
+		return err
+	}
+
+	var current *models.ObjectTypeAttribute
+	for i := range attributes {
+		if attributes[i].ID == attributeID {
+			current = &attributes[i]
+			break
+		}
+	}
+	if current == nil {
+		return fmt.Errorf("attribute %s does not exist on object type %s", attributeID, objectTypeID)
+	}
+
+	// Assets stores select options as a single comma-separated string.
+	var options []string
+	for _, existing := range strings.Split(current.Options, ",") {
+		if existing = strings.TrimSpace(existing); existing != "" {
+			if existing == option {
+				return nil
+			}
+			options = append(options, existing)
+		}
+	}
+	options = append(options, option)
+
+	bodyBytes, err := json.Marshal(map[string]interface{}{"name": current.Name, "options": strings.Join(options, ",")})
+	if err != nil {
+		return fmt.Errorf("failed to marshal option update body: %w", err)
+	}
+	path := fmt.Sprintf("objecttypeattribute/%s/%s", objectTypeID, attributeID)
+	if _, _, err := c.makeAPIRequest(ctx, http.MethodPut, path, nil, bytes.NewReader(bodyBytes)); err != nil {
+		return fmt.Errorf("failed to add option '%s' to attribute %s: %w", option, current.Name, err)
+	}
+	log.Printf("SUCCESS: [JiraMethods] Added option '%s' to select attribute '%s'.", option, current.Name)
+	return nil
+}
+
+// writeWithOptionRetry runs an asset write. If it fails because of missing select options, the
+// options are either created and the write retried once (when AUTO_CREATE_SELECT_OPTIONS is on),
+// or the failure is returned as a *MissingOptionError so callers can report it.
+func (c *Client) writeWithOptionRetry(ctx context.Context, objectTypeID string, write func() error) error {
+	err := write()
+	missing := ParseMissingOptions(err)
+	if len(missing) == 0 {
+		return err
+	}
+	if !c.cfg.JiraAutoCreateSelectOptions {
+		return &MissingOptionError{Options: missing, Err: err}
+	}
+
+	for _, option := range missing {
+		if option.AttributeID == "" {
+			return &MissingOptionError{Options: missing, Err: err}
+		}
+		if addErr := c.AddSelectOption(ctx, objectTypeID, option.AttributeID, option.Value); addErr != nil {
+			log.Printf("ERROR: [JiraMethods] Could not auto-create option '%s' for %s: %v", option.Value, option.Attribute, addErr)
+			return &MissingOptionError{Options: missing, Err: err}
+		}
+	}
+	log.Printf("INFO: [JiraMethods] Retrying write after adding %d missing select option(s).", len(missing))
+	return write()
+}
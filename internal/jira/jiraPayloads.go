@@ -0,0 +1,72 @@
+// internal/jira/jiraPayloads.go
+
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+)
+
+// redactedValue replaces sensitive values in logged payloads.
+const redactedValue = "[REDACTED]"
+
+// sensitiveNamePattern matches JSON keys and attribute names whose values must never be logged.
+var sensitiveNamePattern = regexp.MustCompile(`(?i)token|secret|password|api[ _-]?key|authorization|ssn|social security|birth`)
+
+// IsSensitiveName reports whether values of a JSON key or attribute with this name are
+// redacted from logs and captured payloads.
+func IsSensitiveName(name string) bool {
+	return sensitiveNamePattern.MatchString(name)
+}
+
+// logFailedPayload logs the request payload of a write that Jira rejected, so the failure can be
+// reproduced. Successful writes and transport errors are not logged: the payload only helps when
+// Jira saw it and said no.
+func logFailedPayload(operation string, payload []byte, err error) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return
+	}
+	log.Printf("ERROR: [JiraClient] %s was rejected with %s. Request payload: %s", operation, apiErr.Status, redactPayload(payload))
+}
+
+// redactPayload returns a JSON payload with sensitive values replaced. Asset attributes are
+// matched on their attribute name, since the payload only carries their IDs.
+func redactPayload(payload []byte) string {
+	var decoded interface{}
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return fmt.Sprintf("<%d bytes, not JSON>", len(payload))
+	}
+	redacted, err := json.Marshal(redactValue(decoded))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes, could not be redacted>", len(payload))
+	}
+	return loggableBody(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if id, ok := v["objectTypeAttributeId"].(string); ok && sensitiveNamePattern.MatchString(attributeNameForID(id)) {
+			v["objectAttributeValues"] = redactedValue
+			return v
+		}
+		for key, child := range v {
+			if sensitiveNamePattern.MatchString(key) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactValue(child)
+		}
+		return v
+	}
+	return value
+}
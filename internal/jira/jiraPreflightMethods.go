@@ -0,0 +1,84 @@
+// internal/jira/jiraPreflightMethods.go
+
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// Myself is the user the Jira client authenticates as.
+type Myself struct {
+	AccountID    string `json:"accountId"` // Cloud
+	Name         string `json:"name"`      // Server
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+// GetMyself returns the user the configured credentials belong to.
+func (c *Client) GetMyself(ctx context.Context) (*Myself, error) {
+	body, _, err := c.makeStandardAPIRequest(ctx, http.MethodGet, "myself", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the authenticated Jira user: %w", err)
+	}
+	var me Myself
+	if err := json.Unmarshal(body, &me); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal myself response: %w", err)
+	}
+	return &me, nil
+}
+
+// FieldExists reports whether a Jira issue field (for example "customfield_10050") exists.
+func (c *Client) FieldExists(ctx context.Context, fieldID string) (bool, error) {
+	body, _, err := c.makeStandardAPIRequest(ctx, http.MethodGet, "field", nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to list Jira fields: %w", err)
+	}
+	var fields []struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return false, fmt.Errorf("failed to unmarshal field list: %w", err)
+	}
+	for _, field := range fields {
+		if field.ID == fieldID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// VerifyWriteAccess creates and immediately deletes a throwaway object in the given object type,
+// proving the credentials can write to Assets. The object type must have a writable "Name" attribute.
+func (c *Client) VerifyWriteAccess(ctx context.Context, objectTypeID string) error {
+	attributes, err := c.GetObjectTypeAttributes(ctx, objectTypeID)
+	if err != nil {
+		return err
+	}
+	nameAttributeID := ""
+	for _, attr := range attributes {
+		if strings.EqualFold(attr.Name, "Name") {
+			nameAttributeID = attr.ID
+			break
+		}
+	}
+	if nameAttributeID == "" {
+		return fmt.Errorf("object type %s has no 'Name' attribute to create a test object with", objectTypeID)
+	}
+
+	created, err := c.createObject(ctx, objectTypeID, []models.AssetAttribute{
+		{ObjectTypeAttributeID: nameAttributeID, Values: []models.Value{{Value: "PSDI preflight check"}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create test object in object type %s: %w", objectTypeID, err)
+	}
+	if err := c.DeleteObject(ctx, created.ID); err != nil {
+		return fmt.Errorf("created test object %s but could not delete it, remove it by hand: %w", created.ObjectKey, err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// internal/jira/jiraRateLimit.go
+
+package jira
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRateLimitWait caps how long a request waits for the rate-limit window to reset.
+const maxRateLimitWait = time.Minute
+
+// rateLimiter tracks the rate-limit headers Atlassian returns and holds requests back when the
+// remaining budget is low, so a large run slows down rather than being throttled with 429s.
+// 429s themselves are still retried by the HTTP client's retry transport.
+type rateLimiter struct {
+	minRemaining int // Budget at or below which requests wait; 0 disables waiting
+
+	mu        sync.Mutex
+	remaining int       // Last X-RateLimit-Remaining; -1 until Jira sends one
+	lowest    int       // Lowest X-RateLimit-Remaining seen; -1 until Jira sends one
+	resumeAt  time.Time // When the budget is expected back, from X-RateLimit-Reset or Retry-After
+	waits     int
+}
+
+func newRateLimiter(minRemaining int) *rateLimiter {
+	return &rateLimiter{minRemaining: minRemaining, remaining: -1, lowest: -1}
+}
+
+// observe records the rate-limit headers of a response.
+func (l *rateLimiter) observe(header http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if remaining, err := strconv.Atoi(strings.TrimSpace(header.Get("X-RateLimit-Remaining"))); err == nil {
+		l.remaining = remaining
+		if l.lowest < 0 || remaining < l.lowest {
+			l.lowest = remaining
+		}
+	}
+	if reset, ok := parseRateLimitReset(header.Get("X-RateLimit-Reset")); ok {
+		l.resumeAt = reset
+	}
+	if seconds, err := strconv.Atoi(strings.TrimSpace(header.Get("Retry-After"))); err == nil && seconds > 0 {
+		if at := time.Now().Add(time.Duration(seconds) * time.Second); at.After(l.resumeAt) {
+			l.resumeAt = at
+		}
+		// A Retry-After means the budget is spent, whatever the last Remaining said.
+		l.remaining = 0
+	}
+}
+
+// wait blocks until the rate-limit window resets when the remaining budget is at or below
+// minRemaining, up to maxRateLimitWait.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	if l.minRemaining <= 0 || l.remaining < 0 || l.remaining > l.minRemaining {
+		l.mu.Unlock()
+		return nil
+	}
+	delay := time.Until(l.resumeAt)
+	if delay <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	if delay > maxRateLimitWait {
+		delay = maxRateLimitWait
+	}
+	l.waits++
+	remaining := l.remaining
+	l.mu.Unlock()
+
+	log.Printf("WARN: [JiraClient] Jira rate-limit budget is low (%d requests left), waiting %v for it to reset.", remaining, delay.Round(time.Second))
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return fmt.Errorf("Jira request cancelled while waiting for the rate limit to reset: %w", ctx.Err())
+	}
+
+	// Let the next response report the new budget rather than waiting again on the old one.
+	l.mu.Lock()
+	if !l.resumeAt.After(time.Now()) {
+		l.remaining = -1
+	}
+	l.mu.Unlock()
+	return nil
+}
+
+// parseRateLimitReset reads X-RateLimit-Reset, which Atlassian sends as an ISO 8601 timestamp.
+func parseRateLimitReset(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z07:00"} {
+		if at, err := time.Parse(layout, value); err == nil {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// RateLimitStats reports the Jira rate-limit budget observed so far: the most recent and lowest
+// X-RateLimit-Remaining (-1 if Jira never sent one) and how many times requests waited for the
+// budget to reset.
+func (c *Client) RateLimitStats() (remaining, lowest, waits int) {
+	c.rateLimit.mu.Lock()
+	defer c.rateLimit.mu.Unlock()
+	return c.rateLimit.remaining, c.rateLimit.lowest, c.rateLimit.waits
+}
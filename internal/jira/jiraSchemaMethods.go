@@ -0,0 +1,79 @@
+// internal/jira/jiraSchemaMethods.go
+
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// GetObjectTypeAttributes fetches the attribute definitions of an Assets object type.
+func (c *Client) GetObjectTypeAttributes(ctx context.Context, objectTypeID string) ([]models.ObjectTypeAttribute, error) {
+	if objectTypeID == "" {
+		return nil, fmt.Errorf("cannot fetch attributes: object type ID is empty")
+	}
+	path := fmt.Sprintf("objecttype/%s/attributes", objectTypeID)
+
+	body, _, err := c.makeAPIRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch attributes for object type %s: %w", objectTypeID, err)
+	}
+
+	var attributes []models.ObjectTypeAttribute
+	if err := json.Unmarshal(body, &attributes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal object type attributes: %w. Body: %s", err, loggableBody(body))
+	}
+	return attributes, nil
+}
+
+// ValidateAttributeSchema checks that every attribute the sync writes exists on the
+// configured Employee object type with the ID in models.AttributeID, and is writable.
+// All mismatches are collected into a single error so schema drift can be fixed in one pass.
+func (c *Client) ValidateAttributeSchema(ctx context.Context) error {
+	attributes, err := c.GetObjectTypeAttributes(ctx, c.cfg.JiraEmployeeObjectTypeID)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]models.ObjectTypeAttribute, len(attributes))
+	for _, attr := range attributes {
+		byID[attr.ID] = attr
+	}
+
+	var problems []string
+	for _, name := range models.ManagedAttributes {
+		id, ok := models.AttributeID[name]
+		if !ok && models.IsOptionalAttribute(name) {
+			continue
+		}
+		if !ok {
+			problems = append(problems, fmt.Sprintf("'%s' has no entry in models.AttributeID", name))
+			continue
+		}
+		attr, ok := byID[strconv.Itoa(id)]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("'%s' (ID %d) does not exist on object type %s", name, id, c.cfg.JiraEmployeeObjectTypeID))
+			continue
+		}
+		if !strings.EqualFold(attr.Name, name) {
+			problems = append(problems, fmt.Sprintf("'%s' (ID %d) is named '%s' in Jira", name, id, attr.Name))
+		}
+		if attr.System || !attr.Editable {
+			problems = append(problems, fmt.Sprintf("'%s' (ID %d) is not writable (system=%t, editable=%t)", name, id, attr.System, attr.Editable))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("attribute schema validation failed for object type %s:\n  - %s",
+			c.cfg.JiraEmployeeObjectTypeID, strings.Join(problems, "\n  - "))
+	}
+	log.Printf("INFO: [JiraSchema] Validated %d managed attributes against object type %s.", len(models.ManagedAttributes), c.cfg.JiraEmployeeObjectTypeID)
+	return nil
+}
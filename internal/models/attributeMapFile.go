@@ -0,0 +1,107 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// RoleNameAttribute is the AttributeID entry holding the ID of the Role object type's Name attribute.
+const RoleNameAttribute = "Role Name Attribute ID"
+
+// LocationNameAttribute is the AttributeID entry holding the ID of the Location object type's Name attribute.
+const LocationNameAttribute = "Location Name Attribute ID"
+
+// AttributeMapFile is the JSON document written by cmd/schemaBootstrap, which can be loaded
+// at runtime to replace the hand-maintained IDs in AttributeID.
+type AttributeMapFile struct {
+	Employee ObjectTypeAttributeMap  `json:"employee"`
+	Role     ObjectTypeAttributeMap  `json:"role"`
+	Location *ObjectTypeAttributeMap `json:"location,omitempty"` // Only when JIRA_LOCATION_OBJECT_TYPE_ID is set
+}
+
+// ObjectTypeAttributeMap lists the attributes of one object type.
+type ObjectTypeAttributeMap struct {
+	ObjectTypeID string             `json:"objectTypeId"`
+	Attributes   []AttributeMapItem `json:"attributes"`
+}
+
+// AttributeMapItem is one attribute's name, ID and human readable type.
+type AttributeMapItem struct {
+	Name string `json:"name"`
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+}
+
+// NewObjectTypeAttributeMap converts attribute definitions fetched from Jira into a map entry.
+// Attributes with a non-numeric ID are skipped.
+func NewObjectTypeAttributeMap(objectTypeID string, attributes []ObjectTypeAttribute) ObjectTypeAttributeMap {
+	result := ObjectTypeAttributeMap{ObjectTypeID: objectTypeID}
+	for _, attr := range attributes {
+		id, err := strconv.Atoi(attr.ID)
+		if err != nil {
+			continue
+		}
+		result.Attributes = append(result.Attributes, AttributeMapItem{Name: attr.Name, ID: id, Type: AttributeTypeName(attr)})
+	}
+	return result
+}
+
+// AttributeTypeName describes an attribute's type, using the default type name (Text, Date, ...)
+// for Default attributes.
+func AttributeTypeName(attr ObjectTypeAttribute) string {
+	switch attr.Type {
+	case AttributeTypeDefault:
+		if attr.DefaultType != nil && attr.DefaultType.Name != "" {
+			return attr.DefaultType.Name
+		}
+		return "Default"
+	case AttributeTypeObject:
+		return "Object"
+	case AttributeTypeUser:
+		return "User"
+	case AttributeTypeGroup:
+		return "Group"
+	case AttributeTypeStatus:
+		return "Status"
+	default:
+		return fmt.Sprintf("Type %d", attr.Type)
+	}
+}
+
+// LoadAttributeMap reads a file written by cmd/schemaBootstrap and overrides AttributeID with it.
+// Employee attributes are stored under their own names, and the Role and Location object types'
+// Name attributes under RoleNameAttribute and LocationNameAttribute. It returns the number of
+// entries loaded.
+func LoadAttributeMap(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read attribute map %s: %w", path, err)
+	}
+	var file AttributeMapFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return 0, fmt.Errorf("failed to parse attribute map %s: %w", path, err)
+	}
+
+	loaded := 0
+	for _, attr := range file.Employee.Attributes {
+		AttributeID[attr.Name] = attr.ID
+		loaded++
+	}
+	for _, attr := range file.Role.Attributes {
+		if attr.Name == "Name" {
+			AttributeID[RoleNameAttribute] = attr.ID
+			loaded++
+		}
+	}
+	if file.Location != nil {
+		for _, attr := range file.Location.Attributes {
+			if attr.Name == "Name" {
+				AttributeID[LocationNameAttribute] = attr.ID
+				loaded++
+			}
+		}
+	}
+	return loaded, nil
+}
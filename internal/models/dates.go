@@ -0,0 +1,55 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Date formats accepted by Jira Assets for Date and DateTime attributes.
+const (
+	JiraAssetsDateFormat     = "2006-01-02"
+	JiraAssetsDateTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// Assets default type IDs for date-typed attributes (see ObjectTypeAttribute.DefaultType).
+const (
+	DefaultTypeDate     = 4
+	DefaultTypeDateTime = 6
+)
+
+// paycorDateLayouts are the date shapes Paycor has been seen to return, newest first.
+var paycorDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"01/02/2006",
+	"1/2/2006",
+}
+
+// ParsePaycorDate parses a date string from Paycor. Values without an offset, such as
+// "2024-03-10T02:30:00", are read in loc (PAYCOR_TIMEZONE), or UTC when loc is nil; values with
+// an offset keep it. A local time that a DST change skips or repeats resolves to whichever instant
+// time.ParseInLocation picks; Go doesn't guarantee which, so such values may be off by an hour.
+// It returns ok=false with no error for empty values and for the "0001-01-01" sentinel
+// Paycor uses for unset dates, so callers can omit the attribute entirely.
+func ParsePaycorDate(value string, loc *time.Location) (date time.Time, ok bool, err error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	for _, layout := range paycorDateLayouts {
+		parsed, parseErr := time.ParseInLocation(layout, value, loc)
+		if parseErr != nil {
+			continue
+		}
+		if parsed.Year() <= 1 {
+			return time.Time{}, false, nil
+		}
+		return parsed, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("unrecognised date format '%s'", value)
+}
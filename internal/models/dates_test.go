@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // Don't depend on the host's zone database
+)
+
+func TestParsePaycorDateInTimezone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// want lists the acceptable UTC instants. A local time that DST skips or repeats has two
+	// candidates and Go doesn't guarantee which one ParseInLocation picks.
+	tests := []struct {
+		name  string
+		value string
+		loc   *time.Location
+		want  []string
+	}{
+		{name: "before spring forward", value: "2024-03-10T01:30:00", loc: newYork, want: []string{"2024-03-10T06:30:00Z"}},
+		{name: "after spring forward", value: "2024-03-10T03:30:00", loc: newYork, want: []string{"2024-03-10T07:30:00Z"}},
+		{name: "skipped by spring forward", value: "2024-03-10T02:30:00", loc: newYork, want: []string{"2024-03-10T06:30:00Z", "2024-03-10T07:30:00Z"}},
+		{name: "before fall back", value: "2024-11-03T00:30:00", loc: newYork, want: []string{"2024-11-03T04:30:00Z"}},
+		{name: "after fall back", value: "2024-11-03T02:30:00", loc: newYork, want: []string{"2024-11-03T07:30:00Z"}},
+		{name: "repeated by fall back", value: "2024-11-03T01:30:00", loc: newYork, want: []string{"2024-11-03T05:30:00Z", "2024-11-03T06:30:00Z"}},
+		{name: "date only", value: "2024-07-01", loc: newYork, want: []string{"2024-07-01T04:00:00Z"}},
+		{name: "explicit offset wins", value: "2024-03-10T02:30:00-08:00", loc: newYork, want: []string{"2024-03-10T10:30:00Z"}},
+		{name: "nil location is UTC", value: "2024-03-10T02:30:00", loc: nil, want: []string{"2024-03-10T02:30:00Z"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := ParsePaycorDate(tt.value, tt.loc)
+			if err != nil || !ok {
+				t.Fatalf("ParsePaycorDate(%q) = ok %t, err %v", tt.value, ok, err)
+			}
+			utc := got.UTC().Format(time.RFC3339)
+			for _, want := range tt.want {
+				if utc == want {
+					return
+				}
+			}
+			t.Errorf("ParsePaycorDate(%q) = %s, want one of %v", tt.value, utc, tt.want)
+		})
+	}
+}
+
+func TestParsePaycorDateUnset(t *testing.T) {
+	for _, value := range []string{"", "  ", "0001-01-01", "0001-01-01T00:00:00"} {
+		if _, ok, err := ParsePaycorDate(value, nil); ok || err != nil {
+			t.Errorf("ParsePaycorDate(%q) = ok %t, err %v; want unset", value, ok, err)
+		}
+	}
+	if _, _, err := ParsePaycorDate("next tuesday", nil); err == nil {
+		t.Error("ParsePaycorDate(\"next tuesday\") returned no error")
+	}
+}
@@ -0,0 +1,56 @@
+package models
+
+import "strings"
+
+// ADFNode is a node in an Atlassian Document Format document, the rich text format of Jira
+// Cloud issue descriptions and comments. Block nodes carry Content; text nodes carry Text.
+type ADFNode struct {
+	Type    string                 `json:"type"`
+	Text    string                 `json:"text,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Content []ADFNode              `json:"content,omitempty"`
+}
+
+// NewADFDocument returns a document containing the given block nodes.
+func NewADFDocument(blocks ...ADFNode) *JiraIssueDescription {
+	return &JiraIssueDescription{Type: "doc", Version: 1, Content: blocks}
+}
+
+// ADFParagraph returns a paragraph of plain text. ADF rejects empty text nodes, so an empty
+// string gives an empty paragraph.
+func ADFParagraph(text string) ADFNode {
+	paragraph := ADFNode{Type: "paragraph"}
+	if text != "" {
+		paragraph.Content = []ADFNode{{Type: "text", Text: text}}
+	}
+	return paragraph
+}
+
+// ADFParagraphs returns one paragraph per line of text. Blank lines give empty paragraphs, so
+// multi-line text keeps its spacing.
+func ADFParagraphs(text string) []ADFNode {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	paragraphs := make([]ADFNode, 0, len(lines))
+	for _, line := range lines {
+		paragraphs = append(paragraphs, ADFParagraph(strings.TrimRight(line, "\r")))
+	}
+	return paragraphs
+}
+
+// ADFTable returns a table with a header row followed by one row per entry in rows.
+func ADFTable(headers []string, rows [][]string) ADFNode {
+	table := ADFNode{Type: "table", Attrs: map[string]interface{}{"isNumberColumnEnabled": false, "layout": "default"}}
+	table.Content = append(table.Content, adfTableRow("tableHeader", headers))
+	for _, row := range rows {
+		table.Content = append(table.Content, adfTableRow("tableCell", row))
+	}
+	return table
+}
+
+func adfTableRow(cellType string, cells []string) ADFNode {
+	row := ADFNode{Type: "tableRow"}
+	for _, cell := range cells {
+		row.Content = append(row.Content, ADFNode{Type: cellType, Content: []ADFNode{ADFParagraph(cell)}})
+	}
+	return row
+}
@@ -1,5 +1,12 @@
 package models
 
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
 // EmployeeAssets represents a single employee record in Jira Assets.
 type EmployeeAssets struct {
 	ID         string           `json:"id,omitempty"`
@@ -7,6 +14,7 @@ type EmployeeAssets struct {
 	ObjectKey  string           `json:"objectKey,omitempty"`
 	ObjectType ObjectTypeInfo   `json:"objectType,omitempty"`
 	Attributes []AssetAttribute `json:"attributes"`
+	Updated    string           `json:"updated,omitempty"` // When the object last changed, as Assets formats it; never sent
 }
 
 // 1. ADD this new struct definition. You can place it right above EmployeeAssets.
@@ -19,14 +27,156 @@ type ObjectTypeInfo struct {
 type AssetAttribute struct {
 	ObjectTypeAttributeID string  `json:"objectTypeAttributeId"`
 	Values                []Value `json:"objectAttributeValues"`
+
+	// ObjectTypeAttribute is the attribute's definition, which Jira includes when a single
+	// object is read. It is never sent.
+	ObjectTypeAttribute *AttributeRef `json:"objectTypeAttribute,omitempty"`
+}
+
+// AttributeRef names the attribute an AssetAttribute holds values for.
+type AttributeRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 // Value holds the actual data for an attribute.
+// When writing, only Value is sent. When reading, Jira also returns a DisplayValue and, for
+// reference, status and user attributes, the ReferencedObject, Status or User instead of a plain Value.
 type Value struct {
-	Value string `json:"value"`
+	Value            string            `json:"value"`
+	DisplayValue     string            `json:"displayValue,omitempty"`
+	ReferencedObject *ReferencedObject `json:"referencedObject,omitempty"`
+	Status           *StatusValue      `json:"status,omitempty"`
+	User             *UserValue        `json:"user,omitempty"`
+}
+
+// ReferencedObject is the object an object reference attribute value points at.
+type ReferencedObject struct {
+	ObjectKey string `json:"objectKey"`
+	Label     string `json:"label"`
+}
+
+// StatusValue is the status a status attribute value is set to.
+type StatusValue struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserValue is the user a user attribute value refers to. Key is the Atlassian account ID on Cloud.
+type UserValue struct {
+	Key          string `json:"key"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+}
+
+// UnmarshalJSON decodes an attribute value without failing on the shapes Assets uses besides a
+// plain string: numbers and booleans are kept as their JSON text, null is empty, nested
+// referencedObject, status and user objects are captured, and a bare string is taken as the value.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] != '{' {
+		*v = Value{Value: jsonScalar(data)}
+		return nil
+	}
+
+	var raw struct {
+		Value            json.RawMessage   `json:"value"`
+		DisplayValue     json.RawMessage   `json:"displayValue"`
+		ReferencedObject *ReferencedObject `json:"referencedObject"`
+		Status           *StatusValue      `json:"status"`
+		User             *UserValue        `json:"user"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*v = Value{
+		Value:            jsonScalar(raw.Value),
+		DisplayValue:     jsonScalar(raw.DisplayValue),
+		ReferencedObject: raw.ReferencedObject,
+		Status:           raw.Status,
+		User:             raw.User,
+	}
+	return nil
+}
+
+// jsonScalar returns a JSON string's contents, or the literal text of any other scalar.
+// Null, objects and arrays give "".
+func jsonScalar(data json.RawMessage) string {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] == '{' || data[0] == '[' || bytes.Equal(data, []byte("null")) {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		return s
+	}
+	return string(data)
+}
+
+// Resolved returns the comparable value: the referenced object key for references, the status
+// name for statuses and the account key for users, otherwise the raw value, falling back to
+// the display value.
+func (v Value) Resolved() string {
+	if v.ReferencedObject != nil && v.ReferencedObject.ObjectKey != "" {
+		return v.ReferencedObject.ObjectKey
+	}
+	if v.Status != nil && v.Status.Name != "" {
+		return v.Status.Name
+	}
+	if v.User != nil && v.User.Key != "" {
+		return v.User.Key
+	}
+	if v.Value != "" {
+		return v.Value
+	}
+	return v.DisplayValue
+}
+
+// FirstString returns the attribute's first non-empty resolved value, or "".
+func (a AssetAttribute) FirstString() string {
+	for _, value := range a.Values {
+		if resolved := value.Resolved(); resolved != "" {
+			return resolved
+		}
+	}
+	return ""
+}
+
+// FirstReferencedKey returns the object key of the attribute's first object reference, or "".
+func (a AssetAttribute) FirstReferencedKey() string {
+	for _, value := range a.Values {
+		if value.ReferencedObject != nil && value.ReferencedObject.ObjectKey != "" {
+			return value.ReferencedObject.ObjectKey
+		}
+	}
+	return ""
+}
+
+// AttributesByName returns each attribute's first resolved value keyed by attribute name. Names
+// come from the definitions Jira includes on a single-object read, falling back to AttributeID.
+// Attributes whose name can't be determined are keyed by ID.
+func (a EmployeeAssets) AttributesByName() map[string]string {
+	names := make(map[string]string, len(AttributeID))
+	for name, id := range AttributeID {
+		names[strconv.Itoa(id)] = name
+	}
+	byName := make(map[string]string, len(a.Attributes))
+	for _, attr := range a.Attributes {
+		name := attr.ObjectTypeAttributeID
+		if attr.ObjectTypeAttribute != nil && attr.ObjectTypeAttribute.Name != "" {
+			name = attr.ObjectTypeAttribute.Name
+		} else if mapped, ok := names[attr.ObjectTypeAttributeID]; ok {
+			name = mapped
+		}
+		if value := attr.FirstString(); value != "" {
+			byName[name] = value
+		}
+	}
+	return byName
 }
 
 // NOTE: These IDs are specific to YOUR Jira instance and schema.
+// Run cmd/schemaBootstrap to regenerate them, or point JIRA_ATTRIBUTE_MAP_PATH at its JSON output.
 var AttributeID = map[string]int{
 	"Key":                    81,
 	"Name":                   82,
@@ -43,3 +193,144 @@ var AttributeID = map[string]int{
 	"Employee Status":        93,
 	"Role Name Attribute ID": 78,
 }
+
+// MatchingAttributes are the attributes used to pair a Paycor employee with an existing asset.
+// Entries that are not present in AttributeID are ignored.
+var MatchingAttributes = []string{"Email", "Employee Number"}
+
+// ManagedAttributes are the attributes the sync writes, and therefore needs to read back for diffing.
+var ManagedAttributes = []string{"Name", "Email", "Start Date", "Status", "Job Role", "Manager Name", "Phone", "Location"}
+
+// OptionalAttributes are the managed attributes a schema may leave out. They are only written
+// when AttributeID has them, and a schema without them still validates.
+var OptionalAttributes = []string{"Phone", "Location"}
+
+// IsOptionalAttribute reports whether name is one of OptionalAttributes.
+func IsOptionalAttribute(name string) bool {
+	for _, optional := range OptionalAttributes {
+		if optional == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ObjectTypeAttribute describes one attribute definition on a Jira Assets object type,
+// as returned by the objecttype/{id}/attributes endpoint.
+type ObjectTypeAttribute struct {
+	ID             string       `json:"id"`
+	Name           string       `json:"name"`
+	Type           int          `json:"type"` // 0 = Default, 1 = Object reference, 2 = User, 4 = Group, 7 = Status
+	DefaultType    *DefaultType `json:"defaultType,omitempty"`
+	Editable       bool         `json:"editable"`
+	System         bool         `json:"system"` // Server-managed attributes such as Key, Created and Updated
+	MinCardinality int          `json:"minimumCardinality"`
+	MaxCardinality int          `json:"maximumCardinality"`
+	Options        string       `json:"options,omitempty"` // Comma-separated options of a Select attribute
+}
+
+// Assets attribute kinds (see ObjectTypeAttribute.Type).
+const (
+	AttributeTypeDefault = 0
+	AttributeTypeObject  = 1
+	AttributeTypeUser    = 2
+	AttributeTypeGroup   = 4
+	AttributeTypeStatus  = 7
+)
+
+// Assets default type IDs for non-date attributes (see ObjectTypeAttribute.DefaultType).
+// The date types are in dates.go.
+const (
+	DefaultTypeText    = 0
+	DefaultTypeInteger = 1
+	DefaultTypeBoolean = 2
+	DefaultTypeDouble  = 3
+	DefaultTypeSelect  = 10
+)
+
+// DefaultType is the data type of a "Default" (type 0) attribute, e.g. Text, Date or Integer.
+type DefaultType struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// AttributeValue returns the first resolved value of the named attribute (looked up in
+// AttributeID), or "" if it isn't set. For an object reference that is the referenced object's key.
+func AttributeValue(attributes []AssetAttribute, attributeName string) string {
+	id, ok := AttributeID[attributeName]
+	if !ok {
+		return ""
+	}
+	attributeID := strconv.Itoa(id)
+
+	for _, attr := range attributes {
+		if attr.ObjectTypeAttributeID == attributeID {
+			if value := attr.FirstString(); value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// AttributeSchema indexes an object type's attribute definitions by ID and by name,
+// so attributes can be resolved against what Jira actually has rather than a hand-kept map.
+type AttributeSchema struct {
+	byID   map[string]ObjectTypeAttribute
+	byName map[string]ObjectTypeAttribute
+}
+
+// NewAttributeSchema builds an AttributeSchema from the definitions returned by Jira.
+func NewAttributeSchema(attributes []ObjectTypeAttribute) *AttributeSchema {
+	schema := &AttributeSchema{
+		byID:   make(map[string]ObjectTypeAttribute, len(attributes)),
+		byName: make(map[string]ObjectTypeAttribute, len(attributes)),
+	}
+	for _, attr := range attributes {
+		schema.byID[attr.ID] = attr
+		schema.byName[strings.ToLower(attr.Name)] = attr
+	}
+	return schema
+}
+
+// Attribute returns the definition for an attribute ID.
+func (s *AttributeSchema) Attribute(attributeID string) (ObjectTypeAttribute, bool) {
+	if s == nil {
+		return ObjectTypeAttribute{}, false
+	}
+	attr, ok := s.byID[attributeID]
+	return attr, ok
+}
+
+// AttributeIDFor resolves an attribute name to its ID. The discovered schema wins; the
+// static AttributeID map is used when the schema is nil or doesn't know the name.
+func (s *AttributeSchema) AttributeIDFor(attributeName string) (string, bool) {
+	if s != nil {
+		if attr, ok := s.byName[strings.ToLower(attributeName)]; ok {
+			return attr.ID, true
+		}
+	}
+	if id, ok := AttributeID[attributeName]; ok {
+		return strconv.Itoa(id), true
+	}
+	return "", false
+}
+
+// GetAttributeValue returns the first value of the named attribute on an asset.
+// Text and date attributes return their raw value, and object references return the
+// referenced object's key. It returns "" if the attribute is unknown or has no value.
+func (s *AttributeSchema) GetAttributeValue(asset EmployeeAssets, attributeName string) string {
+	attributeID, ok := s.AttributeIDFor(attributeName)
+	if !ok {
+		return ""
+	}
+	for _, attr := range asset.Attributes {
+		if attr.ObjectTypeAttributeID != attributeID {
+			continue
+		}
+		if value := attr.FirstString(); value != "" {
+			return value
+		}
+	}
+	return ""
+}
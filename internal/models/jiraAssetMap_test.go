@@ -0,0 +1,225 @@
+package models
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValueUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		want         Value
+		wantResolved string
+	}{
+		{
+			name:         "string",
+			data:         `{"value":"Jane Doe","displayValue":"Jane Doe"}`,
+			want:         Value{Value: "Jane Doe", DisplayValue: "Jane Doe"},
+			wantResolved: "Jane Doe",
+		},
+		{
+			name:         "number",
+			data:         `{"value":42.5,"displayValue":42.5}`,
+			want:         Value{Value: "42.5", DisplayValue: "42.5"},
+			wantResolved: "42.5",
+		},
+		{
+			name:         "boolean",
+			data:         `{"value":true,"displayValue":"true"}`,
+			want:         Value{Value: "true", DisplayValue: "true"},
+			wantResolved: "true",
+		},
+		{
+			name:         "null",
+			data:         `{"value":null,"displayValue":"Unset"}`,
+			want:         Value{DisplayValue: "Unset"},
+			wantResolved: "Unset",
+		},
+		{
+			name:         "referenced object",
+			data:         `{"displayValue":"Engineer","referencedObject":{"id":"88","objectKey":"ROLE-88","label":"Engineer"}}`,
+			want:         Value{DisplayValue: "Engineer", ReferencedObject: &ReferencedObject{ObjectKey: "ROLE-88", Label: "Engineer"}},
+			wantResolved: "ROLE-88",
+		},
+		{
+			name:         "status",
+			data:         `{"displayValue":"Active","status":{"id":"3","name":"Active","category":1}}`,
+			want:         Value{DisplayValue: "Active", Status: &StatusValue{ID: "3", Name: "Active"}},
+			wantResolved: "Active",
+		},
+		{
+			name:         "user",
+			data:         `{"value":"5b10ac8d82e05b22cc7d4ef5","user":{"key":"5b10ac8d82e05b22cc7d4ef5","displayName":"Jane Doe"}}`,
+			want:         Value{Value: "5b10ac8d82e05b22cc7d4ef5", User: &UserValue{Key: "5b10ac8d82e05b22cc7d4ef5", DisplayName: "Jane Doe"}},
+			wantResolved: "5b10ac8d82e05b22cc7d4ef5",
+		},
+		{
+			name:         "bare string",
+			data:         `"Jane Doe"`,
+			want:         Value{Value: "Jane Doe"},
+			wantResolved: "Jane Doe",
+		},
+		{
+			name:         "bare number",
+			data:         `7`,
+			want:         Value{Value: "7"},
+			wantResolved: "7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Value
+			if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+				t.Fatalf("Unmarshal(%s): %v", tt.data, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.data, got, tt.want)
+			}
+			if resolved := got.Resolved(); resolved != tt.wantResolved {
+				t.Errorf("Resolved() = %q, want %q", resolved, tt.wantResolved)
+			}
+
+			// What was read must survive being written back, as it is when an asset is cached or diffed.
+			encoded, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			var roundTripped Value
+			if err := json.Unmarshal(encoded, &roundTripped); err != nil {
+				t.Fatalf("Unmarshal(%s): %v", encoded, err)
+			}
+			if !reflect.DeepEqual(roundTripped, got) {
+				t.Errorf("round trip through %s = %+v, want %+v", encoded, roundTripped, got)
+			}
+		})
+	}
+}
+
+// loadFixture decodes testdata/name into v. The fixtures are sanitized Assets responses.
+func loadFixture(t *testing.T, name string, v interface{}) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("decoding %s: %v", name, err)
+	}
+}
+
+func TestAssetObjectFixture(t *testing.T) {
+	var asset EmployeeAssets
+	loadFixture(t, "asset_object.json", &asset)
+	if asset.ObjectKey != "EMP-1042" || asset.ObjectType.Name != "Employees" || len(asset.Attributes) != 9 {
+		t.Fatalf("decoded %s (%s) with %d attributes, want EMP-1042 (Employees) with 9", asset.ObjectKey, asset.ObjectType.Name, len(asset.Attributes))
+	}
+
+	byID := make(map[string]AssetAttribute, len(asset.Attributes))
+	for _, attr := range asset.Attributes {
+		byID[attr.ObjectTypeAttributeID] = attr
+	}
+	tests := []struct {
+		name           string
+		attributeID    string
+		wantString     string
+		wantReferenced string
+	}{
+		{name: "text", attributeID: "82", wantString: "Jane Doe"},
+		{name: "date keeps the value, not the display value", attributeID: "91", wantString: "2023-02-13"},
+		{name: "object reference", attributeID: "87", wantString: "ROLE-88", wantReferenced: "ROLE-88"},
+		{name: "status", attributeID: "92", wantString: "Active"},
+		{name: "user", attributeID: "85", wantString: "557058:00000000-1111-2222-3333-444444444444"},
+		{name: "number", attributeID: "94", wantString: "0.5"},
+		{name: "multi-valued with a blank first value", attributeID: "95", wantString: "555-0100"},
+		{name: "no values", attributeID: "86"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr, ok := byID[tt.attributeID]
+			if !ok {
+				t.Fatalf("the fixture has no attribute %s", tt.attributeID)
+			}
+			if got := attr.FirstString(); got != tt.wantString {
+				t.Errorf("FirstString() = %q, want %q", got, tt.wantString)
+			}
+			if got := attr.FirstReferencedKey(); got != tt.wantReferenced {
+				t.Errorf("FirstReferencedKey() = %q, want %q", got, tt.wantReferenced)
+			}
+		})
+	}
+
+	// The nested objects are captured, not just resolved.
+	role := byID["87"].Values[0]
+	if role.DisplayValue != "Engineer" || role.ReferencedObject == nil || role.ReferencedObject.Label != "Engineer" {
+		t.Errorf("Job Role value = %+v, want the Engineer reference with its display value", role)
+	}
+	if status := byID["92"].Values[0].Status; status == nil || status.ID != "3" {
+		t.Errorf("Status = %+v, want status 3", status)
+	}
+	if user := byID["85"].Values[0].User; user == nil || user.DisplayName != "Jane Doe" || user.EmailAddress != "jane.doe@example.com" {
+		t.Errorf("User = %+v, want Jane Doe", user)
+	}
+
+	// Names come from the definitions included with the object.
+	byName := asset.AttributesByName()
+	for name, want := range map[string]string{"Job Role": "ROLE-88", "Status": "Active", "Headcount Weight": "0.5"} {
+		if got := byName[name]; got != want {
+			t.Errorf("AttributesByName()[%q] = %q, want %q", name, got, want)
+		}
+	}
+	if _, ok := byName["Manager Name"]; ok {
+		t.Error("AttributesByName() included an attribute with no values")
+	}
+}
+
+func TestGetAttributeValueFromAQLResponse(t *testing.T) {
+	// The captured schema's IDs differ from the static AttributeID map, so every lookup below
+	// has to go through the discovered schema.
+	var definitions []ObjectTypeAttribute
+	loadFixture(t, "objecttype_attributes.json", &definitions)
+	schema := NewAttributeSchema(definitions)
+	var response struct {
+		ObjectEntries []EmployeeAssets `json:"objectEntries"`
+	}
+	loadFixture(t, "aql_objects.json", &response)
+	if len(response.ObjectEntries) != 2 {
+		t.Fatalf("decoded %d objects, want 2", len(response.ObjectEntries))
+	}
+	jane, john := response.ObjectEntries[0], response.ObjectEntries[1]
+
+	tests := []struct {
+		name      string
+		asset     EmployeeAssets
+		attribute string
+		want      string
+	}{
+		{name: "text", asset: jane, attribute: "Name", want: "Jane Doe"},
+		{name: "email", asset: jane, attribute: "Email", want: "jane.doe@example.com"},
+		{name: "name is case-insensitive", asset: jane, attribute: "email", want: "jane.doe@example.com"},
+		{name: "date", asset: jane, attribute: "Start Date", want: "2023-02-13"},
+		{name: "object reference", asset: jane, attribute: "Job Role", want: "ROLE-88"},
+		{name: "status", asset: jane, attribute: "Status", want: "Active"},
+		{name: "user", asset: jane, attribute: "Atlassian Account ID", want: "557058:00000000-1111-2222-3333-444444444444"},
+		{name: "no values", asset: john, attribute: "Email", want: ""},
+		{name: "empty reference", asset: john, attribute: "Job Role", want: ""},
+		{name: "attribute not on the object", asset: john, attribute: "Start Date", want: ""},
+		{name: "attribute not in the schema", asset: jane, attribute: "Shoe Size", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := schema.GetAttributeValue(tt.asset, tt.attribute); got != tt.want {
+				t.Errorf("GetAttributeValue(%s, %q) = %q, want %q", tt.asset.ObjectKey, tt.attribute, got, tt.want)
+			}
+		})
+	}
+
+	// Without a schema the static map is used, and its IDs don't match this response.
+	var noSchema *AttributeSchema
+	if got := noSchema.GetAttributeValue(jane, "Email"); got != "" {
+		t.Errorf("GetAttributeValue without a schema = %q, want \"\" for IDs the static map doesn't have", got)
+	}
+}
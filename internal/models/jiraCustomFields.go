@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// JiraDateFormat is the format of Jira date fields such as duedate and date custom fields.
+const JiraDateFormat = "2006-01-02"
+
+// JiraOption identifies a select list option by its ID.
+type JiraOption struct {
+	ID string `json:"id"`
+}
+
+// setCustomField stores a custom field value, creating the map on first use.
+func (f *JiraIssueFields) setCustomField(fieldID string, value interface{}) {
+	if f.CustomFields == nil {
+		f.CustomFields = make(map[string]interface{})
+	}
+	f.CustomFields[fieldID] = value
+}
+
+// CustomFieldText sets a text custom field (single or multi line).
+func (f *JiraIssueFields) CustomFieldText(fieldID, value string) {
+	f.setCustomField(fieldID, value)
+}
+
+// CustomFieldDate sets a date picker custom field.
+func (f *JiraIssueFields) CustomFieldDate(fieldID string, date time.Time) {
+	f.setCustomField(fieldID, date.Format(JiraDateFormat))
+}
+
+// CustomFieldSelect sets a single-select custom field to the option with the given ID.
+func (f *JiraIssueFields) CustomFieldSelect(fieldID, optionID string) {
+	f.setCustomField(fieldID, JiraOption{ID: optionID})
+}
+
+// CustomFieldUser sets a single user picker custom field.
+func (f *JiraIssueFields) CustomFieldUser(fieldID, accountID string) {
+	f.setCustomField(fieldID, JiraUser{AccountID: accountID})
+}
+
+// CustomFieldAssets sets an Assets object custom field to the given object keys.
+func (f *JiraIssueFields) CustomFieldAssets(fieldID string, objectKeys ...string) {
+	f.setCustomField(fieldID, objectKeys)
+}
@@ -1,6 +1,10 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 // PaycorConfig holds Paycor API configuration
 
@@ -9,8 +13,10 @@ import "encoding/json"
 // --- Helper Structs for Nested JSON Objects ---
 
 type PositionData struct {
-	JobTitle string `json:"jobTitle"`
-	Manager  string `json:"manager,omitempty"`
+	JobTitle   string `json:"jobTitle"`
+	Department string `json:"department,omitempty"`
+	Manager    string `json:"manager,omitempty"`
+	ManagerID  string `json:"managerId,omitempty"`
 }
 
 type Email struct {
@@ -18,9 +24,34 @@ type Email struct {
 	EmailAddress string `json:"emailAddress"`
 }
 
+type Phone struct {
+	Type        string `json:"type"`
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+// CustomField is one of an employee's Paycor custom fields. Value is kept raw because Paycor
+// sends text, numbers or null depending on the field's type.
+type CustomField struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Text returns the field's value as text, or "" when it is null or absent.
+func (f CustomField) Text() string {
+	var s string
+	if json.Unmarshal(f.Value, &s) == nil {
+		return s
+	}
+	if len(f.Value) == 0 || string(f.Value) == "null" {
+		return ""
+	}
+	return string(f.Value)
+}
+
 type EmploymentDateData struct {
 	HireDate        string `json:"hireDate"`
 	TerminationDate string `json:"terminationDate"`
+	RehireDate      string `json:"rehireDate,omitempty"`
 }
 
 type StatusData struct {
@@ -33,6 +64,16 @@ type WorkLocation struct {
 	State string `json:"state"`
 }
 
+// Location is one of a legal entity's work locations, from the worklocations endpoint.
+type Location struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Address struct {
+		City  string `json:"city"`
+		State string `json:"state"`
+	} `json:"address"`
+}
+
 type LegalEntity struct {
 	ID string `json:"id"`
 }
@@ -44,13 +85,46 @@ type Employee struct {
 	ID                 string             `json:"id"`
 	FirstName          string             `json:"firstName"`
 	LastName           string             `json:"lastName"`
+	MiddleName         string             `json:"middleName,omitempty"`
+	PreferredName      string             `json:"preferredName,omitempty"`
 	EmployeeNumber     string             `json:"employeeNumber"`
 	Email              Email              `json:"email"`
+	Phones             []Phone            `json:"phones,omitempty"`
 	PositionData       PositionData       `json:"positionData"`
 	EmploymentDateData EmploymentDateData `json:"employmentDateData"`
 	StatusData         StatusData         `json:"statusData"`
 	WorkLocation       WorkLocation       `json:"workLocation"`
 	LegalEntity        LegalEntity        `json:"legalEntity"`
+	CustomFields       []CustomField      `json:"customFields,omitempty"`
+}
+
+// CustomFieldValue returns the text of the named custom field, and whether the employee has it.
+// Names are matched case-insensitively.
+func (e Employee) CustomFieldValue(name string) (string, bool) {
+	for _, field := range e.CustomFields {
+		if strings.EqualFold(field.Name, name) {
+			return field.Text(), true
+		}
+	}
+	return "", false
+}
+
+// NormalizeEmail returns the form of an email address used to pair employees with assets and to
+// find duplicate assets: trimmed and lowercased, and with any "+tag" removed from the local part
+// if stripPlusTags is set.
+func NormalizeEmail(email string, stripPlusTags bool) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if !stripPlusTags {
+		return email
+	}
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return email
+	}
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+	return local + "@" + domain
 }
 
 // JiraConfig holds Jira API configuration
@@ -61,36 +135,59 @@ type JiraIssueRequest struct {
 }
 
 // JiraIssueFields contains all the fields for a new issue.
+// Optional standard fields are omitted from the JSON when unset.
 type JiraIssueFields struct {
 	Project      JiraProject            `json:"project"`
 	Summary      string                 `json:"summary"`
-	Description  JiraIssueDescription   `json:"description,omitempty"`
+	Description  *JiraIssueDescription  `json:"description,omitempty"`
 	IssueType    JiraIssueType          `json:"issuetype"`
-	CustomFields map[string]interface{} `json:"-"` // This will be handled dynamically
+	Labels       []string               `json:"labels,omitempty"`
+	Assignee     *JiraUser              `json:"assignee,omitempty"`
+	DueDate      string                 `json:"duedate,omitempty"` // YYYY-MM-DD
+	Priority     *JiraPriority          `json:"priority,omitempty"`
+	CustomFields map[string]interface{} `json:"-"` // Merged in by MarshalJSON, keyed by field ID e.g. "customfield_10050"
 }
 
-// MarshalJSON is a custom marshaller to include the dynamic custom fields.
+// MarshalJSON marshals the standard fields from their struct tags and then merges in the
+// dynamic custom fields, so new standard fields only need a tag to be included.
 func (f JiraIssueFields) MarshalJSON() ([]byte, error) {
-	// Use an alias to avoid recursion
-	type Alias JiraIssueFields
-
-	// Start with the standard fields
-	m := map[string]interface{}{
-		"project":   f.Project,
-		"summary":   f.Summary,
-		"issuetype": f.IssueType,
-	}
+	// The local type has the same fields but not this method, which avoids recursion.
+	type standardFields JiraIssueFields
 
-	if f.Description.Content != nil {
-		m["description"] = f.Description
+	base, err := json.Marshal(standardFields(f))
+	if err != nil {
+		return nil, err
+	}
+	if len(f.CustomFields) == 0 {
+		return base, nil
 	}
 
-	// Add the custom fields to the map
+	merged := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(base, &merged); err != nil {
+		return nil, err
+	}
 	for key, value := range f.CustomFields {
-		m[key] = value
+		if _, exists := merged[key]; exists {
+			return nil, fmt.Errorf("custom field '%s' collides with a standard issue field", key)
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal custom field '%s': %w", key, err)
+		}
+		merged[key] = raw
 	}
+	return json.Marshal(merged)
+}
+
+// JiraUser identifies a user by Atlassian account ID.
+type JiraUser struct {
+	AccountID string `json:"accountId"`
+}
 
-	return json.Marshal(m)
+// JiraPriority identifies a priority by name or ID.
+type JiraPriority struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
 }
 
 // JiraProject identifies the project by its key.
@@ -103,23 +200,24 @@ type JiraIssueType struct {
 	Name string `json:"name"`
 }
 
-// JiraIssueDescription represents the rich text description field.
+// JiraIssueDescription represents a rich text (ADF) document, used for issue descriptions and comments.
 type JiraIssueDescription struct {
-	Type    string                   `json:"type"`
-	Version int                      `json:"version"`
-	Content []JiraDescriptionContent `json:"content"`
-}
-
-// JiraDescriptionContent is part of the rich text format.
-type JiraDescriptionContent struct {
-	Type    string                `json:"type"`
-	Content []JiraDescriptionText `json:"content"`
-}
-
-// JiraDescriptionText is the final text part of the rich text format.
-type JiraDescriptionText struct {
-	Type string `json:"type"`
-	Text string `json:"text"`
+	Type    string    `json:"type"`
+	Version int       `json:"version"`
+	Content []ADFNode `json:"content"`
+}
+
+// JiraIssue is an issue as returned by an issue search.
+type JiraIssue struct {
+	ID     string `json:"id"`
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string   `json:"summary"`
+		Labels  []string `json:"labels"`
+		Status  struct {
+			Name string `json:"name"`
+		} `json:"status"`
+	} `json:"fields"`
 }
 
 // JiraIssueResponse models the response from Jira after creating an issue.
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// jsonEqual reports whether two JSON documents hold the same data, ignoring key order.
+func jsonEqual(t *testing.T, got []byte, want string) bool {
+	t.Helper()
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", want, err)
+	}
+	return reflect.DeepEqual(gotValue, wantValue)
+}
+
+func TestJiraIssueFieldsMarshalJSON(t *testing.T) {
+	base := func() JiraIssueFields {
+		return JiraIssueFields{Project: JiraProject{Key: "HR"}, Summary: "Offboard Jane Doe", IssueType: JiraIssueType{Name: "Task"}}
+	}
+	tests := []struct {
+		name   string
+		fields func() JiraIssueFields
+		want   string
+	}{
+		{
+			name:   "unset optional fields are omitted",
+			fields: base,
+			want:   `{"project":{"key":"HR"},"summary":"Offboard Jane Doe","issuetype":{"name":"Task"}}`,
+		},
+		{
+			name: "standard fields",
+			fields: func() JiraIssueFields {
+				f := base()
+				f.Labels = []string{"psdi", "offboarding"}
+				f.Assignee = &JiraUser{AccountID: "5b10ac8d"}
+				f.DueDate = "2024-07-01"
+				f.Priority = &JiraPriority{Name: "High"}
+				return f
+			},
+			want: `{"project":{"key":"HR"},"summary":"Offboard Jane Doe","issuetype":{"name":"Task"},
+				"labels":["psdi","offboarding"],"assignee":{"accountId":"5b10ac8d"},"duedate":"2024-07-01","priority":{"name":"High"}}`,
+		},
+		{
+			name: "custom fields of each kind",
+			fields: func() JiraIssueFields {
+				f := base()
+				f.CustomFieldText("customfield_10001", "Engineering")
+				f.CustomFieldDate("customfield_10002", time.Date(2024, 7, 1, 23, 30, 0, 0, time.UTC))
+				f.CustomFieldSelect("customfield_10003", "10100")
+				f.CustomFieldUser("customfield_10004", "5b10ac8d")
+				f.CustomFieldAssets("customfield_10005", "EMP-1", "EMP-2")
+				return f
+			},
+			want: `{"project":{"key":"HR"},"summary":"Offboard Jane Doe","issuetype":{"name":"Task"},
+				"customfield_10001":"Engineering","customfield_10002":"2024-07-01","customfield_10003":{"id":"10100"},
+				"customfield_10004":{"accountId":"5b10ac8d"},"customfield_10005":["EMP-1","EMP-2"]}`,
+		},
+		{
+			name: "empty custom field map",
+			fields: func() JiraIssueFields {
+				f := base()
+				f.CustomFields = map[string]interface{}{}
+				return f
+			},
+			want: `{"project":{"key":"HR"},"summary":"Offboard Jane Doe","issuetype":{"name":"Task"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.fields())
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if !jsonEqual(t, got, tt.want) {
+				t.Errorf("Marshal = %s, want %s", got, tt.want)
+			}
+			// The request wrapper must use the same encoding.
+			wrapped, err := json.Marshal(JiraIssueRequest{Fields: tt.fields()})
+			if err != nil {
+				t.Fatalf("Marshal request: %v", err)
+			}
+			if !jsonEqual(t, wrapped, `{"fields":`+tt.want+`}`) {
+				t.Errorf("Marshal request = %s, want the fields under \"fields\"", wrapped)
+			}
+		})
+	}
+}
+
+func TestJiraIssueFieldsMarshalJSONErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		custom  map[string]interface{}
+		wantErr string
+	}{
+		{name: "collides with a standard field", custom: map[string]interface{}{"summary": "other"}, wantErr: "collides with a standard issue field"},
+		{name: "unencodable value", custom: map[string]interface{}{"customfield_10001": make(chan int)}, wantErr: "customfield_10001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := JiraIssueFields{Summary: "Offboard Jane Doe", CustomFields: tt.custom}
+			if _, err := json.Marshal(fields); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Marshal error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email         string
+		stripPlusTags bool
+		want          string
+	}{
+		{email: "Jane.Doe@Example.COM", want: "jane.doe@example.com"},
+		{email: "  jane.doe@example.com \t", want: "jane.doe@example.com"},
+		{email: "Jane.Doe+Payroll@Example.com ", want: "jane.doe+payroll@example.com"},
+		{email: "Jane.Doe+Payroll@Example.com ", stripPlusTags: true, want: "jane.doe@example.com"},
+		{email: "jane+a+b@example.com", stripPlusTags: true, want: "jane@example.com"},
+		// A local part that is only a tag is left alone rather than emptied.
+		{email: "+tag@example.com", stripPlusTags: true, want: "+tag@example.com"},
+		{email: "not-an-address+tag", stripPlusTags: true, want: "not-an-address+tag"},
+		{email: "", stripPlusTags: true, want: ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeEmail(tt.email, tt.stripPlusTags); got != tt.want {
+			t.Errorf("NormalizeEmail(%q, %t) = %q, want %q", tt.email, tt.stripPlusTags, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// internal/orgadmin/orgAdminClient.go
+
+// Package orgadmin is a client for the Atlassian organization admin API, used to suspend the
+// Atlassian accounts of terminated employees.
+package orgadmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/httpclient"
+)
+
+// maxLoggedBodyBytes caps how much of an error response body is written to the log.
+const maxLoggedBodyBytes = 2048
+
+// APIError is returned when the admin API responds with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Atlassian admin API returned non-2xx status: %s", e.Status)
+}
+
+// Client calls the admin API for one organization. It is safe for concurrent use.
+type Client struct {
+	cfg        config.OrgAdminConfig
+	httpClient *http.Client
+
+	// users maps lowercased email to the organization's managed account, loaded on first use
+	// and kept for the client's lifetime, which is one run.
+	mu    sync.Mutex
+	users map[string]User
+}
+
+// NewClient creates an admin API client. It returns an error if the organization ID or the
+// admin API key is missing.
+func NewClient(cfg config.OrgAdminConfig) (*Client, error) {
+	if cfg.AtlassianOrgID == "" || cfg.AtlassianOrgAdminToken == "" {
+		return nil, fmt.Errorf("Atlassian admin client configuration is incomplete: ATLASSIAN_ORG_ID and ATLASSIAN_ORG_ADMIN_TOKEN are required")
+	}
+	if _, err := url.Parse(cfg.AtlassianAdminURL); err != nil {
+		return nil, fmt.Errorf("invalid Atlassian admin API URL '%s': %w", cfg.AtlassianAdminURL, err)
+	}
+	httpClient, _, err := httpclient.New("Atlassian Admin", cfg.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Atlassian admin HTTP client configuration: %w", err)
+	}
+	return &Client{cfg: cfg, httpClient: httpClient}, nil
+}
+
+// makeAPIRequest sends a request to path below the organization, e.g. "users", and decodes a
+// JSON response into out when out is non-nil.
+func (c *Client) makeAPIRequest(ctx context.Context, method, path string, query url.Values, out interface{}) error {
+	apiURL, err := url.Parse(c.cfg.AtlassianAdminURL)
+	if err != nil {
+		return fmt.Errorf("invalid Atlassian admin API URL from config: %w", err)
+	}
+	apiURL = apiURL.JoinPath("admin/v1/orgs", c.cfg.AtlassianOrgID, path)
+	if query != nil {
+		apiURL.RawQuery = query.Encode()
+	}
+	return c.do(ctx, method, apiURL.String(), out)
+}
+
+// do sends an authenticated request to an absolute URL.
+func (c *Client) do(ctx context.Context, method, rawURL string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create Atlassian admin API request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.cfg.AtlassianOrgAdminToken)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.cfg.UserAgent)
+
+	log.Printf("INFO: [OrgAdmin] Making %s request to: %s", method, rawURL)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute Atlassian admin API request: %w", err)
+	}
+	defer httpclient.DrainAndClose(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBodyBytes))
+		log.Printf("ERROR: [OrgAdmin] Atlassian admin API returned non-2xx status: %s, body: %s", resp.Status, strings.TrimSpace(string(body)))
+		return &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: body}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Atlassian admin API response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// internal/orgadmin/orgAdminUsers.go
+
+package orgadmin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Account statuses reported by the admin API.
+const (
+	AccountStatusActive = "active"
+)
+
+// Outcomes of SuspendUserByEmail.
+const (
+	SuspendOutcomeSuspended       = "suspended"
+	SuspendOutcomeWouldSuspend    = "would-suspend" // Dry run
+	SuspendOutcomeNotFound        = "not-found"     // No managed account has the email
+	SuspendOutcomeAlreadyInactive = "already-inactive"
+)
+
+// maxUserPages bounds the user listing in case the API keeps returning a next link.
+const maxUserPages = 1000
+
+// User is a managed account in the organization.
+type User struct {
+	AccountID     string `json:"account_id"`
+	Email         string `json:"email"`
+	Name          string `json:"name"`
+	AccountStatus string `json:"account_status"`
+}
+
+// usersPage is one page of the organization's managed accounts.
+type usersPage struct {
+	Data  []User `json:"data"`
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+// SuspendResult describes what SuspendUserByEmail did.
+type SuspendResult struct {
+	AccountID string
+	Status    string // The account's status before the call
+	Outcome   string
+}
+
+// FindUserByEmail returns the managed account with the given email, matched case-insensitively,
+// or nil if there is none. The organization's accounts are listed once and then cached.
+func (c *Client) FindUserByEmail(ctx context.Context, email string) (*User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.users == nil {
+		users, err := c.listUsers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.users = users
+	}
+	user, ok := c.users[strings.ToLower(strings.TrimSpace(email))]
+	if !ok {
+		return nil, nil
+	}
+	return &user, nil
+}
+
+// listUsers pages through the organization's managed accounts, keyed by lowercased email.
+func (c *Client) listUsers(ctx context.Context) (map[string]User, error) {
+	users := make(map[string]User)
+	var page usersPage
+	if err := c.makeAPIRequest(ctx, http.MethodGet, "users", nil, &page); err != nil {
+		return nil, fmt.Errorf("failed to list Atlassian organization users: %w", err)
+	}
+	for pages := 1; ; pages++ {
+		for _, user := range page.Data {
+			if email := strings.ToLower(strings.TrimSpace(user.Email)); email != "" {
+				users[email] = user
+			}
+		}
+		next := page.Links.Next
+		if next == "" {
+			break
+		}
+		if pages >= maxUserPages {
+			return nil, fmt.Errorf("Atlassian organization user listing exceeded %d pages", maxUserPages)
+		}
+		// The next link is either a full URL or a cursor for the same endpoint.
+		page = usersPage{}
+		var err error
+		if strings.HasPrefix(next, "http://") || strings.HasPrefix(next, "https://") {
+			err = c.do(ctx, http.MethodGet, next, &page)
+		} else {
+			err = c.makeAPIRequest(ctx, http.MethodGet, "users", url.Values{"cursor": {next}}, &page)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Atlassian organization users: %w", err)
+		}
+	}
+	log.Printf("INFO: [OrgAdmin] Loaded %d managed accounts for organization %s.", len(users), c.cfg.AtlassianOrgID)
+	return users, nil
+}
+
+// SuspendUserByEmail suspends the Atlassian account with the given email, revoking its access
+// to every product in the organization. Accounts that are not active are left alone. With
+// dryRun set, the account is looked up but not suspended.
+func (c *Client) SuspendUserByEmail(ctx context.Context, email string, dryRun bool) (SuspendResult, error) {
+	user, err := c.FindUserByEmail(ctx, email)
+	if err != nil {
+		return SuspendResult{}, err
+	}
+	if user == nil {
+		return SuspendResult{Outcome: SuspendOutcomeNotFound}, nil
+	}
+	result := SuspendResult{AccountID: user.AccountID, Status: user.AccountStatus}
+	if !strings.EqualFold(user.AccountStatus, AccountStatusActive) {
+		result.Outcome = SuspendOutcomeAlreadyInactive
+		return result, nil
+	}
+	if dryRun {
+		log.Printf("INFO: DRY RUN: Would suspend Atlassian account %s (%s).", user.AccountID, email)
+		result.Outcome = SuspendOutcomeWouldSuspend
+		return result, nil
+	}
+
+	path := "directory/users/" + url.PathEscape(user.AccountID) + "/suspend-access"
+	if err := c.makeAPIRequest(ctx, http.MethodPost, path, nil, nil); err != nil {
+		return result, fmt.Errorf("failed to suspend Atlassian account %s: %w", user.AccountID, err)
+	}
+	log.Printf("SUCCESS: [OrgAdmin] Suspended Atlassian account %s (%s).", user.AccountID, email)
+
+	c.mu.Lock()
+	user.AccountStatus = "suspended"
+	c.users[strings.ToLower(strings.TrimSpace(email))] = *user
+	c.mu.Unlock()
+
+	result.Outcome = SuspendOutcomeSuspended
+	return result, nil
+}
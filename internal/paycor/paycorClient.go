@@ -4,6 +4,8 @@ package paycor
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -13,23 +15,44 @@ import (
 
 	// Import the central config package
 	"github.com/Devon-ODell/PSDIv0.2/internal/config"
-	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+	"github.com/Devon-ODell/PSDIv0.2/internal/httpclient"
 	"golang.org/x/oauth2"
 )
 
 // NOTE: The UnmarshalJSON method for models.Employee has been moved to internal/models/structs.go
 // This is required because methods can only be defined on types within the same package.
 
-// EmployeesAPIResponse models the expected API response structure when fetching employees.
+// EmployeesAPIResponse models one page of the employees endpoint. Paycor documents the list as
+// "records", but some responses carry it as "items", so both are accepted; use EmployeeRecords
+// rather than either field. The records are kept raw so each can be decoded on its own.
 type EmployeesAPIResponse struct {
-	Records           []models.Employee `json:"records"`
+	Records           []json.RawMessage `json:"records"`
+	Items             []json.RawMessage `json:"items"`
 	ContinuationToken string            `json:"continuationToken"`
+	TotalCount        *int              `json:"totalCount,omitempty"` // Not always provided by the API
+}
+
+// EmployeeRecords returns the page's employee records from whichever field Paycor used.
+func (r *EmployeesAPIResponse) EmployeeRecords() []json.RawMessage {
+	if len(r.Records) > 0 {
+		return r.Records
+	}
+	return r.Items
+}
+
+// missingRecordList reports whether the page had neither a "records" nor an "items" list, which
+// means Paycor changed its response shape and every employee on the page would silently be lost.
+// An empty list, as on a legitimately empty page, is not missing.
+func (r *EmployeesAPIResponse) missingRecordList() bool {
+	return r.Records == nil && r.Items == nil
 }
 
 // Client manages communication with the Paycor API.
 type Client struct {
 	cfg        config.PaycorConfig // Use the imported config struct
 	httpClient *http.Client
+	retries    *httpclient.RetryTransport
+	tokens     *loggingTokenSource
 }
 
 // loggingTokenSource (same as before, but references the central config)
@@ -37,6 +60,7 @@ type loggingTokenSource struct {
 	src              oauth2.TokenSource
 	lastRefreshToken string
 	paycorCfg        config.PaycorConfig // Use the imported config struct
+	health           *tokenHealth
 }
 
 func (s *loggingTokenSource) Token() (*oauth2.Token, error) {
@@ -44,27 +68,52 @@ func (s *loggingTokenSource) Token() (*oauth2.Token, error) {
 	token, err := s.src.Token()
 	if err != nil {
 		log.Printf("ERROR: [PaycorTokenSource] Failed to retrieve/refresh token: %v", err)
-		if retrieveError, ok := err.(*oauth2.RetrieveError); ok {
+		var tokenErr *TokenError
+		if errors.As(err, &tokenErr) {
+			log.Printf("DEBUG: [PaycorTokenSource] Token endpoint error: status=%d error=%q description=%q", tokenErr.StatusCode, tokenErr.ErrorCode, tokenErr.ErrorDescription)
+			if tokenErr.ErrorCode == "" {
+				log.Printf("  Response Body: %s", tokenErr.Body)
+			}
+		} else if retrieveError, ok := err.(*oauth2.RetrieveError); ok {
 			log.Printf("DEBUG: [PaycorTokenSource] OAuth2 RetrieveError details:")
 			if retrieveError.Response != nil {
 				log.Printf("  HTTP Status Code: %d", retrieveError.Response.StatusCode)
 			}
 			log.Printf("  Response Body: %s", string(retrieveError.Body))
 		}
+		s.health.recordFailure(err)
 		return nil, err
 	}
 	log.Printf("DEBUG: [PaycorTokenSource] Successfully retrieved/refreshed token.")
 	log.Printf("  Expires At (UTC): %s", token.Expiry.UTC().Format(time.RFC3339))
 
-	if token.RefreshToken != "" && token.RefreshToken != s.lastRefreshToken {
+	rotated := token.RefreshToken != "" && token.RefreshToken != s.lastRefreshToken
+	if rotated {
 		log.Printf("INFO: [PaycorTokenSource] A new Refresh Token was issued (masked): %s...", safeSubstring(token.RefreshToken, 10))
 		log.Println("INFO: [PaycorTokenSource] IMPORTANT: The new refresh token should be saved securely and used for subsequent runs.")
 		s.lastRefreshToken = token.RefreshToken
 		log.Printf("ACTION REQUIRED: New Refresh Token: %s", token.RefreshToken)
 	}
+	s.health.recordSuccess(rotated)
 	return token, nil
 }
 
+// userAgentTransport sets the User-Agent header on requests that don't already carry one.
+type userAgentTransport struct {
+	userAgent string
+	base      http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.userAgent == "" || req.Header.Get("User-Agent") != "" {
+		return t.base.RoundTrip(req)
+	}
+	// RoundTrippers must not modify the caller's request, so work on a clone.
+	clone := req.Clone(req.Context())
+	clone.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(clone)
+}
+
 // NewClient creates a new Paycor API client.
 // It now accepts the central config.PaycorConfig struct.
 func NewClient(ctx context.Context, cfg config.PaycorConfig) (*Client, error) {
@@ -73,41 +122,45 @@ func NewClient(ctx context.Context, cfg config.PaycorConfig) (*Client, error) {
 		return nil, fmt.Errorf("Paycor client configuration is incomplete in NewClient")
 	}
 
-	parsedTokenURL, err := url.Parse(cfg.PaycorTokenURLBase)
-	if err != nil {
+	if _, err := url.Parse(cfg.PaycorTokenURLBase); err != nil {
 		return nil, fmt.Errorf("invalid Paycor Token URL Base '%s': %w", cfg.PaycorTokenURLBase, err)
 	}
-	queryToken := parsedTokenURL.Query()
-	queryToken.Set("subscription-key", cfg.PaycorOcpApimSubscriptionKey)
-	parsedTokenURL.RawQuery = queryToken.Encode()
-	fullTokenURL := parsedTokenURL.String()
 
-	oauthConf := &oauth2.Config{
-		ClientID:     cfg.PaycorClientID,
-		ClientSecret: cfg.PaycorClientSecret,
-		Scopes:       cfg.PaycorScopes,
-		Endpoint:     oauth2.Endpoint{TokenURL: fullTokenURL},
+	// The token endpoint is called by refreshTokenSource, not makeAPIRequest, so the
+	// User-Agent is added by the transport for those requests.
+	transport, err := httpclient.NewTransport(cfg.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Paycor HTTP client configuration: %w", err)
+	}
+	retries := httpclient.NewRetryTransport("Paycor", transport, cfg.HTTP)
+	customHTTPClient := &http.Client{
+		Timeout:   cfg.HTTP.Timeout,
+		Transport: &userAgentTransport{userAgent: cfg.UserAgent, base: retries},
 	}
 
 	initialToken := &oauth2.Token{
 		RefreshToken: cfg.PaycorRefreshToken,
 		Expiry:       time.Now().Add(-1 * time.Hour), // Force initial refresh
 	}
+	refreshSource := newRefreshTokenSource(ctx, customHTTPClient, cfg)
+	loggingTS := &loggingTokenSource{src: oauth2.ReuseTokenSource(initialToken, refreshSource), lastRefreshToken: cfg.PaycorRefreshToken, paycorCfg: cfg, health: newTokenHealth(cfg)}
 
-	loggingTS := &loggingTokenSource{src: oauthConf.TokenSource(ctx, initialToken), lastRefreshToken: cfg.PaycorRefreshToken, paycorCfg: cfg}
-
-	customHTTPClient := &http.Client{
-		Timeout: 90 * time.Second,
-	}
 	authCtx := context.WithValue(ctx, oauth2.HTTPClient, customHTTPClient)
 	authedClient := oauth2.NewClient(authCtx, loggingTS)
 
 	return &Client{
 		cfg:        cfg,
 		httpClient: authedClient,
+		retries:    retries,
+		tokens:     loggingTS,
 	}, nil
 }
 
+// RetriesUsed returns how many request retries the client has made, out of HTTP_RETRY_BUDGET.
+func (c *Client) RetriesUsed() int {
+	return c.retries.RetriesUsed()
+}
+
 func (c *Client) makeAPIRequest(ctx context.Context, method, path string, queryParams url.Values, body io.Reader) ([]byte, int, error) {
 	fullURL, err := url.Parse(c.cfg.PaycorAPIBaseURL)
 	if err != nil {
@@ -126,6 +179,7 @@ func (c *Client) makeAPIRequest(ctx context.Context, method, path string, queryP
 
 	req.Header.Add("Ocp-Apim-Subscription-key", c.cfg.PaycorOcpApimSubscriptionKey)
 	req.Header.Add("Accept", "application/json")
+	req.Header.Set("User-Agent", c.cfg.UserAgent)
 	if body != nil {
 		req.Header.Add("Content-Type", "application/json")
 	}
@@ -145,7 +199,7 @@ func (c *Client) makeAPIRequest(ctx context.Context, method, path string, queryP
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		log.Printf("ERROR: [PaycorClient] API request to %s failed with status %d. Body: %s", urlStr, resp.StatusCode, string(responseBodyBytes))
-		return responseBodyBytes, resp.StatusCode, fmt.Errorf("API request to %s failed with status %d. Body: %s", urlStr, resp.StatusCode, string(responseBodyBytes))
+		return responseBodyBytes, resp.StatusCode, newAPIError(resp.StatusCode, urlStr, responseBodyBytes)
 	}
 
 	return responseBodyBytes, resp.StatusCode, nil
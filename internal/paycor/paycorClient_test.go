@@ -0,0 +1,97 @@
+package paycor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+)
+
+func TestMain(m *testing.M) {
+	// The client logs every request; keep test output readable.
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// testLegalEntityID is the legal entity the test clients fetch employees for.
+const testLegalEntityID = "123456"
+
+// newTestClient returns a client for a fake Paycor whose token endpoint always succeeds and
+// whose employees endpoint for testLegalEntityID is served by employees.
+func newTestClient(t *testing.T, employees http.HandlerFunc) *Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"access","token_type":"Bearer","refresh_token":"refresh","expires_in":3600}`))
+	})
+	mux.HandleFunc("GET /legalentities/"+testLegalEntityID+"/employees", employees)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(context.Background(), config.PaycorConfig{
+		PaycorTokenURLBase:           server.URL + "/token",
+		PaycorAPIBaseURL:             server.URL,
+		PaycorClientID:               "client",
+		PaycorClientSecret:           "secret",
+		PaycorOcpApimSubscriptionKey: "subscription",
+		PaycorRefreshToken:           "refresh",
+		PaycorLegalEntityID:          testLegalEntityID,
+		PaycorTokenAuthStyle:         config.PaycorTokenAuthQuery,
+		PaycorMaxDecodeFailures:      -1,
+		HTTP:                         config.HTTPClientConfig{Timeout: 10 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestEmployeesAPIResponseRecordField(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantIDs     []string
+		wantMissing bool
+	}{
+		{name: "records", body: `{"records":[{"id":"r1"},{"id":"r2"}],"continuationToken":"abc"}`, wantIDs: []string{"r1", "r2"}},
+		{name: "items", body: `{"items":[{"id":"i1"}],"continuationToken":"abc"}`, wantIDs: []string{"i1"}},
+		{name: "records preferred when both are set", body: `{"records":[{"id":"r1"}],"items":[{"id":"i1"}]}`, wantIDs: []string{"r1"}},
+		{name: "items used when records is empty", body: `{"records":[],"items":[{"id":"i1"}]}`, wantIDs: []string{"i1"}},
+		{name: "empty page", body: `{"records":[]}`},
+		{name: "null list", body: `{"records":null,"continuationToken":""}`, wantMissing: true},
+		{name: "neither list", body: `{"data":[{"id":"d1"}],"continuationToken":"abc"}`, wantMissing: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var response EmployeesAPIResponse
+			if err := json.Unmarshal([]byte(tt.body), &response); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			var ids []string
+			for _, record := range response.EmployeeRecords() {
+				var employee struct {
+					ID string `json:"id"`
+				}
+				if err := json.Unmarshal(record, &employee); err != nil {
+					t.Fatalf("Unmarshal record %s: %v", record, err)
+				}
+				ids = append(ids, employee.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("EmployeeRecords() = %v, want %v", ids, tt.wantIDs)
+			}
+			if got := response.missingRecordList(); got != tt.wantMissing {
+				t.Errorf("missingRecordList() = %t, want %t", got, tt.wantMissing)
+			}
+		})
+	}
+}
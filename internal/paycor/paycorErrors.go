@@ -0,0 +1,118 @@
+// internal/paycor/paycorErrors.go
+
+package paycor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when the Paycor API responds with a non-2xx status. Code and Message
+// are parsed from the body when it is JSON; Body keeps the raw response either way.
+type APIError struct {
+	StatusCode int
+	URL        string
+	Code       string // Paycor's or the API gateway's error code, when it sent one
+	Message    string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("Paycor API request to %s failed with status %d", e.URL, e.StatusCode)
+	switch {
+	case e.Code != "" && e.Message != "":
+		return msg + ": " + e.Code + ": " + e.Message
+	case e.Code != "" || e.Message != "":
+		return msg + ": " + e.Code + e.Message
+	case e.Body != "":
+		return msg + ". Body: " + safeSubstring(e.Body, 500)
+	}
+	return msg
+}
+
+// newAPIError builds an APIError, parsing the error shapes Paycor and its API gateway use:
+// {"statusCode": 401, "message": "..."} from the gateway, {"code": "...", "message": "..."} or
+// an "errors" list from the API, and problem details ({"title": "...", "detail": "..."}).
+func newAPIError(statusCode int, url string, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, URL: url, Body: string(body)}
+	var parsed struct {
+		Code      json.RawMessage `json:"code"`
+		ErrorCode string          `json:"errorCode"`
+		Message   string          `json:"message"`
+		Title     string          `json:"title"`
+		Detail    string          `json:"detail"`
+		Errors    json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return apiErr
+	}
+	apiErr.Code = firstNonEmpty(jsonText(parsed.Code), parsed.ErrorCode)
+	apiErr.Message = firstNonEmpty(parsed.Message, parsed.Detail, parsed.Title)
+
+	// "errors" is either a list of {code, message} or, in problem details, a map of field to messages.
+	var list []struct {
+		Code    json.RawMessage `json:"code"`
+		Message string          `json:"message"`
+	}
+	if json.Unmarshal(parsed.Errors, &list) == nil && len(list) > 0 {
+		apiErr.Code = firstNonEmpty(apiErr.Code, jsonText(list[0].Code))
+		apiErr.Message = firstNonEmpty(apiErr.Message, list[0].Message)
+	}
+	return apiErr
+}
+
+// jsonText returns a JSON string or number as text.
+func jsonText(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// statusIs reports whether err is an APIError with the given status.
+func statusIs(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// IsAuthError reports whether Paycor rejected the request's credentials: the access token, or
+// the subscription key, which the API gateway checks first.
+func IsAuthError(err error) bool {
+	return statusIs(err, http.StatusUnauthorized)
+}
+
+// IsScopeError reports whether Paycor refused the request because the token's scopes don't
+// cover the endpoint.
+func IsScopeError(err error) bool {
+	return statusIs(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether the requested resource, such as the legal entity, doesn't exist.
+func IsNotFound(err error) bool {
+	return statusIs(err, http.StatusNotFound)
+}
+
+// isSubscriptionKeyError reports whether an auth error came from the API gateway rejecting the
+// subscription key rather than from Paycor rejecting the token.
+func isSubscriptionKeyError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized &&
+		strings.Contains(strings.ToLower(apiErr.Message+" "+apiErr.Body), "subscription key")
+}
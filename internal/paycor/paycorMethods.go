@@ -1,74 +1,447 @@
 package paycor
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/Devon-ODell/PSDIv0.2/internal/models"
 )
 
-// FetchAllEmployees fetches all employees for the configured LegalEntityID.
-func (c *Client) FetchAllEmployees(ctx context.Context) ([]models.Employee, error) {
-	if c.cfg.PaycorLegalEntityID == "" {
-		return nil, fmt.Errorf("LegalEntityID is not configured in Paycor client")
+// EmployeePage is one page of employees from FetchEmployees, or the error that ended the fetch.
+type EmployeePage struct {
+	Number     int
+	Employees  []models.Employee
+	TotalCount int // Paycor's reported total across all pages, or 0 when it doesn't send one
+	Err        error
+
+	// DecodeFailures lists the page's records that could not be decoded. They are left out of
+	// Employees; the rest of the page is unaffected.
+	DecodeFailures []DecodeFailure
+}
+
+// DecodeFailure is an employee record that could not be decoded, e.g. because a field came
+// back with the wrong JSON type.
+type DecodeFailure struct {
+	Page       int    `json:"page"`
+	Index      int    `json:"index"`                // Position of the record on the page
+	EmployeeID string `json:"employeeId,omitempty"` // Set when the record's id could still be read
+	Err        string `json:"error"`
+	Raw        string `json:"raw"` // The record's JSON, truncated to maxDecodeFailureRaw bytes
+}
+
+// maxDecodeFailureRaw caps how much of an undecodable record is kept for debugging.
+const maxDecodeFailureRaw = 500
+
+// rawEmployeePage is a fetched but not yet decoded page, passed from the fetch stage to the decode stage.
+type rawEmployeePage struct {
+	number int
+	body   []byte
+	err    error
+}
+
+// FetchEmployees streams employees for the configured LegalEntityID page by page.
+// Fetching and decoding run as a two-stage pipeline: the HTTP request for page N+1 starts
+// as soon as page N's continuation token is known, while page N is still being decoded
+// and handed to the caller. PaycorPagesInFlight bounds how many pages may be buffered
+// between the stages and the caller. Pages are always delivered in order.
+// The channel is closed after the last page, or after a page carrying an Err.
+// Cancelling ctx tears down both stages; the caller should keep draining the channel until it closes.
+func (c *Client) FetchEmployees(ctx context.Context) <-chan EmployeePage {
+	inFlight := c.cfg.PaycorPagesInFlight
+	if inFlight < 1 {
+		inFlight = 1
 	}
+	rawPages := make(chan rawEmployeePage, inFlight-1)
+	pages := make(chan EmployeePage, inFlight-1)
 
-	var allEmployees []models.Employee
-	currentContinuationToken := ""
-	apiPath := fmt.Sprintf("/legalentities/%s/employees", c.cfg.PaycorLegalEntityID)
-	pageCount := 0
+	go c.fetchEmployeePages(ctx, rawPages)
+	go c.decodeEmployeePages(ctx, rawPages, pages)
 
-	log.Printf("INFO: [PaycorClient] Starting to fetch all employees for Legal Entity ID: %s", c.cfg.PaycorLegalEntityID)
+	return pages
+}
 
-	for {
-		pageCount++
-		queryParams := url.Values{}
-		if currentContinuationToken != "" {
-			queryParams.Set("continuationToken", currentContinuationToken)
+// fetchEmployeePages is the fetch stage. It requests pages serially, following continuation tokens.
+func (c *Client) fetchEmployeePages(ctx context.Context, rawPages chan<- rawEmployeePage) {
+	defer close(rawPages)
+
+	send := func(page rawEmployeePage) bool {
+		select {
+		case rawPages <- page:
+			return true
+		case <-ctx.Done():
+			return false
 		}
-		queryParams.Set("include", "All")
+	}
 
-		log.Printf("DEBUG: [PaycorClient] Fetching page %d for employees (LE ID %s) with token: %s...",
-			pageCount, c.cfg.PaycorLegalEntityID, safeSubstring(currentContinuationToken, 10))
+	if c.cfg.PaycorLegalEntityID == "" {
+		send(rawEmployeePage{err: fmt.Errorf("LegalEntityID is not configured in Paycor client")})
+		return
+	}
+	log.Printf("INFO: [PaycorClient] Starting to fetch all employees for Legal Entity ID: %s", c.cfg.PaycorLegalEntityID)
 
-		empBody, _, err := c.makeAPIRequest(ctx, "GET", apiPath, queryParams, nil)
+	maxPages := c.cfg.PaycorMaxPages
+	maxEmptyPages := c.cfg.PaycorMaxEmptyPages
+	if maxEmptyPages < 1 {
+		maxEmptyPages = 1
+	}
+
+	// Paycor has been seen returning empty pages that still carry a continuation token,
+	// so guard against a token that repeats, an endless run of empty pages, and runaway paging.
+	seenTokens := make(map[string]bool)
+	consecutiveEmpty := 0
+	fetchedRecords := 0
+	var totalCount *int
+
+	currentContinuationToken := ""
+	for pageCount := 1; ; pageCount++ {
+		if maxPages > 0 && pageCount > maxPages {
+			send(rawEmployeePage{number: pageCount, err: fmt.Errorf("aborting employee fetch for LE ID %s: exceeded the maximum of %d pages (PAYCOR_MAX_PAGES)", c.cfg.PaycorLegalEntityID, maxPages)})
+			return
+		}
+
+		body, err := c.fetchEmployeePage(ctx, pageCount, currentContinuationToken)
 		if err != nil {
-			return nil, fmt.Errorf("API call for employees page %d (LE ID %s) failed: %w", pageCount, c.cfg.PaycorLegalEntityID, err)
+			send(rawEmployeePage{number: pageCount, err: err})
+			return
 		}
 
-		var empResponse EmployeesAPIResponse
-		if err := json.Unmarshal(empBody, &empResponse); err != nil {
-			log.Printf("ERROR: [PaycorClient] Could not unmarshal Employees page %d response for LE ID %s. Raw response snippet:\n%s. Error: %v",
-				pageCount, c.cfg.PaycorLegalEntityID, safeSubstring(string(empBody), 500), err)
-			return nil, fmt.Errorf("unmarshaling employees response for page %d (LE ID %s): %w", pageCount, c.cfg.PaycorLegalEntityID, err)
+		// Only the token and record count are needed to keep fetching; the records are decoded by the next stage.
+		var pageInfo EmployeesAPIResponse
+		if err := json.Unmarshal(body, &pageInfo); err != nil {
+			send(rawEmployeePage{number: pageCount, err: fmt.Errorf("reading continuation token from page %d (LE ID %s): %w", pageCount, c.cfg.PaycorLegalEntityID, err)})
+			return
+		}
+		records := len(pageInfo.EmployeeRecords())
+		fetchedRecords += records
+		if totalCount == nil && pageInfo.TotalCount != nil {
+			totalCount = pageInfo.TotalCount
 		}
 
-		if len(empResponse.Records) > 0 {
-			allEmployees = append(allEmployees, empResponse.Records...)
-			log.Printf("INFO: [PaycorClient] Fetched %d employees this page (%d total) for LE ID %s.",
-				len(empResponse.Records), len(allEmployees), c.cfg.PaycorLegalEntityID)
-		} else {
-			log.Printf("INFO: [PaycorClient] Fetched 0 employees on page %d for LE ID %s. This might indicate end of data or an issue.", pageCount, c.cfg.PaycorLegalEntityID)
+		if !send(rawEmployeePage{number: pageCount, body: body}) {
+			return
 		}
 
-		if empResponse.ContinuationToken != "" {
-			currentContinuationToken = empResponse.ContinuationToken
-		} else {
+		if pageInfo.ContinuationToken == "" {
 			log.Printf("INFO: [PaycorClient] No more continuationToken for LE ID %s after page %d. Finished fetching.", c.cfg.PaycorLegalEntityID, pageCount)
-			break
+			if totalCount != nil && *totalCount != fetchedRecords {
+				log.Printf("WARN: [PaycorClient] !!! Paycor reported totalCount %d for LE ID %s but %d records were fetched. The employee list may be incomplete. !!!",
+					*totalCount, c.cfg.PaycorLegalEntityID, fetchedRecords)
+			}
+			return
+		}
+
+		if records == 0 {
+			consecutiveEmpty++
+			if consecutiveEmpty >= maxEmptyPages {
+				send(rawEmployeePage{number: pageCount, err: fmt.Errorf("aborting employee fetch for LE ID %s: %d consecutive empty pages still carried a continuation token", c.cfg.PaycorLegalEntityID, consecutiveEmpty)})
+				return
+			}
+		} else {
+			consecutiveEmpty = 0
+		}
+
+		if seenTokens[pageInfo.ContinuationToken] {
+			send(rawEmployeePage{number: pageCount, err: fmt.Errorf("aborting employee fetch for LE ID %s: continuation token %s... repeated on page %d", c.cfg.PaycorLegalEntityID, safeSubstring(pageInfo.ContinuationToken, 10), pageCount)})
+			return
+		}
+		seenTokens[pageInfo.ContinuationToken] = true
+		currentContinuationToken = pageInfo.ContinuationToken
+	}
+}
+
+// decodeEmployeePages is the decode stage. It turns raw pages into EmployeePages, preserving order.
+// Each record is decoded on its own, so one malformed record is reported as a DecodeFailure
+// rather than failing its page. The fetch aborts once more than PAYCOR_MAX_DECODE_FAILURES
+// records have failed.
+func (c *Client) decodeEmployeePages(ctx context.Context, rawPages <-chan rawEmployeePage, pages chan<- EmployeePage) {
+	defer close(pages)
+
+	totalEmployees, totalFailures := 0, 0
+	for raw := range rawPages {
+		page := EmployeePage{Number: raw.number, Err: raw.err}
+		if page.Err == nil {
+			var empResponse EmployeesAPIResponse
+			if err := json.Unmarshal(raw.body, &empResponse); err != nil {
+				log.Printf("ERROR: [PaycorClient] Could not unmarshal Employees page %d response for LE ID %s. Raw response snippet:\n%s. Error: %v",
+					raw.number, c.cfg.PaycorLegalEntityID, safeSubstring(string(raw.body), 500), err)
+				page.Err = fmt.Errorf("unmarshaling employees response for page %d (LE ID %s): %w", raw.number, c.cfg.PaycorLegalEntityID, err)
+			} else {
+				records := empResponse.EmployeeRecords()
+				c.warnIfNoRecordList(raw.number, raw.body, &empResponse)
+				page.Employees, page.DecodeFailures = decodeEmployeeRecords(raw.number, records)
+				if empResponse.TotalCount != nil {
+					page.TotalCount = *empResponse.TotalCount
+				}
+				totalEmployees += len(page.Employees)
+				if len(records) > 0 {
+					log.Printf("INFO: [PaycorClient] Fetched %d employees this page (%d total) for LE ID %s.",
+						len(page.Employees), totalEmployees, c.cfg.PaycorLegalEntityID)
+				} else {
+					log.Printf("INFO: [PaycorClient] Fetched 0 employees on page %d for LE ID %s.", raw.number, c.cfg.PaycorLegalEntityID)
+				}
+				for _, failure := range page.DecodeFailures {
+					log.Printf("ERROR: [PaycorClient] Could not decode record %d on page %d (employee %s): %s. Raw record:\n%s",
+						failure.Index, failure.Page, failure.EmployeeID, failure.Err, failure.Raw)
+				}
+				totalFailures += len(page.DecodeFailures)
+				if limit := c.cfg.PaycorMaxDecodeFailures; limit >= 0 && totalFailures > limit {
+					page.Err = fmt.Errorf("aborting employee fetch for LE ID %s: %d records failed to decode, more than PAYCOR_MAX_DECODE_FAILURES (%d)", c.cfg.PaycorLegalEntityID, totalFailures, limit)
+				}
+			}
+		}
+
+		select {
+		case pages <- page:
+		case <-ctx.Done():
+			return
+		}
+		if page.Err != nil {
+			return
+		}
+	}
+}
+
+// decodeEmployeeRecords decodes a page's records one by one and returns the employees that decoded
+// and a DecodeFailure for each record that didn't.
+func decodeEmployeeRecords(pageNumber int, records []json.RawMessage) ([]models.Employee, []DecodeFailure) {
+	employees := make([]models.Employee, 0, len(records))
+	var failures []DecodeFailure
+	for i, record := range records {
+		var emp models.Employee
+		err := json.Unmarshal(record, &emp)
+		if err == nil {
+			employees = append(employees, emp)
+			continue
+		}
+		// The id usually survives even when another field has the wrong type.
+		var identity struct {
+			ID string `json:"id"`
+		}
+		_ = json.Unmarshal(record, &identity)
+		failures = append(failures, DecodeFailure{
+			Page:       pageNumber,
+			Index:      i,
+			EmployeeID: identity.ID,
+			Err:        err.Error(),
+			Raw:        safeSubstring(string(record), maxDecodeFailureRaw),
+		})
+	}
+	return employees, failures
+}
+
+// fetchEmployeePage requests a single page of employees and returns the raw response body.
+func (c *Client) fetchEmployeePage(ctx context.Context, pageCount int, continuationToken string) ([]byte, error) {
+	apiPath := fmt.Sprintf("/legalentities/%s/employees", c.cfg.PaycorLegalEntityID)
+
+	queryParams := url.Values{}
+	if continuationToken != "" {
+		queryParams.Set("continuationToken", continuationToken)
+	}
+	queryParams.Set("include", "All")
+
+	log.Printf("DEBUG: [PaycorClient] Fetching page %d for employees (LE ID %s) with token: %s...",
+		pageCount, c.cfg.PaycorLegalEntityID, safeSubstring(continuationToken, 10))
+
+	empBody, _, err := c.makeAPIRequest(ctx, "GET", apiPath, queryParams, nil)
+	if err != nil && pageCount == 1 {
+		if diagnosed := c.diagnoseAccessError(err); diagnosed != nil {
+			return nil, diagnosed
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("API call for employees page %d (LE ID %s) failed: %w", pageCount, c.cfg.PaycorLegalEntityID, err)
+	}
+	return empBody, nil
+}
+
+// diagnoseAccessError turns the errors a misconfiguration causes on the first employees request
+// into one that says what to fix. It returns nil for other errors.
+func (c *Client) diagnoseAccessError(err error) error {
+	switch {
+	case isSubscriptionKeyError(err):
+		return fmt.Errorf("Paycor's API gateway rejected the subscription key. Check PAYCOR_OCP_APIM_SUBSCRIPTION_KEY is the key of an active subscription for the %s environment: %w",
+			c.cfg.PaycorEnvironmentLabel(), err)
+	case IsAuthError(err), IsNotFound(err):
+		// A legal entity or token from the other Paycor environment fails like this on the first page.
+		return fmt.Errorf("API call for employees page 1 (LE ID %s) failed. Check PAYCOR_LEGAL_ENTITY_ID, and that the legal entity and refresh token belong to the %s environment (PAYCOR_ENVIRONMENT); sandbox and production IDs and tokens are not interchangeable: %w",
+			c.cfg.PaycorLegalEntityID, c.cfg.PaycorEnvironmentLabel(), err)
+	case IsScopeError(err):
+		scopes := strings.Join(c.cfg.PaycorScopes, " ")
+		if scopes == "" {
+			scopes = "none (PAYCOR_SCOPES is empty)"
+		}
+		return fmt.Errorf("the Paycor token's scopes do not include read access to employees for LE ID %s; configured scopes: %s. Grant the scope to the app and issue a new refresh token: %w",
+			c.cfg.PaycorLegalEntityID, scopes, err)
+	}
+	return nil
+}
+
+// CheckAccess makes one request for the first page of employees, so a bad subscription key,
+// missing scope or wrong legal entity fails at startup with an error saying what to fix,
+// rather than partway into a run.
+func (c *Client) CheckAccess(ctx context.Context) error {
+	if c.cfg.PaycorLegalEntityID == "" {
+		return fmt.Errorf("LegalEntityID is not configured in Paycor client")
+	}
+	_, err := c.fetchEmployeePage(ctx, 1, "")
+	return err
+}
+
+// FetchFirstEmployeePage fetches only the first page of employees and returns how many records
+// it held. It is a cheap way to prove the token and API scopes work.
+func (c *Client) FetchFirstEmployeePage(ctx context.Context) (int, error) {
+	if c.cfg.PaycorLegalEntityID == "" {
+		return 0, fmt.Errorf("LegalEntityID is not configured in Paycor client")
+	}
+	body, err := c.fetchEmployeePage(ctx, 1, "")
+	if err != nil {
+		return 0, err
+	}
+	var empResponse EmployeesAPIResponse
+	if err := json.Unmarshal(body, &empResponse); err != nil {
+		return 0, fmt.Errorf("unmarshaling employees response for page 1 (LE ID %s): %w", c.cfg.PaycorLegalEntityID, err)
+	}
+	c.warnIfNoRecordList(1, body, &empResponse)
+	return len(empResponse.EmployeeRecords()), nil
+}
+
+// warnIfNoRecordList logs an error when a non-empty page body holds no employee list under any
+// field name we know, naming the fields it did have, so a changed response shape can't silently
+// turn into a sync of zero employees.
+func (c *Client) warnIfNoRecordList(pageNumber int, body []byte, page *EmployeesAPIResponse) {
+	if !page.missingRecordList() || len(bytes.TrimSpace(body)) == 0 {
+		return
+	}
+	var fields map[string]json.RawMessage
+	names := []string{}
+	if json.Unmarshal(body, &fields) == nil {
+		for name := range fields {
+			names = append(names, name)
 		}
+		sort.Strings(names)
+	}
+	log.Printf("ERROR: [PaycorClient] !!! Employees page %d for LE ID %s has no 'records' or 'items' list, so no employees were read from it. Response fields: [%s]. Raw response snippet:\n%s !!!",
+		pageNumber, c.cfg.PaycorLegalEntityID, strings.Join(names, ", "), safeSubstring(string(body), 500))
+}
+
+// FetchAllEmployees fetches all employees for the configured LegalEntityID into memory.
+// Prefer FetchEmployees for large tenants.
+func (c *Client) FetchAllEmployees(ctx context.Context) ([]models.Employee, error) {
+	var allEmployees []models.Employee
+	pageCount := 0
+
+	for page := range c.FetchEmployees(ctx) {
+		if page.Err != nil {
+			return nil, page.Err
+		}
+		pageCount = page.Number
+		allEmployees = append(allEmployees, page.Employees...)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("fetching employees was cancelled: %w", err)
 	}
 
 	log.Printf("INFO: [PaycorClient] Successfully fetched a total of %d employees for Legal Entity ID %s over %d pages.", len(allEmployees), c.cfg.PaycorLegalEntityID, pageCount)
 	return allEmployees, nil
 }
 
+// FetchLocations fetches the work locations of the configured LegalEntityID, following
+// continuation tokens until the last page.
+func (c *Client) FetchLocations(ctx context.Context) ([]models.Location, error) {
+	if c.cfg.PaycorLegalEntityID == "" {
+		return nil, fmt.Errorf("LegalEntityID is not configured in Paycor client")
+	}
+	apiPath := fmt.Sprintf("/legalentities/%s/worklocations", c.cfg.PaycorLegalEntityID)
+
+	var locations []models.Location
+	continuationToken := ""
+	for pageCount := 1; ; pageCount++ {
+		queryParams := url.Values{}
+		if continuationToken != "" {
+			queryParams.Set("continuationToken", continuationToken)
+		}
+		body, _, err := c.makeAPIRequest(ctx, "GET", apiPath, queryParams, nil)
+		if err != nil {
+			return nil, fmt.Errorf("API call for work locations page %d (LE ID %s) failed: %w", pageCount, c.cfg.PaycorLegalEntityID, err)
+		}
+		var page EmployeesAPIResponse
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to decode work locations page %d: %w. Body: %s", pageCount, err, safeSubstring(string(body), 500))
+		}
+		for i, record := range page.EmployeeRecords() {
+			var location models.Location
+			if err := json.Unmarshal(record, &location); err != nil {
+				return nil, fmt.Errorf("failed to decode work location %d on page %d: %w", i, pageCount, err)
+			}
+			locations = append(locations, location)
+		}
+		if page.ContinuationToken == "" || page.ContinuationToken == continuationToken {
+			break
+		}
+		continuationToken = page.ContinuationToken
+	}
+
+	log.Printf("INFO: [PaycorClient] Fetched %d work locations for Legal Entity ID %s.", len(locations), c.cfg.PaycorLegalEntityID)
+	return locations, nil
+}
+
+// safeSubstring returns at most length bytes of s, backing off to the start of a character so a
+// multi-byte UTF-8 character is never split.
 func safeSubstring(s string, length int) string {
-	if len(s) < length {
+	if len(s) <= length {
 		return s
 	}
+	for length > 0 && !utf8.RuneStart(s[length]) {
+		length--
+	}
 	return s[:length]
 }
+
+// ErrReadOnlyField is returned when Paycor refuses to update a custom field because it is read-only.
+var ErrReadOnlyField = errors.New("Paycor custom field is read-only")
+
+// readOnlyMarkers are fragments of Paycor's error messages for fields that can't be written.
+var readOnlyMarkers = []string{"read-only", "readonly", "read only", "not editable", "cannot be updated"}
+
+// UpdateEmployeeCustomField sets a single custom field on a Paycor employee.
+func (c *Client) UpdateEmployeeCustomField(ctx context.Context, employeeID, fieldName, value string) error {
+	if employeeID == "" || fieldName == "" {
+		return fmt.Errorf("employee ID and custom field name are required")
+	}
+
+	reqBody := map[string]interface{}{
+		"customFields": []map[string]string{
+			{"name": fieldName, "value": value},
+		},
+	}
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal custom field update body: %w", err)
+	}
+
+	apiPath := fmt.Sprintf("/employees/%s/customfields", employeeID)
+	respBody, statusCode, err := c.makeAPIRequest(ctx, http.MethodPut, apiPath, nil, bytes.NewReader(bodyBytes))
+	if err != nil {
+		if statusCode == http.StatusForbidden || statusCode == http.StatusBadRequest {
+			lowerBody := strings.ToLower(string(respBody))
+			for _, marker := range readOnlyMarkers {
+				if strings.Contains(lowerBody, marker) {
+					return fmt.Errorf("cannot set '%s' on employee %s: %w", fieldName, employeeID, ErrReadOnlyField)
+				}
+			}
+		}
+		return fmt.Errorf("failed to update custom field '%s' on employee %s: %w", fieldName, employeeID, err)
+	}
+
+	log.Printf("SUCCESS: [PaycorClient] Set custom field '%s' on employee %s.", fieldName, employeeID)
+	return nil
+}
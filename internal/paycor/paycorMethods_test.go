@@ -0,0 +1,145 @@
+package paycor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+)
+
+// Regression test for pages Paycor sends with the list under "items" rather than "records",
+// which were read as having no employees, so the sync silently did nothing.
+func TestFetchAllEmployeesReadsEitherRecordList(t *testing.T) {
+	sample, err := os.ReadFile(filepath.Join("testdata", "employees_page.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		listField string
+		wantCount int
+		wantError bool // Whether the page is logged as having no record list
+	}{
+		{name: "records", listField: "records", wantCount: 2},
+		{name: "items", listField: "items", wantCount: 2},
+		{name: "unknown field", listField: "employees", wantCount: 0, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := bytes.Replace(sample, []byte(`"records":`), []byte(`"`+tt.listField+`":`), 1)
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write(body)
+			})
+
+			var logs bytes.Buffer
+			log.SetOutput(&logs)
+			defer log.SetOutput(io.Discard)
+
+			employees, err := client.FetchAllEmployees(context.Background())
+			if err != nil {
+				t.Fatalf("FetchAllEmployees: %v", err)
+			}
+			if len(employees) != tt.wantCount {
+				t.Fatalf("got %d employees, want %d", len(employees), tt.wantCount)
+			}
+			if tt.wantCount > 0 {
+				jane := employees[0]
+				if jane.Email.EmailAddress != "jane.doe@example.com" || jane.PositionData.JobTitle != "Engineer" || jane.EmploymentDateData.HireDate != "2023-02-13T00:00:00" {
+					t.Errorf("first employee decoded as %+v", jane)
+				}
+			}
+			logged := strings.Contains(logs.String(), "has no 'records' or 'items' list")
+			if logged != tt.wantError {
+				t.Errorf("missing record list logged = %t, want %t", logged, tt.wantError)
+			}
+			if tt.wantError && !strings.Contains(logs.String(), "Response fields: [additionalResultsUrl, contentItemCount, continuationToken, employees, hasMoreResults]") {
+				t.Errorf("the error did not name the fields the page had:\n%s", logs.String())
+			}
+		})
+	}
+}
+
+// endlessPages serves one employee per page, each with a new continuation token, so the fetch
+// never finishes on its own. With block set, every request after the first hangs until the
+// client gives up on it.
+type endlessPages struct {
+	block    bool
+	requests atomic.Int64
+}
+
+func (p *endlessPages) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.requests.Add(1)
+	page := 1
+	if token := r.URL.Query().Get("continuationToken"); token != "" {
+		page, _ = strconv.Atoi(strings.TrimPrefix(token, "page-"))
+		page++
+	}
+	if p.block && page > 1 {
+		<-r.Context().Done()
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"records":[{"id":"` + strconv.Itoa(page) + `"}],"continuationToken":"page-` + strconv.Itoa(page) + `"}`))
+}
+
+func TestFetchEmployeesCancelDoesNotLeak(t *testing.T) {
+	tests := []struct {
+		name  string
+		block bool // The next page's request is in flight when ctx is cancelled
+		drain bool // The caller keeps reading until the channel closes
+	}{
+		{name: "stages blocked on a full pipeline, caller drains", drain: true},
+		{name: "stages blocked on a full pipeline, caller stops reading"},
+		{name: "request in flight, caller drains", block: true, drain: true},
+		{name: "request in flight, caller stops reading", block: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Cleanups run last-registered first, so this check runs after the fake server is closed.
+			ignore := goleak.IgnoreCurrent()
+			t.Cleanup(func() { goleak.VerifyNone(t, ignore) })
+
+			server := &endlessPages{block: tt.block}
+			client := newTestClient(t, server.ServeHTTP)
+			client.cfg.PaycorPagesInFlight = 1 // Unbuffered, so each stage blocks as soon as the next one isn't reading
+
+			ctx, cancel := context.WithCancel(context.Background())
+			pages := client.FetchEmployees(ctx)
+			first, ok := <-pages
+			if !ok || first.Err != nil || len(first.Employees) != 1 {
+				t.Fatalf("first page = %+v (open %t), want one employee", first, ok)
+			}
+
+			// Wait for the decode stage to block handing over page 2 and the fetch stage to block
+			// handing over page 3, or for the request for page 2 to be hanging.
+			want := int64(3)
+			if tt.block {
+				want = 2
+			}
+			deadline := time.Now().Add(5 * time.Second)
+			for server.requests.Load() < want {
+				if time.Now().After(deadline) {
+					t.Fatalf("the fetch made %d requests, want %d", server.requests.Load(), want)
+				}
+				time.Sleep(time.Millisecond)
+			}
+			cancel()
+
+			if tt.drain {
+				for range pages {
+				}
+			}
+		})
+	}
+}
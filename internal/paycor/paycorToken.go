@@ -0,0 +1,150 @@
+// internal/paycor/paycorToken.go
+
+package paycor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"golang.org/x/oauth2"
+)
+
+// TokenError is a failed refresh token exchange, with the OAuth error fields parsed out of
+// the token endpoint's response.
+type TokenError struct {
+	StatusCode       int
+	ErrorCode        string // RFC 6749 "error", e.g. "invalid_grant"
+	ErrorDescription string
+	Body             string // Raw response body, for errors that aren't OAuth shaped
+}
+
+func (e *TokenError) Error() string {
+	if e.ErrorCode != "" {
+		msg := fmt.Sprintf("Paycor token refresh failed (HTTP %d): %s", e.StatusCode, e.ErrorCode)
+		if e.ErrorDescription != "" {
+			msg += ": " + e.ErrorDescription
+		}
+		return msg
+	}
+	return fmt.Sprintf("Paycor token refresh failed (HTTP %d): %s", e.StatusCode, safeSubstring(e.Body, 500))
+}
+
+// refreshTokenSource exchanges a refresh token for an access token itself, rather than through
+// oauth2.Config, so the subscription key can be sent the way the token endpoint expects.
+// It remembers the latest refresh token, since Paycor may rotate it on each exchange.
+type refreshTokenSource struct {
+	ctx        context.Context
+	httpClient *http.Client
+	cfg        config.PaycorConfig
+
+	mu           sync.Mutex
+	refreshToken string
+}
+
+func newRefreshTokenSource(ctx context.Context, httpClient *http.Client, cfg config.PaycorConfig) *refreshTokenSource {
+	return &refreshTokenSource{ctx: ctx, httpClient: httpClient, cfg: cfg, refreshToken: cfg.PaycorRefreshToken}
+}
+
+// tokenURL returns the token endpoint, with the subscription key in the query for the
+// "query" and "both" auth styles.
+func (s *refreshTokenSource) tokenURL() (string, error) {
+	parsed, err := url.Parse(s.cfg.PaycorTokenURLBase)
+	if err != nil {
+		return "", fmt.Errorf("invalid Paycor Token URL Base '%s': %w", s.cfg.PaycorTokenURLBase, err)
+	}
+	if s.cfg.PaycorTokenAuthStyle != config.PaycorTokenAuthHeader {
+		query := parsed.Query()
+		query.Set("subscription-key", s.cfg.PaycorOcpApimSubscriptionKey)
+		parsed.RawQuery = query.Encode()
+	}
+	return parsed.String(), nil
+}
+
+// Token performs the refresh token exchange. Callers wrap it in oauth2.ReuseTokenSource so it
+// only runs when the current access token has expired.
+func (s *refreshTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokenURL, err := s.tokenURL()
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {s.refreshToken},
+		"client_id":     {s.cfg.PaycorClientID},
+		"client_secret": {s.cfg.PaycorClientSecret},
+	}
+	if len(s.cfg.PaycorScopes) > 0 {
+		form.Set("scope", strings.Join(s.cfg.PaycorScopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Paycor token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if s.cfg.PaycorTokenAuthStyle != config.PaycorTokenAuthQuery {
+		req.Header.Set("Ocp-Apim-Subscription-Key", s.cfg.PaycorOcpApimSubscriptionKey)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Paycor token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Paycor token response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		tokenErr := &TokenError{StatusCode: resp.StatusCode, Body: string(body)}
+		var oauthErr struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &oauthErr) == nil {
+			tokenErr.ErrorCode = oauthErr.Error
+			tokenErr.ErrorDescription = oauthErr.ErrorDescription
+		}
+		return nil, tokenErr
+	}
+
+	var tokenResp struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Paycor token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, &TokenError{StatusCode: resp.StatusCode, Body: "token response has no access_token"}
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  tokenResp.AccessToken,
+		TokenType:    tokenResp.TokenType,
+		RefreshToken: tokenResp.RefreshToken,
+	}
+	if tokenResp.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = s.refreshToken
+	}
+	s.refreshToken = token.RefreshToken
+	return token, nil
+}
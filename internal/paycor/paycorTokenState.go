@@ -0,0 +1,142 @@
+// internal/paycor/paycorTokenState.go
+
+package paycor
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+)
+
+// TokenState records how refresh token exchanges have gone across runs, so a refresh token that
+// is dying is noticed before a nightly run fails on it. The tokens themselves are not stored.
+type TokenState struct {
+	LastSuccessfulRefresh time.Time `json:"lastSuccessfulRefresh"`
+	LastRotation          time.Time `json:"lastRotation"` // When Paycor last issued a new refresh token
+	LastFailure           time.Time `json:"lastFailure"`
+	LastError             string    `json:"lastError,omitempty"`
+	ConsecutiveFailures   int       `json:"consecutiveFailures"`
+}
+
+// tokenHealth tracks TokenState in PAYCOR_TOKEN_STATE_PATH and logs warnings when refreshes
+// stop succeeding. Every method is a no-op when the path is empty.
+type tokenHealth struct {
+	path          string
+	warnAfter     time.Duration
+	escalateAfter int
+
+	mu    sync.Mutex
+	state TokenState
+}
+
+// newTokenHealth loads the recorded state and warns if the last successful refresh is older
+// than PAYCOR_TOKEN_REFRESH_WARN_AFTER. An unreadable state file is logged and started afresh.
+func newTokenHealth(cfg config.PaycorConfig) *tokenHealth {
+	h := &tokenHealth{path: cfg.PaycorTokenStatePath, warnAfter: cfg.PaycorTokenRefreshWarnAfter, escalateAfter: cfg.PaycorTokenFailureEscalateAfter}
+	if h.path == "" {
+		return h
+	}
+	data, err := os.ReadFile(h.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("WARN: [PaycorTokenSource] Failed to read token state %s: %v", h.path, err)
+	} else if err == nil {
+		if err := json.Unmarshal(data, &h.state); err != nil {
+			log.Printf("WARN: [PaycorTokenSource] Failed to parse token state %s: %v", h.path, err)
+		}
+	}
+
+	if last := h.state.LastSuccessfulRefresh; !last.IsZero() && h.warnAfter > 0 && time.Since(last) > h.warnAfter {
+		log.Printf("WARN: [PaycorTokenSource] The last successful Paycor token refresh was %s (%v ago, over PAYCOR_TOKEN_REFRESH_WARN_AFTER=%v). Unused refresh tokens expire; check it is still valid.",
+			last.Format(time.RFC3339), time.Since(last).Round(time.Hour), h.warnAfter)
+	}
+	return h
+}
+
+// snapshot returns a copy of the current state.
+func (h *tokenHealth) snapshot() TokenState {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.state
+}
+
+// recordSuccess notes a successful refresh and whether it rotated the refresh token.
+func (h *tokenHealth) recordSuccess(rotated bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.path == "" {
+		return
+	}
+	if h.state.ConsecutiveFailures > 0 {
+		log.Printf("INFO: [PaycorTokenSource] Token refresh succeeded after %d consecutive failures.", h.state.ConsecutiveFailures)
+	}
+	h.state.LastSuccessfulRefresh = time.Now()
+	if rotated {
+		h.state.LastRotation = h.state.LastSuccessfulRefresh
+	}
+	h.state.ConsecutiveFailures = 0
+	h.state.LastError = ""
+	h.save()
+}
+
+// recordFailure notes a failed refresh. Once failures reach PAYCOR_TOKEN_FAILURE_ESCALATE_AFTER
+// in a row they are logged as an alert on every failure, not just once per run.
+func (h *tokenHealth) recordFailure(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.path == "" {
+		return
+	}
+	h.state.LastFailure = time.Now()
+	h.state.LastError = err.Error()
+	h.state.ConsecutiveFailures++
+	h.save()
+
+	since := "never"
+	if !h.state.LastSuccessfulRefresh.IsZero() {
+		since = h.state.LastSuccessfulRefresh.Format(time.RFC3339)
+	}
+	if h.escalateAfter > 0 && h.state.ConsecutiveFailures >= h.escalateAfter {
+		log.Printf("ERROR: [PaycorTokenSource] ALERT: Paycor token refresh has failed %d times in a row (last success: %s). The refresh token has probably expired or been revoked and must be replaced.",
+			h.state.ConsecutiveFailures, since)
+		return
+	}
+	log.Printf("WARN: [PaycorTokenSource] Paycor token refresh failed (%d in a row, last success: %s).", h.state.ConsecutiveFailures, since)
+}
+
+// save writes the state file. Failures are logged only; tracking must never stop a sync.
+func (h *tokenHealth) save() {
+	data, err := json.MarshalIndent(h.state, "", "  ")
+	if err == nil {
+		err = os.WriteFile(h.path, data, 0600)
+	}
+	if err != nil {
+		log.Printf("WARN: [PaycorTokenSource] Failed to write token state %s: %v", h.path, err)
+	}
+}
+
+// TokenStatus describes the Paycor credentials after a refresh attempt.
+type TokenStatus struct {
+	AccessTokenExpiry time.Time
+	Rotated           bool // The refresh token changed since the client was created; the new one must be saved
+	State             TokenState
+}
+
+// TokenStatus obtains an access token, refreshing it if needed, and reports its expiry along
+// with the recorded refresh history. The returned status is filled in even when the refresh fails.
+func (c *Client) TokenStatus() (*TokenStatus, error) {
+	token, err := c.tokens.Token()
+	status := &TokenStatus{
+		Rotated: c.tokens.lastRefreshToken != c.cfg.PaycorRefreshToken,
+		State:   c.tokens.health.snapshot(),
+	}
+	if err != nil {
+		return status, err
+	}
+	status.AccessTokenExpiry = token.Expiry
+	return status, nil
+}
@@ -0,0 +1,153 @@
+// internal/preflight/preflight.go
+
+// Package preflight runs read-only checks that the configured Paycor and Jira credentials
+// can do everything a sync needs, so permission problems surface before a run rather than midway.
+// SelfTest goes further and writes, then removes, a throwaway Employee asset.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/jira"
+	"github.com/Devon-ODell/PSDIv0.2/internal/paycor"
+)
+
+// Status is the outcome of a single check.
+type Status string
+
+const (
+	StatusPass Status = "PASS"
+	StatusFail Status = "FAIL"
+	StatusSkip Status = "SKIP"
+)
+
+// Result is the outcome of one check, with a remediation hint when it failed.
+type Result struct {
+	Name     string
+	Required bool
+	Status   Status
+	Detail   string
+	Hint     string
+}
+
+// Run performs every check and returns the results in order. Checks that depend on a client
+// that could not be created are reported as failed rather than skipped.
+func Run(ctx context.Context, cfg *config.AppConfig) []Result {
+	var results []Result
+	add := func(name string, required bool, hint string, err error, detail string) {
+		result := Result{Name: name, Required: required, Status: StatusPass, Detail: detail}
+		if err != nil {
+			result.Status = StatusFail
+			result.Detail = err.Error()
+			result.Hint = hint
+		}
+		results = append(results, result)
+	}
+	skip := func(name, detail string) {
+		results = append(results, Result{Name: name, Status: StatusSkip, Detail: detail})
+	}
+
+	// --- Paycor ---
+	paycorClient, err := paycor.NewClient(ctx, cfg.Paycor)
+	add("Paycor client configuration", true,
+		"Set PAYCOR_CLIENT_ID, PAYCOR_CLIENT_SECRET, PAYCOR_SUBSCRIPTION_KEY, PAYCOR_REFRESH_TOKEN, PAYCOR_TOKEN_BASE_URL and PAYCOR_BASE_URL.",
+		err, "")
+	if err == nil {
+		count, err := paycorClient.FetchFirstEmployeePage(ctx)
+		add("Paycor token refresh and employee fetch", true,
+			"Check the refresh token is current and that PAYCOR_SCOPES includes access to the employees endpoint for PAYCOR_LEGAL_ENTITY_ID.",
+			err, fmt.Sprintf("%d employees on the first page", count))
+	}
+
+	// --- Jira ---
+	jiraClient, err := jira.NewClient(cfg.Jira)
+	add("Jira client configuration", true,
+		"Check JIRA_DEPLOYMENT_TYPE, JIRA_ORG_DOMAIN, JIRA_WORKSPACE_ID and the credentials for JIRA_AUTH_MODE.",
+		err, "")
+	if err != nil {
+		return results
+	}
+
+	me, err := jiraClient.GetMyself(ctx)
+	detail := ""
+	if err == nil {
+		detail = "authenticated as " + firstNonEmpty(me.EmailAddress, me.DisplayName, me.Name, me.AccountID)
+	}
+	add("Jira authentication", true, "Check the Jira API token or bearer token is valid and not expired.", err, detail)
+
+	err = jiraClient.ValidateAttributeSchema(ctx)
+	add("Jira Assets schema read", true,
+		"Check the user has Assets access to JIRA_EMPLOYEE_OBJECT_TYPE_ID, and run cmd/schemaBootstrap to refresh the attribute IDs.",
+		err, "managed attributes match the schema")
+
+	if cfg.Jira.JiraSandboxObjectTypeID == "" {
+		skip("Jira Assets write access", "JIRA_SANDBOX_OBJECT_TYPE_ID is not set")
+	} else {
+		err = jiraClient.VerifyWriteAccess(ctx, cfg.Jira.JiraSandboxObjectTypeID)
+		add("Jira Assets write access", true,
+			"Grant the user the Object Schema Manager or Object Schema Users role with create and delete permission.",
+			err, "created and deleted a test object")
+	}
+
+	if cfg.Jira.JiraAssetObjectKeyCustomField == "" {
+		skip("Jira asset custom field", "JIRA_ASSET_OBJECT_KEY_CUSTOM_FIELD_ID is not set")
+	} else {
+		exists, err := jiraClient.FieldExists(ctx, cfg.Jira.JiraAssetObjectKeyCustomField)
+		if err == nil && !exists {
+			err = fmt.Errorf("field %s does not exist", cfg.Jira.JiraAssetObjectKeyCustomField)
+		}
+		add("Jira asset custom field", false,
+			"Check JIRA_ASSET_OBJECT_KEY_CUSTOM_FIELD_ID against Jira's field list. Only issue creation needs it.",
+			err, cfg.Jira.JiraAssetObjectKeyCustomField+" exists")
+	}
+
+	return results
+}
+
+// Passed reports whether every required check passed.
+func Passed(results []Result) bool {
+	for _, result := range results {
+		if result.Required && result.Status == StatusFail {
+			return false
+		}
+	}
+	return true
+}
+
+// PrintTable writes the results as a table, followed by the hints for failed checks.
+func PrintTable(w io.Writer, results []Result) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tREQUIRED\tSTATUS\tDETAIL")
+	for _, result := range results {
+		required := "no"
+		if result.Required {
+			required = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", result.Name, required, result.Status, singleLine(result.Detail))
+	}
+	tw.Flush()
+
+	for _, result := range results {
+		if result.Status == StatusFail && result.Hint != "" {
+			fmt.Fprintf(w, "\n%s: %s\n", result.Name, result.Hint)
+		}
+	}
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+// internal/preflight/selftest.go
+
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/jira"
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// SelfTest exercises the Employee object type end to end: it creates a throwaway asset, finds it
+// by AQL, updates its name, reads the change back and deletes it. This catches wrong attribute
+// IDs and missing Assets permissions in minutes rather than partway into a sync.
+//
+// Only the asset the test created is deleted, and only while it still carries the test's unique
+// email. If the create instead updated an existing asset (JIRA_CREATE_CONFLICT_FALLBACK), that
+// asset is left alone.
+func SelfTest(ctx context.Context, cfg *config.AppConfig) []Result {
+	var results []Result
+	add := func(name, hint string, err error, detail string) bool {
+		result := Result{Name: name, Required: true, Status: StatusPass, Detail: detail}
+		if err != nil {
+			result.Status = StatusFail
+			result.Detail = err.Error()
+			result.Hint = hint
+		}
+		results = append(results, result)
+		return err == nil
+	}
+	skip := func(names ...string) {
+		for _, name := range names {
+			results = append(results, Result{Name: name, Required: true, Status: StatusSkip, Detail: "an earlier step failed"})
+		}
+	}
+
+	jiraClient, err := jira.NewClient(cfg.Jira)
+	if !add("Jira client configuration",
+		"Check JIRA_DEPLOYMENT_TYPE, JIRA_ORG_DOMAIN, JIRA_WORKSPACE_ID and the credentials for JIRA_AUTH_MODE.",
+		err, "") {
+		return results
+	}
+
+	nameID, nameOK := models.AttributeID["Name"]
+	emailID, emailOK := models.AttributeID["Email"]
+	if !nameOK || !emailOK {
+		add("Attribute map", "Run cmd/schemaBootstrap and set JIRA_ATTRIBUTE_MAP_PATH to its output.",
+			fmt.Errorf("the attribute map has no 'Name' or 'Email' entry"), "")
+		return results
+	}
+
+	stamp := time.Now().UTC().Format("20060102T150405")
+	email := fmt.Sprintf("psdi-selftest-%s-%d@example.com", stamp, time.Now().UnixNano()%1000000)
+	name := "PSDI self-test " + stamp
+	asset := models.EmployeeAssets{Attributes: []models.AssetAttribute{
+		{ObjectTypeAttributeID: strconv.Itoa(nameID), Values: []models.Value{{Value: name}}},
+		{ObjectTypeAttributeID: strconv.Itoa(emailID), Values: []models.Value{{Value: email}}},
+	}}
+
+	// --- Create ---
+	created, outcome, err := jiraClient.CreateEmployeeAsset(ctx, asset)
+	if err == nil && outcome != jira.CreateOutcomeCreated {
+		err = fmt.Errorf("the create updated existing asset %s instead of creating one; it will not be deleted", created.ObjectKey)
+		created = nil
+	}
+	if !add("Create Employee asset",
+		"Check the Name and Email attribute IDs (cmd/schemaBootstrap) and that the user can create objects in JIRA_EMPLOYEE_OBJECT_TYPE_ID.",
+		err, describe(created)) {
+		skip("Find asset by AQL", "Update asset", "Verify update", "Delete asset")
+		return results
+	}
+
+	// --- Find ---
+	aql := fmt.Sprintf(`objectType = "%s" AND "Email" = "%s"`, cfg.Jira.JiraEmployeeObjectTypeName, email)
+	matches, _, err := jiraClient.FindObjectsByAQL(ctx, aql)
+	if err == nil && !containsObject(matches, created.ID) {
+		err = fmt.Errorf("AQL %s returned %d objects, none of them %s", aql, len(matches), created.ObjectKey)
+	}
+	found := add("Find asset by AQL",
+		"Check JIRA_EMPLOYEE_OBJECT_TYPE_NAME matches the object type's name and that the Email attribute is called 'Email'.",
+		err, "found "+created.ObjectKey)
+
+	// --- Update and verify ---
+	if found {
+		updatedName := name + " (updated)"
+		update := models.EmployeeAssets{Attributes: []models.AssetAttribute{
+			{ObjectTypeAttributeID: strconv.Itoa(nameID), Values: []models.Value{{Value: updatedName}}},
+		}}
+		err = jiraClient.UpdateEmployeeAsset(ctx, created.ID, update)
+		if add("Update asset", "Check the user can edit objects in JIRA_EMPLOYEE_OBJECT_TYPE_ID.", err, "set Name") {
+			object, err := jiraClient.GetObject(ctx, created.ID)
+			if err == nil && models.AttributeValue(object.Attributes, "Name") != updatedName {
+				err = fmt.Errorf("Name reads back as '%s', expected '%s'", models.AttributeValue(object.Attributes, "Name"), updatedName)
+			}
+			add("Verify update", "Check the Name attribute ID (cmd/schemaBootstrap); the update may have written a different attribute.",
+				err, "Name reads back as written")
+		} else {
+			skip("Verify update")
+		}
+	} else {
+		skip("Update asset", "Verify update")
+	}
+
+	// --- Delete, always attempted once something was created ---
+	add("Delete asset",
+		fmt.Sprintf("Check the user can delete objects in JIRA_EMPLOYEE_OBJECT_TYPE_ID, and remove %s by hand.", created.ObjectKey),
+		deleteTestObject(ctx, jiraClient, created, email), "deleted "+created.ObjectKey)
+	return results
+}
+
+// deleteTestObject deletes the self-test's asset after confirming it still carries the test's
+// email, then confirms it is gone.
+func deleteTestObject(ctx context.Context, jiraClient *jira.Client, created *models.EmployeeAssets, email string) error {
+	object, err := jiraClient.GetObject(ctx, created.ID)
+	if err != nil {
+		return fmt.Errorf("could not re-read %s before deleting it: %w", created.ObjectKey, err)
+	}
+	if got := models.AttributeValue(object.Attributes, "Email"); got != email {
+		return fmt.Errorf("refusing to delete %s: its Email is '%s', not the self-test's '%s'", created.ObjectKey, got, email)
+	}
+	if err := jiraClient.DeleteObject(ctx, created.ID); err != nil {
+		return err
+	}
+	_, err = jiraClient.GetObject(ctx, created.ID)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%s still exists after being deleted", created.ObjectKey)
+	case !jira.IsNotFound(err):
+		return fmt.Errorf("deleted %s but could not confirm it is gone: %w", created.ObjectKey, err)
+	}
+	return nil
+}
+
+func containsObject(objects []models.EmployeeAssets, objectID string) bool {
+	for _, object := range objects {
+		if object.ID == objectID {
+			return true
+		}
+	}
+	return false
+}
+
+func describe(object *models.EmployeeAssets) string {
+	if object == nil {
+		return ""
+	}
+	return fmt.Sprintf("created %s (ID %s)", object.ObjectKey, object.ID)
+}
@@ -0,0 +1,118 @@
+// internal/syncer/backfill.go
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// BackfillReport summarises a Paycor ID backfill.
+type BackfillReport struct {
+	DryRun     bool          `json:"dryRun"`
+	Backfilled []ReportEntry `json:"backfilled"`
+	NoMatch    []ReportEntry `json:"noMatch"`   // No Paycor employee has the asset's email
+	Ambiguous  []ReportEntry `json:"ambiguous"` // Several Paycor employees share the asset's email
+	Failed     []ReportEntry `json:"failed"`
+	Skipped    int           `json:"skipped"`  // Assets that already carry a Paycor ID
+	Excluded   int           `json:"excluded"` // Assets whose Paycor employee is outside SYNC_FILTER, left untouched
+}
+
+// BackfillPaycorIDs writes the Paycor employee ID onto every Employee asset that lacks one,
+// matching the asset to a Paycor employee by normalized email. Assets created before the ID was
+// stored can then be matched on it. Assets whose employee is outside SYNC_FILTER are left alone.
+// In a dry run the matches are only reported.
+func (s *Syncer) BackfillPaycorIDs(ctx context.Context) (*BackfillReport, error) {
+	if err := s.loadSchema(ctx); err != nil {
+		return nil, err
+	}
+	attributeName := s.cfg.Jira.JiraPaycorIDAttribute
+	attributeID, ok := s.schema.AttributeIDFor(attributeName)
+	if !ok {
+		return nil, fmt.Errorf("the Paycor ID attribute '%s' (JIRA_PAYCOR_ID_ATTRIBUTE) does not exist on the Employee object type", attributeName)
+	}
+
+	// The attribute is resolved from the discovered schema, so project it explicitly in case the
+	// attribute map doesn't have it; otherwise every asset would look like it lacks an ID.
+	assets, err := s.jiraClient.GetAllEmployeeAssets(ctx, attributeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee assets from Jira: %w", err)
+	}
+	employees, err := s.paycorClient.FetchAllEmployees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch employees from Paycor: %w", err)
+	}
+
+	byEmail := make(map[string][]models.Employee)
+	for _, emp := range employees {
+		if email := models.NormalizeEmail(emp.Email.EmailAddress, s.cfg.Features.StripEmailPlusTags); email != "" {
+			byEmail[email] = append(byEmail[email], emp)
+		}
+	}
+
+	report := &BackfillReport{DryRun: s.opts.DryRun}
+	for _, asset := range assets {
+		if s.schema.GetAttributeValue(asset, attributeName) != "" {
+			report.Skipped++
+			continue
+		}
+		email := s.schema.GetAttributeValue(asset, "Email")
+		entry := ReportEntry{Email: email, ObjectKey: asset.ObjectKey}
+
+		matches := byEmail[models.NormalizeEmail(email, s.cfg.Features.StripEmailPlusTags)]
+		switch {
+		case len(matches) == 0:
+			report.NoMatch = append(report.NoMatch, entry)
+			continue
+		case len(matches) > 1:
+			ids := make([]string, 0, len(matches))
+			for _, emp := range matches {
+				ids = append(ids, emp.ID)
+			}
+			entry.Detail = "Paycor employees " + strings.Join(ids, ", ")
+			report.Ambiguous = append(report.Ambiguous, entry)
+			continue
+		}
+
+		if !s.filter.matches(matches[0]) {
+			report.Excluded++
+			continue
+		}
+		entry.EmployeeID = matches[0].ID
+		if s.opts.DryRun {
+			log.Printf("INFO: DRY RUN: Would set %s on asset %s to %s.", attributeName, asset.ObjectKey, entry.EmployeeID)
+			report.Backfilled = append(report.Backfilled, entry)
+			continue
+		}
+		payload := models.EmployeeAssets{Attributes: []models.AssetAttribute{
+			{ObjectTypeAttributeID: attributeID, Values: []models.Value{{Value: entry.EmployeeID}}},
+		}}
+		if err := s.jiraClient.UpdateEmployeeAsset(ctx, asset.ID, payload); err != nil {
+			log.Printf("ERROR: Failed to backfill %s on asset %s: %v", attributeName, asset.ObjectKey, err)
+			entry.Detail = err.Error()
+			report.Failed = append(report.Failed, entry)
+			continue
+		}
+		report.Backfilled = append(report.Backfilled, entry)
+	}
+	return report, nil
+}
+
+// LogSummary writes the backfill counts and every asset that could not be backfilled to the log.
+func (r *BackfillReport) LogSummary() {
+	log.Printf("INFO: [Backfill] Paycor ID backfill (dryRun=%t): backfilled=%d noMatch=%d ambiguous=%d failed=%d alreadySet=%d excluded=%d",
+		r.DryRun, len(r.Backfilled), len(r.NoMatch), len(r.Ambiguous), len(r.Failed), r.Skipped, r.Excluded)
+	for _, entry := range r.NoMatch {
+		log.Printf("INFO: [Backfill] No Paycor match: asset %s (%s)", entry.ObjectKey, entry.Email)
+	}
+	for _, entry := range r.Ambiguous {
+		log.Printf("WARN: [Backfill] Ambiguous: asset %s (%s) matches %s", entry.ObjectKey, entry.Email, entry.Detail)
+	}
+	for _, entry := range r.Failed {
+		log.Printf("WARN: [Backfill] Failed: asset %s (%s): %s", entry.ObjectKey, entry.Email, entry.Detail)
+	}
+}
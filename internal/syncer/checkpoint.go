@@ -0,0 +1,132 @@
+// internal/syncer/checkpoint.go
+
+package syncer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// checkpoint records which employees a run has already written, so an interrupted run can be
+// resumed without repeating them. It is flushed to SYNC_CHECKPOINT_PATH every
+// SYNC_CHECKPOINT_EVERY employees and removed once a run completes.
+type checkpoint struct {
+	RunStartedAt time.Time `json:"runStartedAt"`
+	Target       string    `json:"target,omitempty"` // Named Jira target the run wrote to; a run against another target can't resume it
+	UpdatedAt    time.Time `json:"updatedAt"`
+
+	// Completed maps employee ID to the content hash of the Paycor record that was synced.
+	// An employee whose record changed since is synced again on resume.
+	Completed map[string]string `json:"completed"`
+
+	path    string
+	pending int // employees completed since the last flush
+}
+
+// newCheckpoint starts an empty checkpoint for a run against target.
+func newCheckpoint(path, target string, startedAt time.Time) *checkpoint {
+	return &checkpoint{RunStartedAt: startedAt, Target: target, Completed: make(map[string]string), path: path}
+}
+
+// loadCheckpoint reads the checkpoint left by an interrupted run. It returns nil with no error
+// when there is nothing to resume.
+func loadCheckpoint(path string) (*checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", path, err)
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	if cp.Completed == nil {
+		cp.Completed = make(map[string]string)
+	}
+	cp.path = path
+	return &cp, nil
+}
+
+// done reports whether the employee was completed with identical Paycor data.
+func (cp *checkpoint) done(emp models.Employee) bool {
+	hash, ok := cp.Completed[emp.ID]
+	return ok && hash == employeeHash(emp)
+}
+
+// complete marks an employee as written and flushes the checkpoint every flushEvery employees.
+func (cp *checkpoint) complete(emp models.Employee, flushEvery int) {
+	cp.Completed[emp.ID] = employeeHash(emp)
+	cp.pending++
+	if flushEvery > 0 && cp.pending >= flushEvery {
+		cp.flush()
+	}
+}
+
+// flush writes the checkpoint atomically. Failures are logged, not returned: losing a
+// checkpoint only costs repeated work on resume.
+func (cp *checkpoint) flush() {
+	cp.UpdatedAt = time.Now()
+	if err := writeJSONFile(cp.path, cp); err != nil {
+		log.Printf("WARN: Failed to write sync checkpoint %s: %v", cp.path, err)
+		return
+	}
+	cp.pending = 0
+	log.Printf("INFO: Checkpoint saved: %d employees completed.", len(cp.Completed))
+}
+
+// writeJSONFile writes v as indented JSON via a temporary file and a rename, so a reader never
+// sees a partly written file.
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", path, err)
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic writes data via a temporary file and a rename, as writeJSONFile does.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(tmp.Name())
+		return errors.Join(writeErr, closeErr)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// remove deletes the checkpoint file after a run completes.
+func (cp *checkpoint) remove() {
+	if err := os.Remove(cp.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("WARN: Failed to remove sync checkpoint %s: %v", cp.path, err)
+	}
+}
+
+// employeeHash is a content hash of a Paycor employee record.
+func employeeHash(emp models.Employee) string {
+	data, err := json.Marshal(emp)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,251 @@
+// internal/syncer/coerce.go
+
+package syncer
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// valueConverter turns one mapped value into the string Jira Assets expects for an attribute type.
+// present is false when the value is empty and should be left out of the payload.
+type valueConverter func(value string) (converted string, present bool, err error)
+
+// coerceAttributes converts every attribute value to the type its attribute has in the schema:
+// dates are reformatted, numbers are written without grouping or exponents, booleans become
+// "true"/"false" and object references must be object keys. Attributes not in the schema, and
+// text-like attributes, are passed through unchanged.
+// Empty values are omitted, and attributes with a value that can't be converted are omitted
+// entirely and returned as mapping problems. Paycor dates without an offset are read in loc.
+func coerceAttributes(attributes []models.AssetAttribute, schema *models.AttributeSchema, loc *time.Location) ([]models.AssetAttribute, []string) {
+	var kept []models.AssetAttribute
+	var problems []string
+
+	for _, attr := range attributes {
+		definition, ok := schema.Attribute(attr.ObjectTypeAttributeID)
+		if !ok {
+			kept = append(kept, attr)
+			continue
+		}
+		convert := converterFor(definition, loc)
+		if convert == nil {
+			kept = append(kept, attr)
+			continue
+		}
+
+		var values []models.Value
+		var invalid bool
+		for _, value := range attr.Values {
+			converted, present, err := convert(value.Value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", definition.Name, err))
+				invalid = true
+				break
+			}
+			if present {
+				values = append(values, models.Value{Value: converted})
+			}
+		}
+		if invalid || len(values) == 0 {
+			continue
+		}
+		attr.Values = values
+		kept = append(kept, attr)
+	}
+	return kept, problems
+}
+
+// converterFor returns the converter for an attribute definition, or nil if values are sent as is.
+func converterFor(definition models.ObjectTypeAttribute, loc *time.Location) valueConverter {
+	if definition.Type == models.AttributeTypeObject {
+		return toObjectKey
+	}
+	if definition.Type != models.AttributeTypeDefault || definition.DefaultType == nil {
+		return nil
+	}
+	switch definition.DefaultType.ID {
+	case models.DefaultTypeDate:
+		return dateConverter(models.JiraAssetsDateFormat, loc)
+	case models.DefaultTypeDateTime:
+		return dateConverter(models.JiraAssetsDateTimeFormat, loc)
+	case models.DefaultTypeInteger:
+		return toInteger
+	case models.DefaultTypeDouble:
+		return toDouble
+	case models.DefaultTypeBoolean:
+		return toBoolean
+	}
+	return nil
+}
+
+// dateConverter parses a Paycor date, in loc when it has no offset, and formats it with layout.
+func dateConverter(layout string, loc *time.Location) valueConverter {
+	return func(value string) (string, bool, error) {
+		parsed, present, err := models.ParsePaycorDate(value, loc)
+		if err != nil || !present {
+			return "", false, err
+		}
+		return parsed.Format(layout), true, nil
+	}
+}
+
+// groupedNumber matches numbers written with comma thousands separators, e.g. "1,234.5".
+var groupedNumber = regexp.MustCompile(`^[-+]?\d{1,3}(,\d{3})+(\.\d+)?$`)
+
+// parseNumber parses a decimal number, accepting comma thousands separators.
+func parseNumber(value string) (float64, error) {
+	if groupedNumber.MatchString(value) {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("'%s' is not a number", value)
+	}
+	return number, nil
+}
+
+func toInteger(value string) (string, bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", false, nil
+	}
+	number, err := parseNumber(value)
+	if err != nil {
+		return "", false, err
+	}
+	if number != math.Trunc(number) {
+		return "", false, fmt.Errorf("'%s' is not a whole number", value)
+	}
+	return strconv.FormatInt(int64(number), 10), true, nil
+}
+
+func toDouble(value string) (string, bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", false, nil
+	}
+	number, err := parseNumber(value)
+	if err != nil {
+		return "", false, err
+	}
+	return strconv.FormatFloat(number, 'f', -1, 64), true, nil
+}
+
+func toBoolean(value string) (string, bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return "", false, nil
+	case "true", "yes", "y", "1":
+		return "true", true, nil
+	case "false", "no", "n", "0":
+		return "false", true, nil
+	}
+	return "", false, fmt.Errorf("'%s' is not a boolean", value)
+}
+
+// objectKeyPattern matches Assets object keys such as "HR-123".
+var objectKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*-\d+$`)
+
+func toObjectKey(value string) (string, bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", false, nil
+	}
+	// Dry runs stand in "<new role: X>" for objects they would create; those are never written.
+	if strings.HasPrefix(value, "<") && strings.HasSuffix(value, ">") {
+		return value, true, nil
+	}
+	if !objectKeyPattern.MatchString(value) {
+		return "", false, fmt.Errorf("'%s' is not an object key", value)
+	}
+	return value, true, nil
+}
+
+// sourceKind describes what kind of value the mapping produces for an attribute.
+type sourceKind string
+
+const (
+	sourceText      sourceKind = "text"
+	sourceDate      sourceKind = "date"
+	sourceObjectKey sourceKind = "object key"
+)
+
+// mappedSourceKinds returns the kind of value mapPaycorToJiraAsset and the rehire handling
+// write to each attribute, keyed by attribute name.
+func (s *Syncer) mappedSourceKinds() map[string]sourceKind {
+	kinds := map[string]sourceKind{
+		"Name":         sourceText,
+		"Email":        sourceText,
+		"Start Date":   sourceDate,
+		"Status":       sourceText,
+		"Job Role":     sourceObjectKey,
+		"Location":     sourceObjectKey,
+		"Manager Name": sourceText,
+	}
+	if name := s.cfg.Jira.JiraRehireDateAttribute; name != "" {
+		kinds[name] = sourceDate
+	}
+	return kinds
+}
+
+// checkSourceKinds rejects a schema where a mapped attribute has a type its source value
+// can never convert to, such as a text field mapped onto an Integer attribute.
+func (s *Syncer) checkSourceKinds() error {
+	var problems []string
+	for name, kind := range s.mappedSourceKinds() {
+		id, ok := s.schema.AttributeIDFor(name)
+		if !ok {
+			continue
+		}
+		definition, ok := s.schema.Attribute(id)
+		if !ok {
+			continue
+		}
+		if !sourceCompatible(kind, definition) {
+			problems = append(problems, fmt.Sprintf("'%s' is a %s attribute but is mapped from a %s value", name, models.AttributeTypeName(definition), kind))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("attribute mapping is incompatible with the Jira schema:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// sourceCompatible reports whether a value of the given kind can be written to an attribute.
+// Text attributes accept anything.
+func sourceCompatible(kind sourceKind, definition models.ObjectTypeAttribute) bool {
+	defaultType := models.DefaultTypeText
+	if definition.DefaultType != nil {
+		defaultType = definition.DefaultType.ID
+	}
+	isText := definition.Type == models.AttributeTypeDefault && defaultType == models.DefaultTypeText
+
+	switch kind {
+	case sourceDate:
+		return isText || (definition.Type == models.AttributeTypeDefault &&
+			(defaultType == models.DefaultTypeDate || defaultType == models.DefaultTypeDateTime))
+	case sourceObjectKey:
+		return isText || definition.Type == models.AttributeTypeObject
+	default:
+		if definition.Type == models.AttributeTypeStatus {
+			return true
+		}
+		if definition.Type != models.AttributeTypeDefault {
+			return false
+		}
+		switch defaultType {
+		case models.DefaultTypeInteger, models.DefaultTypeDouble, models.DefaultTypeBoolean,
+			models.DefaultTypeDate, models.DefaultTypeDateTime:
+			return false
+		}
+		return true
+	}
+}
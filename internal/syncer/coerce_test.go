@@ -0,0 +1,159 @@
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// typedSchema has one Employee attribute of each kind the coercion handles.
+var typedSchema = []models.ObjectTypeAttribute{
+	{ID: "82", Name: "Name", Type: models.AttributeTypeDefault, DefaultType: &models.DefaultType{ID: models.DefaultTypeText, Name: "Text"}},
+	{ID: "89", Name: "Email", Type: models.AttributeTypeDefault, DefaultType: &models.DefaultType{ID: 7, Name: "Email"}},
+	{ID: "91", Name: "Start Date", Type: models.AttributeTypeDefault, DefaultType: &models.DefaultType{ID: models.DefaultTypeDate, Name: "Date"}},
+	{ID: "92", Name: "Status", Type: models.AttributeTypeStatus},
+	{ID: "87", Name: "Job Role", Type: models.AttributeTypeObject},
+	{ID: "86", Name: "Manager Name", Type: models.AttributeTypeDefault, DefaultType: &models.DefaultType{ID: models.DefaultTypeText, Name: "Text"}},
+	{ID: "101", Name: "Last Synced", Type: models.AttributeTypeDefault, DefaultType: &models.DefaultType{ID: models.DefaultTypeDateTime, Name: "DateTime"}},
+	{ID: "102", Name: "Headcount", Type: models.AttributeTypeDefault, DefaultType: &models.DefaultType{ID: models.DefaultTypeInteger, Name: "Integer"}},
+	{ID: "103", Name: "FTE", Type: models.AttributeTypeDefault, DefaultType: &models.DefaultType{ID: models.DefaultTypeDouble, Name: "Double"}},
+	{ID: "104", Name: "Remote", Type: models.AttributeTypeDefault, DefaultType: &models.DefaultType{ID: models.DefaultTypeBoolean, Name: "Boolean"}},
+	{ID: "105", Name: "Shift", Type: models.AttributeTypeDefault, DefaultType: &models.DefaultType{ID: models.DefaultTypeSelect, Name: "Select"}, Options: "Day,Night"},
+}
+
+// serveSchema returns a fake Jira that serves attributes as the Employee object type's schema.
+func serveSchema(attributes []models.ObjectTypeAttribute) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /objecttype/7/attributes", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(attributes)
+	})
+	return mux
+}
+
+func TestCoerceAttributesPerType(t *testing.T) {
+	s := newFakeJiraSyncer(t, serveSchema(typedSchema))
+	if err := s.loadSchema(context.Background()); err != nil {
+		t.Fatalf("loadSchema: %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		attributeID string
+		values      []string
+		want        []string // nil when the attribute is left out
+		wantProblem string
+	}{
+		{name: "text passes through", attributeID: "82", values: []string{" Jane  Doe "}, want: []string{" Jane  Doe "}},
+		{name: "email passes through", attributeID: "89", values: []string{"Jane@Example.com"}, want: []string{"Jane@Example.com"}},
+		{name: "status passes through", attributeID: "92", values: []string{"Active"}, want: []string{"Active"}},
+		{name: "select passes through", attributeID: "105", values: []string{"Night"}, want: []string{"Night"}},
+		{name: "date from a timestamp", attributeID: "91", values: []string{"2023-02-13T00:00:00"}, want: []string{"2023-02-13"}},
+		{name: "date from US format", attributeID: "91", values: []string{"2/13/2023"}, want: []string{"2023-02-13"}},
+		{name: "unset date is left out", attributeID: "91", values: []string{"0001-01-01T00:00:00"}},
+		{name: "unreadable date", attributeID: "91", values: []string{"next week"}, wantProblem: "Start Date: unrecognised date format"},
+		{name: "datetime in the Paycor timezone", attributeID: "101", values: []string{"2024-07-01T09:00:00"}, want: []string{"2024-07-01T09:00:00.000-04:00"}},
+		{name: "datetime keeps an explicit offset", attributeID: "101", values: []string{"2024-07-01T09:00:00Z"}, want: []string{"2024-07-01T09:00:00.000Z"}},
+		{name: "integer", attributeID: "102", values: []string{" 42 "}, want: []string{"42"}},
+		{name: "integer with grouping", attributeID: "102", values: []string{"1,234"}, want: []string{"1234"}},
+		{name: "integer written as a whole float", attributeID: "102", values: []string{"7.0"}, want: []string{"7"}},
+		{name: "fractional integer", attributeID: "102", values: []string{"7.5"}, wantProblem: "Headcount: '7.5' is not a whole number"},
+		{name: "integer that isn't a number", attributeID: "102", values: []string{"many"}, wantProblem: "Headcount: 'many' is not a number"},
+		{name: "double", attributeID: "103", values: []string{"91.50"}, want: []string{"91.5"}},
+		{name: "double without an exponent", attributeID: "103", values: []string{"1e6"}, want: []string{"1000000"}},
+		{name: "double with grouping", attributeID: "103", values: []string{"1,234.5"}, want: []string{"1234.5"}},
+		{name: "double rejects NaN", attributeID: "103", values: []string{"NaN"}, wantProblem: "FTE: 'NaN' is not a number"},
+		{name: "boolean true", attributeID: "104", values: []string{"Yes"}, want: []string{"true"}},
+		{name: "boolean false", attributeID: "104", values: []string{"0"}, want: []string{"false"}},
+		{name: "boolean that isn't one", attributeID: "104", values: []string{"sometimes"}, wantProblem: "Remote: 'sometimes' is not a boolean"},
+		{name: "object key", attributeID: "87", values: []string{" ROLE-88 "}, want: []string{"ROLE-88"}},
+		{name: "dry run placeholder", attributeID: "87", values: []string{"<new role: Engineer>"}, want: []string{"<new role: Engineer>"}},
+		{name: "object name instead of a key", attributeID: "87", values: []string{"Engineer"}, wantProblem: "Job Role: 'Engineer' is not an object key"},
+		{name: "empty values are dropped", attributeID: "102", values: []string{"", "3", " "}, want: []string{"3"}},
+		{name: "only empty values", attributeID: "104", values: []string{""}},
+		{name: "attribute not in the schema", attributeID: "999", values: []string{"anything"}, want: []string{"anything"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr := models.AssetAttribute{ObjectTypeAttributeID: tt.attributeID}
+			for _, value := range tt.values {
+				attr.Values = append(attr.Values, models.Value{Value: value})
+			}
+			kept, problems := coerceAttributes([]models.AssetAttribute{attr}, s.schema, newYork)
+
+			if tt.wantProblem != "" {
+				if len(problems) != 1 || !strings.HasPrefix(problems[0], tt.wantProblem) {
+					t.Errorf("problems = %q, want one starting %q", problems, tt.wantProblem)
+				}
+				if len(kept) != 0 {
+					t.Errorf("kept %+v, want the attribute left out", kept)
+				}
+				return
+			}
+			if len(problems) != 0 {
+				t.Errorf("problems = %q, want none", problems)
+			}
+			var got []string
+			for _, a := range kept {
+				for _, value := range a.Values {
+					got = append(got, value.Value)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("coerced values = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSchemaRejectsIncompatibleMapping(t *testing.T) {
+	tests := []struct {
+		name      string
+		attribute models.ObjectTypeAttribute
+		wantType  string
+	}{
+		{
+			name:      "text onto an integer",
+			attribute: models.ObjectTypeAttribute{ID: "82", Name: "Name", Type: models.AttributeTypeDefault, DefaultType: &models.DefaultType{ID: models.DefaultTypeInteger, Name: "Integer"}},
+			wantType:  "Integer",
+		},
+		{
+			name:      "date onto a boolean",
+			attribute: models.ObjectTypeAttribute{ID: "91", Name: "Start Date", Type: models.AttributeTypeDefault, DefaultType: &models.DefaultType{ID: models.DefaultTypeBoolean, Name: "Boolean"}},
+			wantType:  "Boolean",
+		},
+		{
+			name:      "object key onto a user",
+			attribute: models.ObjectTypeAttribute{ID: "87", Name: "Job Role", Type: models.AttributeTypeUser},
+			wantType:  "User",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := []models.ObjectTypeAttribute{tt.attribute}
+			for _, attribute := range typedSchema {
+				if attribute.Name != tt.attribute.Name {
+					schema = append(schema, attribute)
+				}
+			}
+			s := newFakeJiraSyncer(t, serveSchema(schema))
+			err := s.loadSchema(context.Background())
+			if err == nil {
+				t.Fatal("loadSchema accepted the schema")
+			}
+			if name := "'" + tt.attribute.Name + "'"; !strings.Contains(err.Error(), name) || !strings.Contains(err.Error(), tt.wantType) {
+				t.Errorf("loadSchema error = %q, want it to name %s and its %s type", err, name, tt.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// internal/syncer/comments.go
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// changeComment is a comment explaining a significant change, waiting to be posted on an asset.
+type changeComment struct {
+	objectID  string
+	objectKey string
+	text      string
+}
+
+// queueChangeComment queues a comment on the asset when the update changed an attribute listed
+// in JIRA_CHANGE_COMMENT_ATTRIBUTES. Comments are posted once the run knows how many assets
+// changed; see postChangeComments.
+func (s *Syncer) queueChangeComment(objectID, objectKey, runID string, changes []AttributeChange) {
+	if !s.cfg.Jira.JiraChangeComments {
+		return
+	}
+	var significant []string
+	for _, change := range changes {
+		for _, name := range s.cfg.Jira.JiraChangeCommentAttributes {
+			if strings.EqualFold(change.Attribute, name) {
+				significant = append(significant, fmt.Sprintf("%s '%s' → '%s'", change.Attribute, strings.Join(change.Old, ", "), strings.Join(change.New, ", ")))
+				break
+			}
+		}
+	}
+	if len(significant) == 0 {
+		return
+	}
+	s.comments = append(s.comments, changeComment{
+		objectID:  objectID,
+		objectKey: objectKey,
+		text:      fmt.Sprintf("PSDI sync run %s: %s from Paycor", runID, strings.Join(significant, "; ")),
+	})
+}
+
+// postChangeComments posts the queued comments, unless more assets changed than
+// JIRA_CHANGE_COMMENT_MAX_OBJECTS allows, in which case none are posted. Failures are logged only.
+func (s *Syncer) postChangeComments(ctx context.Context, report *SyncReport) {
+	comments := s.comments
+	s.comments = nil
+	if len(comments) == 0 {
+		return
+	}
+	if limit := s.cfg.Jira.JiraChangeCommentMaxObjects; limit > 0 && len(comments) > limit {
+		log.Printf("WARN: %d assets had significant changes, more than JIRA_CHANGE_COMMENT_MAX_OBJECTS (%d). No change comments will be posted for this run.", len(comments), limit)
+		report.ChangeCommentsSkipped = len(comments)
+		return
+	}
+	for _, comment := range comments {
+		if err := s.jiraClient.AddObjectComment(ctx, comment.objectID, comment.text); err != nil {
+			log.Printf("WARN: Failed to comment on asset %s: %v", comment.objectKey, err)
+			continue
+		}
+		report.ChangeComments++
+	}
+	log.Printf("INFO: Posted %d of %d change comments.", report.ChangeComments, len(comments))
+}
@@ -0,0 +1,118 @@
+// internal/syncer/debugbundle.go
+
+package syncer
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/jira"
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// DebugBundle is everything captured while syncing one employee named in SYNC_DEBUG_EMAILS: the
+// outcome, the computed diff and every Assets API exchange. Payloads pass through the same
+// redaction as logged ones, and the diff through SYNC_REDACT_ATTRIBUTES.
+type DebugBundle struct {
+	RunID       string              `json:"runId"`
+	EmployeeID  string              `json:"employeeId"`
+	Email       string              `json:"email"`
+	GeneratedAt time.Time           `json:"generatedAt"`
+	DryRun      bool                `json:"dryRun"`
+	Outcome     string              `json:"outcome"` // created, updated, unchanged, rehired or failed
+	ObjectKey   string              `json:"objectKey,omitempty"`
+	Detail      string              `json:"detail,omitempty"`
+	Changes     []AttributeChange   `json:"changes,omitempty"`
+	Errors      []EmployeeSyncError `json:"errors,omitempty"`
+	Exchanges   []jira.Exchange     `json:"exchanges"`
+}
+
+// debugCapture is a capture in progress for one employee.
+type debugCapture struct {
+	emp     models.Employee
+	capture *jira.Capture
+}
+
+// startDebugCapture returns a context that captures the employee's Jira requests when their
+// email is in SYNC_DEBUG_EMAILS, and the capture to finish; otherwise ctx and nil.
+func (s *Syncer) startDebugCapture(ctx context.Context, emp models.Employee) (context.Context, *debugCapture) {
+	email := models.NormalizeEmail(emp.Email.EmailAddress, s.cfg.Features.StripEmailPlusTags)
+	if email == "" {
+		return ctx, nil
+	}
+	for _, debugEmail := range s.cfg.SyncDebugEmails {
+		if models.NormalizeEmail(debugEmail, s.cfg.Features.StripEmailPlusTags) == email {
+			log.Printf("INFO: Capturing Jira requests for employee %s (%s) to a debug bundle.", emp.ID, emp.Email.EmailAddress)
+			debug := &debugCapture{emp: emp, capture: &jira.Capture{}}
+			return jira.WithCapture(ctx, debug.capture), debug
+		}
+	}
+	return ctx, nil
+}
+
+// finishDebugCapture writes the employee's debug bundle to SYNC_DEBUG_DIR and lists it in the
+// report's DebugBundles. Failures are logged only.
+func (s *Syncer) finishDebugCapture(debug *debugCapture, report *SyncReport) {
+	if debug == nil {
+		return
+	}
+	emp := debug.emp
+	bundle := DebugBundle{
+		RunID:       report.RunID,
+		EmployeeID:  emp.ID,
+		Email:       emp.Email.EmailAddress,
+		GeneratedAt: time.Now().UTC(),
+		DryRun:      report.DryRun,
+		Exchanges:   debug.capture.Exchanges(),
+	}
+	if entry, outcome, ok := report.entryFor(emp.ID); ok {
+		bundle.Outcome, bundle.ObjectKey, bundle.Detail = outcome, entry.ObjectKey, entry.Detail
+		bundle.Changes = redactChanges(entry.Changes, s.cfg.SyncRedactAttributes)
+	}
+	for _, syncErr := range report.Errors {
+		if syncErr.EmployeeID == emp.ID {
+			bundle.Errors = append(bundle.Errors, syncErr)
+		}
+	}
+
+	path := filepath.Join(s.cfg.SyncDebugDir, report.RunID+"-"+unsafeFileChars.ReplaceAllString(emp.ID, "_")+".json")
+	if err := os.MkdirAll(s.cfg.SyncDebugDir, 0755); err != nil {
+		log.Printf("WARN: Failed to create debug bundle directory %s: %v", s.cfg.SyncDebugDir, err)
+		return
+	}
+	if err := writeJSONFile(path, bundle); err != nil {
+		log.Printf("WARN: Failed to write debug bundle %s: %v", path, err)
+		return
+	}
+	log.Printf("INFO: Debug bundle for employee %s written to %s (%d Jira requests).", emp.ID, path, len(bundle.Exchanges))
+	report.DebugBundles = append(report.DebugBundles, ReportEntry{EmployeeID: emp.ID, Email: emp.Email.EmailAddress, ObjectKey: bundle.ObjectKey, Detail: path})
+}
+
+// unsafeFileChars matches characters kept out of debug bundle file names.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// entryFor returns the employee's latest entry in the outcome lists, and which list it is in.
+func (r *SyncReport) entryFor(employeeID string) (ReportEntry, string, bool) {
+	lists := []struct {
+		outcome string
+		entries []ReportEntry
+	}{
+		{"failed", r.Failed},
+		{"rehired", r.Rehired},
+		{"created", r.Created},
+		{"updated", r.Updated},
+		{"unchanged", r.Unchanged},
+	}
+	for _, list := range lists {
+		for i := len(list.entries) - 1; i >= 0; i-- {
+			if list.entries[i].EmployeeID == employeeID {
+				return list.entries[i], list.outcome, true
+			}
+		}
+	}
+	return ReportEntry{}, "", false
+}
@@ -0,0 +1,193 @@
+// internal/syncer/diff.go
+
+package syncer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// AttributeChange is a single attribute whose Jira value differs from the mapped Paycor value.
+type AttributeChange struct {
+	Attribute string   `json:"attribute"`
+	Old       []string `json:"old"`
+	New       []string `json:"new"`
+}
+
+// String renders the change as "Attribute: old → new" for logs and dry-run output.
+func (c AttributeChange) String() string {
+	return fmt.Sprintf("%s: %s → %s", c.Attribute, formatValues(c.Old), formatValues(c.New))
+}
+
+// diffAttributes compares the desired attributes against the existing asset and returns the changes.
+// When fields is non-empty only attributes named in it are compared.
+func diffAttributes(existing, desired []models.AssetAttribute, fields map[string]bool) []AttributeChange {
+	existingByID := make(map[string][]string, len(existing))
+	for _, attr := range existing {
+		existingByID[attr.ObjectTypeAttributeID] = attributeStrings(attr)
+	}
+
+	var changes []AttributeChange
+	for _, attr := range desired {
+		name := attributeName(attr.ObjectTypeAttributeID)
+		if len(fields) > 0 && !fields[name] {
+			continue
+		}
+		oldValues := existingByID[attr.ObjectTypeAttributeID]
+		newValues := attributeStrings(attr)
+		if !equalValues(oldValues, newValues) {
+			changes = append(changes, AttributeChange{Attribute: name, Old: oldValues, New: newValues})
+		}
+	}
+	return changes
+}
+
+// withoutAttribute returns attributes with the named attribute removed.
+func withoutAttribute(attributes []models.AssetAttribute, name string) []models.AssetAttribute {
+	var kept []models.AssetAttribute
+	for _, attr := range attributes {
+		if attributeName(attr.ObjectTypeAttributeID) != name {
+			kept = append(kept, attr)
+		}
+	}
+	return kept
+}
+
+// applyWritePolicies removes the attributes an update may not write under their write policy
+// (see config.JiraWritePolicies) and returns the changes that were suppressed as a result.
+// Create-only attributes are never updated; if-empty attributes only while the asset has no value.
+func applyWritePolicies(existing, desired []models.AssetAttribute, policies map[string]string) ([]models.AssetAttribute, []AttributeChange) {
+	if len(policies) == 0 {
+		return desired, nil
+	}
+	existingByID := make(map[string][]string, len(existing))
+	for _, attr := range existing {
+		existingByID[attr.ObjectTypeAttributeID] = attributeStrings(attr)
+	}
+
+	var allowed []models.AssetAttribute
+	var suppressed []AttributeChange
+	for _, attr := range desired {
+		name := attributeName(attr.ObjectTypeAttributeID)
+		oldValues := existingByID[attr.ObjectTypeAttributeID]
+		blocked := false
+		switch policies[name] {
+		case config.WritePolicyCreateOnly:
+			blocked = true
+		case config.WritePolicyIfEmpty:
+			blocked = len(oldValues) > 0
+		}
+		if !blocked {
+			allowed = append(allowed, attr)
+			continue
+		}
+		if newValues := attributeStrings(attr); !equalValues(oldValues, newValues) {
+			suppressed = append(suppressed, AttributeChange{Attribute: name, Old: oldValues, New: newValues})
+		}
+	}
+	return allowed, suppressed
+}
+
+// filterChanges returns only the changes to attributes named in fields, or all of them if fields is empty.
+func filterChanges(changes []AttributeChange, fields map[string]bool) []AttributeChange {
+	if len(fields) == 0 {
+		return changes
+	}
+	var filtered []AttributeChange
+	for _, change := range changes {
+		if fields[change.Attribute] {
+			filtered = append(filtered, change)
+		}
+	}
+	return filtered
+}
+
+// filterAttributes returns only the attributes named in fields, or all of them if fields is empty.
+func filterAttributes(attributes []models.AssetAttribute, fields map[string]bool) []models.AssetAttribute {
+	if len(fields) == 0 {
+		return attributes
+	}
+	var filtered []models.AssetAttribute
+	for _, attr := range attributes {
+		if fields[attributeName(attr.ObjectTypeAttributeID)] {
+			filtered = append(filtered, attr)
+		}
+	}
+	return filtered
+}
+
+// mergeAttributes returns the existing asset with the updated attributes and label applied,
+// so the in-memory copy matches what was written to Jira.
+func mergeAttributes(existing, updated models.EmployeeAssets) models.EmployeeAssets {
+	merged := existing
+	merged.Attributes = append([]models.AssetAttribute{}, existing.Attributes...)
+	if updated.Label != "" {
+		merged.Label = updated.Label
+	}
+	for _, attr := range updated.Attributes {
+		replaced := false
+		for i := range merged.Attributes {
+			if merged.Attributes[i].ObjectTypeAttributeID == attr.ObjectTypeAttributeID {
+				merged.Attributes[i] = attr
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged.Attributes = append(merged.Attributes, attr)
+		}
+	}
+	return merged
+}
+
+// attributeStrings returns the non-empty resolved values of an attribute. Object references read
+// back from Jira resolve to the referenced object's key, so they compare equal to the key the
+// mapping writes.
+func attributeStrings(attr models.AssetAttribute) []string {
+	var values []string
+	for _, value := range attr.Values {
+		if resolved := value.Resolved(); resolved != "" {
+			values = append(values, resolved)
+		}
+	}
+	return values
+}
+
+// equalValues compares two value lists as sets, so a multi-value attribute whose values Jira
+// returns in a different order, or with a repeat, is not a change.
+func equalValues(a, b []string) bool {
+	inA := make(map[string]bool, len(a))
+	for _, value := range a {
+		inA[value] = true
+	}
+	inB := make(map[string]bool, len(b))
+	for _, value := range b {
+		if !inA[value] {
+			return false
+		}
+		inB[value] = true
+	}
+	return len(inA) == len(inB)
+}
+
+// formatValues renders a value list for display, using "(empty)" for no values.
+func formatValues(values []string) string {
+	if len(values) == 0 {
+		return "(empty)"
+	}
+	return strings.Join(values, ", ")
+}
+
+// attributeName resolves an attribute ID back to its name in models.AttributeID, or returns the ID itself.
+func attributeName(attributeID string) string {
+	for name, id := range models.AttributeID {
+		if strconv.Itoa(id) == attributeID {
+			return name
+		}
+	}
+	return attributeID
+}
@@ -0,0 +1,146 @@
+// internal/syncer/dryrundiff.go
+
+package syncer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/jira"
+)
+
+// redactedValue replaces the values of SYNC_REDACT_ATTRIBUTES in the dry-run diff.
+const redactedValue = "[REDACTED]"
+
+// Actions in the dry-run diff.
+const (
+	DiffActionCreate = "create"
+	DiffActionUpdate = "update"
+	DiffActionRehire = "rehire"
+)
+
+// DryRunDiff lists, per employee, every attribute a dry run would change with its old and new
+// values. It is meant to be attached to a change ticket and reviewed before writes are enabled.
+type DryRunDiff struct {
+	RunID       string         `json:"runId"`
+	Target      string         `json:"target,omitempty"`
+	GeneratedAt time.Time      `json:"generatedAt"`
+	Fields      []string       `json:"fields,omitempty"` // Attributes updates were restricted to; empty means all
+	Employees   []EmployeeDiff `json:"employees"`
+}
+
+// EmployeeDiff is one employee's pending changes. A create lists every attribute it would set.
+type EmployeeDiff struct {
+	EmployeeID string            `json:"employeeId"`
+	Email      string            `json:"email"`
+	ObjectKey  string            `json:"objectKey,omitempty"` // Empty for creates
+	Action     string            `json:"action"`
+	Changes    []AttributeChange `json:"changes"`
+}
+
+// DryRunDiff builds the diff from the report's created, updated and rehired employees, with
+// values redacted as by redactChanges.
+func (r *SyncReport) DryRunDiff(redact []string) *DryRunDiff {
+	diff := &DryRunDiff{RunID: r.RunID, Target: r.Target, GeneratedAt: time.Now(), Fields: r.Fields}
+	add := func(action string, entries []ReportEntry) {
+		for _, entry := range entries {
+			if len(entry.Changes) == 0 {
+				continue
+			}
+			diff.Employees = append(diff.Employees, EmployeeDiff{
+				EmployeeID: entry.EmployeeID,
+				Email:      entry.Email,
+				ObjectKey:  entry.ObjectKey,
+				Action:     action,
+				Changes:    redactChanges(entry.Changes, redact),
+			})
+		}
+	}
+	add(DiffActionCreate, r.Created)
+	add(DiffActionUpdate, r.Updated)
+	add(DiffActionRehire, r.Rehired)
+	return diff
+}
+
+// redactChanges returns a copy of changes with the values of the attributes named in redact,
+// matched case-insensitively, and of attributes the Jira payload redaction treats as sensitive,
+// replaced with [REDACTED]. The change itself is still listed.
+func redactChanges(changes []AttributeChange, redact []string) []AttributeChange {
+	redacted := make(map[string]bool, len(redact))
+	for _, name := range redact {
+		redacted[strings.ToLower(name)] = true
+	}
+	result := make([]AttributeChange, 0, len(changes))
+	for _, change := range changes {
+		if redacted[strings.ToLower(change.Attribute)] || jira.IsSensitiveName(change.Attribute) {
+			change.Old, change.New = redactValues(change.Old), redactValues(change.New)
+		}
+		result = append(result, change)
+	}
+	return result
+}
+
+// redactValues masks values while keeping an empty side empty, so a redacted diff still shows
+// whether an attribute is being set, cleared or changed.
+func redactValues(values []string) []string {
+	if len(values) == 0 {
+		return values
+	}
+	return []string{redactedValue}
+}
+
+// WriteText writes the diff in a human-readable form, one block per employee:
+//
+//	UPDATE jane@example.com (employee 123, asset EMP-42)
+//	  Status: Active → Inactive
+func (d *DryRunDiff) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "Dry run %s", d.RunID)
+	if d.Target != "" {
+		fmt.Fprintf(w, " (target %s)", d.Target)
+	}
+	fmt.Fprintf(w, ": %d employees would change\n", len(d.Employees))
+	if len(d.Fields) > 0 {
+		fmt.Fprintf(w, "Updates restricted to: %s\n", strings.Join(d.Fields, ", "))
+	}
+	for _, employee := range d.Employees {
+		fmt.Fprintf(w, "\n%s %s (employee %s", strings.ToUpper(employee.Action), employee.Email, employee.EmployeeID)
+		if employee.ObjectKey != "" {
+			fmt.Fprintf(w, ", asset %s", employee.ObjectKey)
+		}
+		fmt.Fprintln(w, ")")
+		for _, change := range employee.Changes {
+			fmt.Fprintf(w, "  %s\n", change)
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// writeDryRunDiff writes the dry-run diff to SYNC_DRY_RUN_DIFF_PATH, as JSON when the path ends
+// in ".json" and as text otherwise. Failures are logged only.
+func (s *Syncer) writeDryRunDiff(report *SyncReport) {
+	path := s.cfg.SyncDryRunDiffPath
+	if path == "" {
+		return
+	}
+	diff := report.DryRunDiff(s.cfg.SyncRedactAttributes)
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = writeJSONFile(path, diff)
+	} else {
+		var text bytes.Buffer
+		if err = diff.WriteText(&text); err == nil {
+			err = writeFileAtomic(path, text.Bytes())
+		}
+	}
+	if err != nil {
+		log.Printf("WARN: Failed to write dry-run diff %s: %v", path, err)
+		return
+	}
+	log.Printf("INFO: Dry-run diff for %d employees written to %s.", len(diff.Employees), path)
+}
@@ -0,0 +1,93 @@
+// internal/syncer/filter.go
+
+package syncer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// filterFields maps the field names usable in SYNC_FILTER to the Paycor value they match.
+var filterFields = map[string]func(emp models.Employee) string{
+	"department":  func(emp models.Employee) string { return emp.PositionData.Department },
+	"location":    func(emp models.Employee) string { return emp.WorkLocation.Name },
+	"city":        func(emp models.Employee) string { return emp.WorkLocation.City },
+	"state":       func(emp models.Employee) string { return emp.WorkLocation.State },
+	"jobtitle":    func(emp models.Employee) string { return emp.PositionData.JobTitle },
+	"legalentity": func(emp models.Employee) string { return emp.LegalEntity.ID },
+}
+
+// filterClause matches employees whose field equals (or, negated, equals none of) the values.
+type filterClause struct {
+	field  string
+	values []string
+	negate bool
+}
+
+// employeeFilter restricts a run to the employees matching every clause.
+type employeeFilter struct {
+	expression string
+	clauses    []filterClause
+}
+
+// parseFilter parses a SYNC_FILTER expression: clauses separated by ';', all of which must
+// match, each in the form field=value or field!=value, with alternative values separated by '|'.
+// For example "department=Engineering|Product; state!=CA". Matching ignores case and surrounding
+// spaces. An empty expression returns nil, which matches everyone.
+func parseFilter(expression string) (*employeeFilter, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, nil
+	}
+	filter := &employeeFilter{expression: strings.TrimSpace(expression)}
+	for _, part := range strings.Split(expression, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		field, values, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("filter clause '%s' is not in the form field=value", strings.TrimSpace(part))
+		}
+		clause := filterClause{}
+		if strings.HasSuffix(field, "!") {
+			clause.negate = true
+			field = strings.TrimSuffix(field, "!")
+		}
+		clause.field = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(field), " ", ""))
+		if _, ok := filterFields[clause.field]; !ok {
+			return nil, fmt.Errorf("unknown filter field '%s'; expected department, location, city, state, jobtitle or legalentity", strings.TrimSpace(field))
+		}
+		for _, value := range strings.Split(values, "|") {
+			if value = strings.TrimSpace(value); value != "" {
+				clause.values = append(clause.values, value)
+			}
+		}
+		if len(clause.values) == 0 {
+			return nil, fmt.Errorf("filter clause '%s' has no values", strings.TrimSpace(part))
+		}
+		filter.clauses = append(filter.clauses, clause)
+	}
+	return filter, nil
+}
+
+// matches reports whether the employee satisfies every clause. A nil filter matches everyone.
+func (f *employeeFilter) matches(emp models.Employee) bool {
+	if f == nil {
+		return true
+	}
+	for _, clause := range f.clauses {
+		actual := strings.TrimSpace(filterFields[clause.field](emp))
+		found := false
+		for _, value := range clause.values {
+			if strings.EqualFold(actual, value) {
+				found = true
+				break
+			}
+		}
+		if found == clause.negate {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,170 @@
+// internal/syncer/followup.go
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// followUpLabelPrefix starts the label that identifies a category's follow-up issue across runs.
+const followUpLabelPrefix = "psdi-followup-"
+
+// followUpMaxRows caps the table in a follow-up issue or comment; Jira limits rich text size.
+const followUpMaxRows = 100
+
+// followUpCategory is a kind of failure that needs a person to fix it, filed as one issue.
+type followUpCategory struct {
+	key         string // Label suffix; must stay stable so later runs find the open issue
+	title       string
+	explanation string
+	rows        func(r *SyncReport) [][]string
+}
+
+var followUpCategories = []followUpCategory{
+	{
+		key:         "missing-option",
+		title:       "Select options missing in Jira Assets",
+		explanation: "Jira rejected these values because the attribute has no such option. Add the options in Assets, or fix the value in Paycor.",
+		rows: func(r *SyncReport) [][]string {
+			rows := make([][]string, 0, len(r.MissingOptions))
+			for _, entry := range r.MissingOptions {
+				rows = append(rows, []string{entry.EmployeeID, entry.Email, "", fmt.Sprintf("%s = '%s' (%s)", entry.Attribute, entry.Value, entry.Operation)})
+			}
+			return rows
+		},
+	},
+	{
+		key:         "unresolved-role",
+		title:       "Employees synced without a Job Role or Location",
+		explanation: "The employee's job title or work location could not be matched to or created as a Role or Location, so that attribute was left untouched.",
+		rows:        func(r *SyncReport) [][]string { return followUpRows(r.Degraded) },
+	},
+	{
+		key:         "mapping-error",
+		title:       "Paycor values that could not be mapped",
+		explanation: "These Paycor values don't fit their Jira attribute's type and were not written. Correct them in Paycor.",
+		rows:        func(r *SyncReport) [][]string { return followUpRows(r.MappingErrors) },
+	},
+	{
+		key:         "invalid-data",
+		title:       "Employees not synced because of invalid Paycor data",
+		explanation: "These employees failed a validation rule and were left out of the sync. Correct the listed fields in Paycor.",
+		rows: func(r *SyncReport) [][]string {
+			rows := make([][]string, 0, len(r.Invalid))
+			for _, entry := range r.Invalid {
+				rows = append(rows, []string{entry.EmployeeID, entry.Email, "", strings.Join(entry.Failures, "; ")})
+			}
+			return rows
+		},
+	},
+	{
+		key:         "write-failure",
+		title:       "Employees that failed to sync",
+		explanation: "Creating or updating these employees' assets failed. Check the detail and the sync log for the cause.",
+		rows:        func(r *SyncReport) [][]string { return followUpRows(r.Failed) },
+	},
+	{
+		key:         "orphan-role",
+		title:       "Roles created with no employee referencing them",
+		explanation: "These Role objects were created for an employee whose asset write then failed or left out Job Role. The next run reuses them; delete any that aren't wanted.",
+		rows:        func(r *SyncReport) [][]string { return followUpRows(r.OrphanRoles) },
+	},
+	{
+		key:         "write-back-failure",
+		title:       "Object keys not written back to Paycor",
+		explanation: "The asset was synced, but its object key could not be stored on the Paycor employee.",
+		rows:        func(r *SyncReport) [][]string { return followUpRows(r.WriteBackFailures) },
+	},
+}
+
+var followUpHeaders = []string{"Employee ID", "Email", "Object Key", "Detail"}
+
+func followUpRows(entries []ReportEntry) [][]string {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{entry.EmployeeID, entry.Email, entry.ObjectKey, entry.Detail})
+	}
+	return rows
+}
+
+// fileFollowUpIssues files one Jira issue per failure category in the run, in
+// JIRA_FOLLOW_UP_PROJECT_KEY. If a category already has an open issue from an earlier run, the
+// affected employees are added to it as a comment instead, so the same problem isn't ticketed
+// every night. Failures to file are logged and never fail the run.
+func (s *Syncer) fileFollowUpIssues(ctx context.Context, report *SyncReport) {
+	projectKey := s.cfg.Jira.JiraFollowUpProjectKey
+	if projectKey == "" {
+		return
+	}
+	runLabel := "psdi-run-" + report.RunID
+
+	for _, category := range followUpCategories {
+		rows := category.rows(report)
+		if len(rows) == 0 {
+			continue
+		}
+		label := followUpLabelPrefix + category.key
+		if s.opts.DryRun {
+			log.Printf("INFO: DRY RUN: Would file a follow-up issue in %s for %d employees: %s.", projectKey, len(rows), category.title)
+			continue
+		}
+
+		jql := fmt.Sprintf(`project = "%s" AND labels = "%s" AND statusCategory != Done ORDER BY created DESC`, projectKey, label)
+		existing, err := s.jiraClient.SearchIssues(ctx, jql, 1)
+		if err != nil {
+			log.Printf("ERROR: Failed to look for an open follow-up issue for '%s': %v", category.title, err)
+			continue
+		}
+
+		if len(existing) > 0 {
+			issueKey := existing[0].Key
+			comment := models.NewADFDocument(followUpBlocks(fmt.Sprintf("Run %s found %d affected employees:", report.RunID, len(rows)), rows)...)
+			if err := s.jiraClient.AddComment(ctx, issueKey, comment); err != nil {
+				log.Printf("ERROR: Failed to update follow-up issue %s: %v", issueKey, err)
+				continue
+			}
+			if err := s.jiraClient.AddLabels(ctx, issueKey, runLabel); err != nil {
+				log.Printf("WARN: Failed to label follow-up issue %s with %s: %v", issueKey, runLabel, err)
+			}
+			log.Printf("INFO: Added %d employees to open follow-up issue %s (%s).", len(rows), issueKey, category.title)
+			report.FollowUpIssues = append(report.FollowUpIssues, issueKey)
+			continue
+		}
+
+		blocks := append([]models.ADFNode{models.ADFParagraph(category.explanation)},
+			followUpBlocks(fmt.Sprintf("Run %s found %d affected employees:", report.RunID, len(rows)), rows)...)
+		issue, err := s.jiraClient.CreateIssue(ctx, models.JiraIssueFields{
+			Project:     models.JiraProject{Key: projectKey},
+			Summary:     "PSDI sync follow-up: " + category.title,
+			IssueType:   models.JiraIssueType{Name: s.cfg.Jira.JiraFollowUpIssueType},
+			Description: models.NewADFDocument(blocks...),
+			Labels:      []string{label, runLabel},
+		})
+		if err != nil {
+			log.Printf("ERROR: Failed to create follow-up issue for '%s': %v", category.title, err)
+			continue
+		}
+		log.Printf("SUCCESS: Created follow-up issue %s for %d employees (%s).", issue.Key, len(rows), category.title)
+		report.FollowUpIssues = append(report.FollowUpIssues, issue.Key)
+	}
+}
+
+// followUpBlocks returns an intro paragraph and the table of affected employees.
+func followUpBlocks(intro string, rows [][]string) []models.ADFNode {
+	blocks := []models.ADFNode{models.ADFParagraph(intro)}
+	truncated := 0
+	if len(rows) > followUpMaxRows {
+		truncated = len(rows) - followUpMaxRows
+		rows = rows[:followUpMaxRows]
+	}
+	blocks = append(blocks, models.ADFTable(followUpHeaders, rows))
+	if truncated > 0 {
+		blocks = append(blocks, models.ADFParagraph(fmt.Sprintf("…and %d more. See the sync report for the full list.", truncated)))
+	}
+	return blocks
+}
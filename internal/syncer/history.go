@@ -0,0 +1,147 @@
+// internal/syncer/history.go
+
+package syncer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Run statuses recorded in SyncReport.Status.
+const (
+	RunStatusRunning    = "running"
+	RunStatusCompleted  = "completed"
+	RunStatusIncomplete = "incomplete" // Stopped by the time budget or -limit
+	RunStatusFailed     = "failed"     // Ended with an error or was cancelled
+)
+
+// RunSummary is one run in the history, as listed by ListRuns.
+type RunSummary struct {
+	RunID      string
+	Status     string
+	Target     string
+	DryRun     bool
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Created    int
+	Updated    int
+	Failed     int
+	Invalid    int
+}
+
+// historyPath returns where a run's report is kept in the history directory.
+func historyPath(dir, runID string) string {
+	return filepath.Join(dir, runID+".json")
+}
+
+// writeHistory writes the report to SYNC_HISTORY_DIR, where it stays after the run so earlier
+// runs can be inspected with cmd/runs. Failures are logged only.
+func (s *Syncer) writeHistory(report *SyncReport) {
+	dir := s.cfg.SyncHistoryDir
+	if dir == "" {
+		return
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.Printf("WARN: Failed to create run history directory %s: %v", dir, err)
+		return
+	}
+	if err := writeJSONFile(historyPath(dir, report.RunID), report); err != nil {
+		log.Printf("WARN: Failed to write run history for %s: %v", report.RunID, err)
+	}
+}
+
+// pruneHistory deletes all but the newest SYNC_HISTORY_KEEP runs from the history directory.
+func (s *Syncer) pruneHistory() {
+	dir, keep := s.cfg.SyncHistoryDir, s.cfg.SyncHistoryKeep
+	if dir == "" || keep <= 0 {
+		return
+	}
+	ids, err := historyRunIDs(dir)
+	if err != nil {
+		log.Printf("WARN: Failed to read run history directory %s: %v", dir, err)
+		return
+	}
+	for _, id := range ids[min(keep, len(ids)):] {
+		if err := os.Remove(historyPath(dir, id)); err != nil {
+			log.Printf("WARN: Failed to prune run %s from the history: %v", id, err)
+		}
+	}
+}
+
+// historyRunIDs returns the run IDs in the history directory, newest first. Run IDs are UTC
+// timestamps, so they sort by start time.
+func historyRunIDs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != ".json" {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(name, ".json"))
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	return ids, nil
+}
+
+// ListRuns returns up to limit runs from the history directory, newest first. A limit of 0
+// returns every run. Reports that can't be read are skipped with a warning.
+func ListRuns(dir string, limit int) ([]RunSummary, error) {
+	ids, err := historyRunIDs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run history directory %s: %w", dir, err)
+	}
+	var runs []RunSummary
+	for _, id := range ids {
+		if limit > 0 && len(runs) >= limit {
+			break
+		}
+		report, err := LoadRun(dir, id)
+		if err != nil {
+			log.Printf("WARN: Skipping run %s: %v", id, err)
+			continue
+		}
+		runs = append(runs, RunSummary{
+			RunID:      report.RunID,
+			Status:     report.Status,
+			Target:     report.Target,
+			DryRun:     report.DryRun,
+			StartedAt:  report.StartedAt,
+			FinishedAt: report.FinishedAt,
+			Created:    len(report.Created),
+			Updated:    len(report.Updated),
+			Failed:     len(report.Failed),
+			Invalid:    len(report.Invalid),
+		})
+	}
+	return runs, nil
+}
+
+// LoadRun reads one run's report from the history directory.
+func LoadRun(dir, runID string) (*SyncReport, error) {
+	if runID == "" || strings.ContainsAny(runID, `/\`) {
+		return nil, fmt.Errorf("invalid run ID '%s'", runID)
+	}
+	data, err := os.ReadFile(historyPath(dir, runID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run %s: %w", runID, err)
+	}
+	var report SyncReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse run %s: %w", runID, err)
+	}
+	return &report, nil
+}
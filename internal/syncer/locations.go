@@ -0,0 +1,101 @@
+// internal/syncer/locations.go
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// syncLocations makes sure every Paycor work location has a Location object before employees are
+// synced, and warms the location cache with their keys, so employees reference Locations without
+// a lookup each. Outside dry-run mode missing locations are created. If Paycor's locations can't
+// be fetched, or Jira can't be searched, locations are looked up per employee instead.
+func (s *Syncer) syncLocations(ctx context.Context) {
+	if s.locations == nil {
+		return
+	}
+	locations, err := s.paycorClient.FetchLocations(ctx)
+	if err != nil {
+		log.Printf("WARN: Failed to fetch Paycor work locations; locations will be looked up per employee: %v", err)
+		return
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, location := range locations {
+		name := strings.TrimSpace(location.Name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	found, err := s.jiraClient.FindLocations(ctx, names)
+	if err != nil {
+		log.Printf("WARN: Failed to look up %d work locations in one pass; locations will be looked up per employee: %v", len(names), err)
+		return
+	}
+	for _, name := range names {
+		s.locations.set(name, found[strings.ToLower(name)], false)
+	}
+	log.Printf("INFO: Location cache warmed: %d of %d Paycor work locations have a Location in Jira.", len(found), len(names))
+
+	for _, name := range names {
+		if found[strings.ToLower(name)] != "" {
+			continue
+		}
+		if _, _, err := s.resolveLocationWithRetry(ctx, name); err != nil {
+			log.Printf("ERROR: Could not create Jira Location for '%s'; employees there will be synced without 'Location': %v", name, err)
+		}
+	}
+}
+
+// resolveLocationWithRetry calls resolveLocation, retrying failures with backoff. It returns an
+// empty key when location references are disabled.
+func (s *Syncer) resolveLocationWithRetry(ctx context.Context, locationName string) (string, bool, error) {
+	if s.locations == nil {
+		return "", false, nil
+	}
+	return resolveWithRetry(ctx, "Location", strings.TrimSpace(locationName), s.resolveLocation)
+}
+
+// resolveLocation returns the Location object key for a work location, and whether the location
+// was created for it, the same way resolveRole does for job titles.
+func (s *Syncer) resolveLocation(ctx context.Context, locationName string) (string, bool, error) {
+	if locationName == "" {
+		return "", false, nil
+	}
+	locationKey, cached := s.locations.get(locationName)
+	if locationKey != "" {
+		return locationKey, false, nil
+	}
+	if !cached {
+		var err error
+		locationKey, err = s.jiraClient.FindLocation(ctx, locationName)
+		if err != nil {
+			return "", false, err
+		}
+		s.locations.set(locationName, locationKey, false)
+		if locationKey != "" {
+			return locationKey, false, nil
+		}
+	}
+
+	if s.opts.DryRun {
+		log.Printf("INFO: DRY RUN: Would create Jira Location '%s'.", locationName)
+		return fmt.Sprintf("<new location: %s>", locationName), false, nil
+	}
+	log.Printf("INFO: No Jira Location exists for work location '%s'. Creating it.", locationName)
+	locationKey, err := s.jiraClient.CreateLocation(ctx, locationName)
+	if err != nil {
+		return "", false, err
+	}
+	s.locations.set(locationName, locationKey, true)
+	return locationKey, true, nil
+}
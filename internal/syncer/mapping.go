@@ -0,0 +1,211 @@
+// internal/syncer/mapping.go
+
+package syncer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// nameFormatTemplates are the JIRA_NAME_FORMAT presets, expressed as display name templates.
+var nameFormatTemplates = map[string]string{
+	config.NameFormatFirstLast:     "{{.FirstName}} {{.LastName}}",
+	config.NameFormatPreferredLast: "{{or .PreferredName .FirstName}} {{.LastName}}",
+	config.NameFormatLastFirst:     "{{.LastName}}, {{.FirstName}}",
+	config.NameFormatMiddleInitial: "{{.FirstName}} {{with .MiddleInitial}}{{.}}. {{end}}{{.LastName}}",
+}
+
+// nameFields is the data a display name template is executed with. Values are trimmed, so
+// a blank preferred name counts as unset in {{or .PreferredName .FirstName}}.
+type nameFields struct {
+	FirstName      string
+	MiddleName     string
+	MiddleInitial  string // First letter of MiddleName, without a period
+	LastName       string
+	PreferredName  string
+	EmployeeNumber string
+}
+
+// parseNameTemplate returns the display name template: JIRA_NAME_TEMPLATE when set, otherwise
+// the JIRA_NAME_FORMAT preset. The template is also executed against a sample employee, so a
+// misspelt field fails at startup rather than for every employee in the run.
+func parseNameTemplate(cfg config.JiraConfig) (*template.Template, error) {
+	text := cfg.JiraNameTemplate
+	if text == "" {
+		text = nameFormatTemplates[cfg.JiraNameFormat]
+	}
+	if text == "" {
+		text = nameFormatTemplates[config.NameFormatFirstLast]
+	}
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse display name template '%s': %w", text, err)
+	}
+	sample := nameFields{FirstName: "Jane", MiddleName: "Ann", MiddleInitial: "A", LastName: "Doe", PreferredName: "JD", EmployeeNumber: "1001"}
+	var name strings.Builder
+	if err := tmpl.Execute(&name, sample); err != nil {
+		return nil, fmt.Errorf("display name template '%s' does not work with a sample employee: %w", text, err)
+	}
+	if strings.TrimSpace(name.String()) == "" {
+		return nil, fmt.Errorf("display name template '%s' gives an empty name for a sample employee", text)
+	}
+	return tmpl, nil
+}
+
+// composeName builds the value of the "Name" attribute, which Jira also uses as the asset label,
+// by executing the display name template. Runs of whitespace in the result are collapsed.
+func composeName(tmpl *template.Template, employee models.Employee) (string, error) {
+	fields := nameFields{
+		FirstName:      strings.TrimSpace(employee.FirstName),
+		MiddleName:     strings.TrimSpace(employee.MiddleName),
+		LastName:       strings.TrimSpace(employee.LastName),
+		PreferredName:  strings.TrimSpace(employee.PreferredName),
+		EmployeeNumber: strings.TrimSpace(employee.EmployeeNumber),
+	}
+	for _, r := range fields.MiddleName {
+		fields.MiddleInitial = strings.ToUpper(string(r))
+		break
+	}
+	var name strings.Builder
+	if err := tmpl.Execute(&name, fields); err != nil {
+		return "", fmt.Errorf("failed to compose display name for employee %s: %w", employee.ID, err)
+	}
+	return strings.Join(strings.Fields(name.String()), " "), nil
+}
+
+// mapPaycorToJiraAsset converts a Paycor employee object to the Jira EmployeeAssets model.
+// This function builds the []AssetAttribute slice structure the Assets API expects.
+// name is the composed display name, written to "Name" and used as the label.
+// managerName is written to the free-text "Manager Name" attribute and left out when empty.
+// Once the schema has a Manager object reference, that should replace this attribute.
+// locationKey is the Location object for the employee's work location, written to the "Location"
+// reference when the schema has one and left out when empty.
+// terminated selects the "Status" written: jiraStatusTerminated instead of jiraStatusActive.
+func mapPaycorToJiraAsset(employee models.Employee, name, roleKey, locationKey, managerName string, terminated bool) models.EmployeeAssets {
+	status := jiraStatusActive
+	if terminated {
+		status = jiraStatusTerminated
+	}
+
+	// !!! IMPORTANT !!!
+	// The 'ObjectTypeAttributeID' values below come from models.AttributeID in
+	// 'jiraAssetMap.go'. You MUST verify these IDs are correct for your specific
+	// Jira Assets schema. You can find them in the Jira UI when configuring your
+	// object schema.
+	asset := models.EmployeeAssets{
+		Label: name, // Jira derives the label from the Name attribute; keep ours in step with it.
+		Attributes: []models.AssetAttribute{
+			{
+				ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Name"]),
+				Values: []models.Value{
+					{Value: name},
+				},
+			},
+			{
+				ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Email"]),
+				Values: []models.Value{
+					{Value: employee.Email.EmailAddress},
+				},
+			},
+			{
+				ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Start Date"]),
+				Values: []models.Value{
+					{Value: employee.EmploymentDateData.HireDate},
+				},
+			},
+			{
+				ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Status"]),
+				Values: []models.Value{
+					// This assumes the Jira status select has "Active" and "Terminated" options.
+					{Value: status},
+				},
+			},
+			{
+				ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Job Role"]),
+				Values: []models.Value{
+					{Value: roleKey},
+				},
+			},
+		},
+	}
+	if managerName != "" {
+		asset.Attributes = append(asset.Attributes, models.AssetAttribute{
+			ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Manager Name"]),
+			Values:                []models.Value{{Value: managerName}},
+		})
+	}
+	if id, ok := models.AttributeID["Location"]; ok && locationKey != "" {
+		asset.Attributes = append(asset.Attributes, models.AssetAttribute{
+			ObjectTypeAttributeID: strconv.Itoa(id),
+			Values:                []models.Value{{Value: locationKey}},
+		})
+	}
+	// "Phone" is multi-valued: every Paycor number is written as its own value. It is only
+	// mapped when the schema has the attribute, since the built-in attribute map doesn't.
+	if id, ok := models.AttributeID["Phone"]; ok {
+		if phones := phoneValues(employee.Phones); len(phones) > 0 {
+			asset.Attributes = append(asset.Attributes, models.AssetAttribute{
+				ObjectTypeAttributeID: strconv.Itoa(id),
+				Values:                phones,
+			})
+		}
+	}
+	return asset
+}
+
+// phoneValues returns one value per distinct, non-empty phone number, in Paycor's order.
+func phoneValues(phones []models.Phone) []models.Value {
+	seen := make(map[string]bool, len(phones))
+	var values []models.Value
+	for _, phone := range phones {
+		number := strings.TrimSpace(phone.PhoneNumber)
+		if number == "" || seen[number] {
+			continue
+		}
+		seen[number] = true
+		values = append(values, models.Value{Value: number})
+	}
+	return values
+}
+
+// normalizePersonName tidies a free-text person name from Paycor. Whitespace is collapsed and,
+// in the "auto" format, "LAST, FIRST" is reordered to "First Last". Names sent entirely in
+// upper case are title-cased.
+func normalizePersonName(name, format string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || format == config.ManagerNameFormatAsIs {
+		return name
+	}
+	if last, first, ok := strings.Cut(name, ","); ok && !strings.Contains(first, ",") {
+		last, first = strings.TrimSpace(last), strings.TrimSpace(first)
+		if last != "" && first != "" {
+			name = first + " " + last
+		}
+	}
+	if name == strings.ToUpper(name) {
+		words := strings.Fields(strings.ToLower(name))
+		for i, word := range words {
+			words[i] = titleWord(word)
+		}
+		name = strings.Join(words, " ")
+	}
+	return name
+}
+
+// titleWord upper-cases the first letter of a word and of each hyphenated or apostrophed part,
+// so "o'neil-smith" becomes "O'Neil-Smith".
+func titleWord(word string) string {
+	runes := []rune(word)
+	for i, r := range runes {
+		if i == 0 || runes[i-1] == '-' || runes[i-1] == '\'' {
+			runes[i] = unicode.ToUpper(r)
+		}
+	}
+	return string(runes)
+}
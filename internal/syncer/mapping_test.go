@@ -0,0 +1,92 @@
+package syncer
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// attributeValues returns the values mapped to the named attribute, or nil if it isn't mapped.
+func attributeValues(asset models.EmployeeAssets, name string) []string {
+	id := strconv.Itoa(models.AttributeID[name])
+	for _, attribute := range asset.Attributes {
+		if attribute.ObjectTypeAttributeID != id {
+			continue
+		}
+		var values []string
+		for _, value := range attribute.Values {
+			values = append(values, value.Value)
+		}
+		return values
+	}
+	return nil
+}
+
+func TestMapPaycorToJiraAssetStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		terminated bool
+		want       string
+	}{
+		{name: "active", terminated: false, want: jiraStatusActive},
+		{name: "terminated", terminated: true, want: jiraStatusTerminated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emp := models.Employee{ID: "1", FirstName: "Jane", LastName: "Doe"}
+			asset := mapPaycorToJiraAsset(emp, "Jane Doe", "ROLE-1", "", "", tt.terminated)
+			got := attributeValues(asset, "Status")
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("Status = %v, want [%s]", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapPaycorToJiraAssetTerminatedEmployee(t *testing.T) {
+	emp := models.Employee{ID: "1", FirstName: "Jane", LastName: "Doe", StatusData: models.StatusData{Status: "Terminated"}}
+	asset := mapPaycorToJiraAsset(emp, "Jane Doe", "", "", "", isTerminated(emp, time.Now(), nil))
+	if got := attributeValues(asset, "Status"); len(got) != 1 || got[0] != jiraStatusTerminated {
+		t.Errorf("Status = %v, want [%s]", got, jiraStatusTerminated)
+	}
+}
+
+func TestPhoneValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		phones []models.Phone
+		want   []string
+	}{
+		{name: "none", phones: nil, want: nil},
+		{
+			name:   "two numbers in Paycor's order",
+			phones: []models.Phone{{Type: "Mobile", PhoneNumber: "555-0100"}, {Type: "Home", PhoneNumber: "555-0199"}},
+			want:   []string{"555-0100", "555-0199"},
+		},
+		{
+			name:   "duplicates",
+			phones: []models.Phone{{Type: "Mobile", PhoneNumber: "555-0100"}, {Type: "Work", PhoneNumber: " 555-0100 "}, {Type: "Home", PhoneNumber: "555-0199"}},
+			want:   []string{"555-0100", "555-0199"},
+		},
+		{
+			name:   "blanks",
+			phones: []models.Phone{{Type: "Mobile", PhoneNumber: ""}, {Type: "Home", PhoneNumber: "  "}, {Type: "Work", PhoneNumber: "555-0100"}},
+			want:   []string{"555-0100"},
+		},
+		{name: "only blanks", phones: []models.Phone{{PhoneNumber: " "}}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, value := range phoneValues(tt.phones) {
+				got = append(got, value.Value)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("phoneValues = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
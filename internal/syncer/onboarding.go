@@ -0,0 +1,115 @@
+// internal/syncer/onboarding.go
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/jira"
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// defaultOnboardingTemplate is the rehire onboarding description used when
+// JIRA_ONBOARDING_TEMPLATE_PATH is not set. Each line becomes a paragraph.
+const defaultOnboardingTemplate = `{{.Name}} ({{.Email}}) was rehired on {{.RehireDate}}. Please complete onboarding for asset {{.ObjectKey}}.
+{{if .JobTitle}}Job title: {{.JobTitle}}
+{{end}}{{if .Department}}Department: {{.Department}}
+{{end}}{{if .Manager}}Manager: {{.Manager}}
+{{end}}{{if .HireDate}}Original hire date: {{.HireDate}}
+{{end}}`
+
+// onboardingFields is the data an onboarding description template is executed with.
+type onboardingFields struct {
+	EmployeeID     string
+	EmployeeNumber string
+	FirstName      string
+	LastName       string
+	Name           string // Display name, as written to the asset
+	Email          string
+	JobTitle       string
+	Department     string
+	Manager        string
+	Location       string
+	HireDate       string
+	RehireDate     string
+	ObjectKey      string
+}
+
+// parseOnboardingTemplate reads the onboarding description template from path, or returns the
+// built-in template when path is empty.
+func parseOnboardingTemplate(path string) (*template.Template, error) {
+	text := defaultOnboardingTemplate
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read onboarding template %s: %w", path, err)
+		}
+		text = string(data)
+	}
+	tmpl, err := template.New("onboarding").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse onboarding template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// createRehireOnboardingIssue opens a new onboarding issue for a rehired employee, linked to their asset.
+// It returns the new issue key, or "" if issue creation is not configured or fails.
+func (s *Syncer) createRehireOnboardingIssue(ctx context.Context, emp models.Employee, objectKey string) string {
+	projectKey := s.cfg.Jira.JiraOnboardingProjectKey
+	if projectKey == "" {
+		return ""
+	}
+	if s.cfg.Jira.JiraAssetObjectKeyCustomField == "" {
+		log.Println("WARN: JIRA_ASSET_OBJECT_KEY_CUSTOM_FIELD_ID is not set. Cannot create a rehire onboarding issue.")
+		return ""
+	}
+
+	name, err := composeName(s.nameTemplate, emp)
+	if err != nil {
+		name = strings.TrimSpace(emp.FirstName + " " + emp.LastName)
+	}
+	var description strings.Builder
+	err = s.onboarding.Execute(&description, onboardingFields{
+		EmployeeID:     emp.ID,
+		EmployeeNumber: emp.EmployeeNumber,
+		FirstName:      emp.FirstName,
+		LastName:       emp.LastName,
+		Name:           name,
+		Email:          emp.Email.EmailAddress,
+		JobTitle:       emp.PositionData.JobTitle,
+		Department:     emp.PositionData.Department,
+		Manager:        s.managerName(emp),
+		Location:       emp.WorkLocation.Name,
+		HireDate:       emp.EmploymentDateData.HireDate,
+		RehireDate:     emp.EmploymentDateData.RehireDate,
+		ObjectKey:      objectKey,
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to render rehire onboarding description for employee %s: %v", emp.ID, err)
+		return ""
+	}
+
+	opts := jira.CreateIssueOptions{
+		Labels:            s.cfg.Jira.JiraOnboardingLabels,
+		AssigneeAccountID: s.cfg.Jira.JiraOnboardingAssigneeID,
+	}
+	if days := s.cfg.Jira.JiraOnboardingDueDays; days > 0 {
+		opts.DueDate = time.Now().AddDate(0, 0, days).Format("2006-01-02")
+	}
+
+	summary := fmt.Sprintf("Rehire onboarding: %s", name)
+	issue, err := s.jiraClient.CreateIssueWithAsset(ctx, projectKey, summary, description.String(), s.cfg.Jira.JiraAssetObjectKeyCustomField, objectKey, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to create rehire onboarding issue for employee %s: %v", emp.ID, err)
+		return ""
+	}
+	log.Printf("SUCCESS: Created rehire onboarding issue %s for employee %s.", issue.Key, emp.ID)
+	return issue.Key
+}
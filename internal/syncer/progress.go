@@ -0,0 +1,43 @@
+// internal/syncer/progress.go
+
+package syncer
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// progress tracks a run in chunks of employees so long runs report how far along they are.
+type progress struct {
+	chunkSize int
+	started   time.Time
+	total     int // Paycor's reported total, or 0 when unknown
+}
+
+func newProgress(chunkSize int, started time.Time) *progress {
+	return &progress{chunkSize: chunkSize, started: started}
+}
+
+// advance reports whether processed employees completes a chunk.
+func (p *progress) advance(processed int) bool {
+	return p.chunkSize > 0 && processed > 0 && processed%p.chunkSize == 0
+}
+
+// log writes a progress line with the processing rate, an ETA when Paycor reported a total,
+// and the outcome counts so far.
+func (p *progress) log(processed int, report *SyncReport) {
+	elapsed := time.Since(p.started)
+	rate := float64(processed) / elapsed.Seconds()
+
+	position := fmt.Sprintf("%d employees", processed)
+	if p.total > 0 {
+		position = fmt.Sprintf("%d/%d employees (%.0f%%)", processed, p.total, 100*float64(processed)/float64(p.total))
+		if remaining := p.total - processed; remaining > 0 && rate > 0 {
+			eta := time.Duration(float64(remaining) / rate * float64(time.Second))
+			position += fmt.Sprintf(", ETA %v", eta.Round(time.Second))
+		}
+	}
+	log.Printf("INFO: Progress: %s in %v, %.1f/s. created=%d updated=%d unchanged=%d failed=%d",
+		position, elapsed.Round(time.Second), rate, len(report.Created), len(report.Updated), len(report.Unchanged), len(report.Failed))
+}
@@ -0,0 +1,156 @@
+// internal/syncer/reconcile.go
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// Keys a Paycor employee and a Jira asset can be matched on, in order of preference.
+const (
+	MatchedByEmail          = "email"
+	MatchedByEmployeeNumber = "employee number"
+)
+
+// ReconcileEntry is one person's presence in Paycor and Jira.
+type ReconcileEntry struct {
+	EmployeeID     string `json:"employeeId,omitempty"`
+	EmployeeNumber string `json:"employeeNumber,omitempty"`
+	Email          string `json:"email,omitempty"`
+	PaycorStatus   string `json:"paycorStatus,omitempty"`
+	ObjectKey      string `json:"objectKey,omitempty"`
+	InPaycor       bool   `json:"inPaycor"`
+	InJira         bool   `json:"inJira"`
+	MatchedBy      string `json:"matchedBy,omitempty"` // Set when the person is in both
+}
+
+// ReconcileReport compares every Paycor employee with every Jira Employee asset without
+// changing either side.
+type ReconcileReport struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	// Filter is the SYNC_FILTER scope the report was restricted to. Excluded counts Paycor
+	// employees outside it; they and their assets appear in no list.
+	Filter   string `json:"filter,omitempty"`
+	Excluded int    `json:"excluded"`
+
+	Matched    []ReconcileEntry `json:"matched"`
+	PaycorOnly []ReconcileEntry `json:"paycorOnly"` // Employees with no asset
+	JiraOnly   []ReconcileEntry `json:"jiraOnly"`   // Assets with no Paycor employee, candidates for cleanup
+
+	// Unmanaged counts unmatched assets without the Managed By flag (JIRA_MANAGED_BY_ATTRIBUTE),
+	// such as hand-created contractors. They are left out of JiraOnly so they are never cleaned up.
+	Unmanaged int `json:"unmanaged"`
+}
+
+// Reconcile loads all Paycor employees and all Jira Employee assets and reports who is present
+// where. Matching is by normalized email first, then by employee number when the schema has
+// an "Employee Number" attribute. With SYNC_FILTER set, employees outside it are still matched,
+// so their assets aren't reported as Jira-only, but are left out of the report. With
+// JIRA_MANAGED_BY_ATTRIBUTE set, only assets carrying the flag are reported as Jira-only.
+// Nothing is written.
+func (s *Syncer) Reconcile(ctx context.Context) (*ReconcileReport, error) {
+	if err := s.loadSchema(ctx); err != nil {
+		return nil, err
+	}
+	assets, err := s.jiraClient.GetAllEmployeeAssets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee assets from Jira: %w", err)
+	}
+	employees, err := s.paycorClient.FetchAllEmployees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch employees from Paycor: %w", err)
+	}
+
+	byEmail := make(map[string]int)
+	byNumber := make(map[string]int)
+	for i, asset := range assets {
+		if email := models.NormalizeEmail(s.schema.GetAttributeValue(asset, "Email"), s.cfg.Features.StripEmailPlusTags); email != "" {
+			byEmail[email] = i
+		}
+		if number := strings.TrimSpace(s.schema.GetAttributeValue(asset, "Employee Number")); number != "" {
+			byNumber[number] = i
+		}
+	}
+
+	report := &ReconcileReport{GeneratedAt: time.Now()}
+	if s.filter != nil {
+		report.Filter = s.filter.expression
+	}
+	matchedAssets := make(map[int]bool)
+	for _, emp := range employees {
+		entry := ReconcileEntry{
+			EmployeeID:     emp.ID,
+			EmployeeNumber: emp.EmployeeNumber,
+			Email:          emp.Email.EmailAddress,
+			PaycorStatus:   emp.StatusData.Status,
+			InPaycor:       true,
+		}
+
+		index, found := byEmail[models.NormalizeEmail(emp.Email.EmailAddress, s.cfg.Features.StripEmailPlusTags)]
+		entry.MatchedBy = MatchedByEmail
+		if !found && strings.TrimSpace(emp.EmployeeNumber) != "" {
+			index, found = byNumber[strings.TrimSpace(emp.EmployeeNumber)]
+			entry.MatchedBy = MatchedByEmployeeNumber
+		}
+		inScope := s.filter.matches(emp)
+		if !inScope {
+			report.Excluded++
+		}
+		if !found || matchedAssets[index] {
+			if inScope {
+				entry.MatchedBy = ""
+				report.PaycorOnly = append(report.PaycorOnly, entry)
+			}
+			continue
+		}
+
+		matchedAssets[index] = true
+		if !inScope {
+			continue
+		}
+		entry.InJira = true
+		entry.ObjectKey = assets[index].ObjectKey
+		report.Matched = append(report.Matched, entry)
+	}
+
+	for i, asset := range assets {
+		if matchedAssets[i] {
+			continue
+		}
+		if !s.isManaged(asset) {
+			report.Unmanaged++
+			continue
+		}
+		report.JiraOnly = append(report.JiraOnly, ReconcileEntry{
+			EmployeeNumber: s.schema.GetAttributeValue(asset, "Employee Number"),
+			Email:          s.schema.GetAttributeValue(asset, "Email"),
+			ObjectKey:      asset.ObjectKey,
+			InJira:         true,
+		})
+	}
+	sort.Slice(report.JiraOnly, func(i, j int) bool { return report.JiraOnly[i].ObjectKey < report.JiraOnly[j].ObjectKey })
+
+	return report, nil
+}
+
+// LogSummary writes the reconcile counts and every unmatched person to the log.
+func (r *ReconcileReport) LogSummary() {
+	log.Printf("INFO: [Reconcile] matched=%d paycorOnly=%d jiraOnly=%d unmanaged=%d excluded=%d", len(r.Matched), len(r.PaycorOnly), len(r.JiraOnly), r.Unmanaged, r.Excluded)
+	if r.Filter != "" {
+		log.Printf("INFO: [Reconcile] Scoped to '%s'. Jira-only assets may include people outside the scope who are missing from Paycor.", r.Filter)
+	}
+	for _, entry := range r.PaycorOnly {
+		log.Printf("INFO: [Reconcile] In Paycor only: employee %s (%s, status %s)", entry.EmployeeID, entry.Email, entry.PaycorStatus)
+	}
+	for _, entry := range r.JiraOnly {
+		log.Printf("INFO: [Reconcile] In Jira only: asset %s (%s)", entry.ObjectKey, entry.Email)
+	}
+}
@@ -0,0 +1,304 @@
+// internal/syncer/report.go
+
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/jira"
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+	"github.com/Devon-ODell/PSDIv0.2/internal/paycor"
+)
+
+// ReportEntry identifies one employee in a SyncReport and what happened to them.
+type ReportEntry struct {
+	EmployeeID string `json:"employeeId"`
+	Email      string `json:"email"`
+	ObjectKey  string `json:"objectKey,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+
+	// Changes lists the attributes that were (or, in a dry run, would be) updated.
+	Changes []AttributeChange `json:"changes,omitempty"`
+}
+
+// SyncReport summarises a single sync run.
+type SyncReport struct {
+	RunID      string        `json:"runId"`            // Identifies the run, e.g. in follow-up issue labels
+	Target     string        `json:"target,omitempty"` // Named Jira target the run wrote to
+	Paycor     string        `json:"paycor"`           // Paycor environment the run read from
+	StartedAt  time.Time     `json:"startedAt"`
+	FinishedAt time.Time     `json:"finishedAt"`
+	Status     string        `json:"status"` // running, completed, incomplete or failed
+	DryRun     bool          `json:"dryRun"`
+	Fields     []string      `json:"fields,omitempty"` // Attributes updates were restricted to; empty means all
+	Fetched    int           `json:"fetched"`
+	Filtered   int           `json:"filtered"` // Terminated employees left out of the sync
+	Resumed    int           `json:"resumed"`  // Employees skipped because the interrupted run already completed them
+	Created    []ReportEntry `json:"created"`
+	Updated    []ReportEntry `json:"updated"`
+	Unchanged  []ReportEntry `json:"unchanged"`
+	Rehired    []ReportEntry `json:"rehired"` // Terminated in Jira, active again in Paycor. Not also counted in Updated.
+	Failed     []ReportEntry `json:"failed"`
+
+	// DeadlineExceeded is set when SYNC_MAX_DURATION ran out and the run stopped early,
+	// so the lists above and below only cover part of Paycor.
+	DeadlineExceeded bool `json:"deadlineExceeded"`
+
+	// Filter is the SYNC_FILTER expression the run was restricted to, and Excluded counts the
+	// employees that didn't match it.
+	Filter   string `json:"filter,omitempty"`
+	Excluded int    `json:"excluded"`
+
+	// Limit is the -limit the run was started with, and LimitReached is set when it cut the run
+	// short. Such a run is a smoke test and does not cover all of Paycor.
+	Limit        int  `json:"limit,omitempty"`
+	LimitReached bool `json:"limitReached,omitempty"`
+
+	// NameChanges lists employees whose Name (and therefore asset label) changed.
+	// They are also counted in Updated or Rehired.
+	NameChanges []ReportEntry `json:"nameChanges"`
+
+	// MappingErrors lists attribute values that could not be mapped and were left out of the write.
+	// The employee itself is still synced and appears in one of the lists above.
+	MappingErrors []ReportEntry `json:"mappingErrors"`
+
+	// MissingOptions lists select values Jira rejected because the attribute has no such option,
+	// so an admin can add them. Each failed write also appears in Failed.
+	MissingOptions []MissingOptionEntry `json:"missingOptions"`
+
+	// Suppressed lists employees with differences a write policy kept from being written,
+	// such as a corrected Start Date on a create-only attribute. Changes holds those differences.
+	Suppressed []ReportEntry `json:"suppressed"`
+
+	// Degraded lists employees whose role or location could not be resolved, so they were synced
+	// without 'Job Role' or 'Location' and need following up. If the write itself also failed they
+	// are in Failed too.
+	Degraded []ReportEntry `json:"degraded"`
+
+	// OrphanRoles lists Role objects created during the run that no asset references, because
+	// the write for the employee they were created for failed or left Job Role out. ObjectKey is
+	// the role's key. A later run reuses the role, so they only need deleting if unwanted.
+	OrphanRoles []ReportEntry `json:"orphanRoles"`
+
+	// Errors lists every per-employee failure with the phase it happened in, so failed employees
+	// can be retried and error rates tracked. The lists above carry the same failures by outcome.
+	Errors []EmployeeSyncError `json:"errors"`
+
+	// DecodeFailures lists Paycor records that could not be decoded and were skipped, with a
+	// snippet of their raw JSON. Too many of them aborts the run (PAYCOR_MAX_DECODE_FAILURES).
+	DecodeFailures []paycor.DecodeFailure `json:"decodeFailures"`
+
+	// Invalid lists employees that failed a validation rule and were not written, with the
+	// rules they failed. ValidationWarnings lists employees synced despite failing a rule
+	// of "warning" severity.
+	Invalid            []ValidationEntry `json:"invalid"`
+	ValidationWarnings []ValidationEntry `json:"validationWarnings"`
+
+	// WriteBacks lists employees whose object key was stored in Paycor (WRITE_BACK_OBJECT_KEY),
+	// or would have been in a dry run. Employees whose field already held the key are left out.
+	// WriteBackFailures lists employees whose object key could not be stored.
+	WriteBacks        []ReportEntry `json:"writeBacks"`
+	WriteBackFailures []ReportEntry `json:"writeBackFailures"`
+
+	// PaycorRetries and JiraRetries count the request retries each client made during the run.
+	PaycorRetries int `json:"paycorRetries"`
+	JiraRetries   int `json:"jiraRetries"`
+
+	// JiraRateLimitLowest is the lowest X-RateLimit-Remaining Jira reported during the run, or -1
+	// if it sent none, and JiraRateLimitWaits counts the requests held back for the window to reset.
+	JiraRateLimitLowest int `json:"jiraRateLimitLowest"`
+	JiraRateLimitWaits  int `json:"jiraRateLimitWaits"`
+
+	// RolesFound counts the job titles matched to an existing Role, and RolesCreated the roles
+	// created for titles that had none.
+	RolesFound   int `json:"rolesFound"`
+	RolesCreated int `json:"rolesCreated"`
+
+	// LocationsFound counts the work locations matched to an existing Location, and
+	// LocationsCreated the locations created for ones that had none. Both stay 0 unless the
+	// attribute map has a "Location" attribute.
+	LocationsFound   int `json:"locationsFound"`
+	LocationsCreated int `json:"locationsCreated"`
+
+	// ChangeComments counts the change comments posted on assets (JIRA_CHANGE_COMMENTS), and
+	// ChangeCommentsSkipped the ones withheld because too many assets changed in the run.
+	ChangeComments        int `json:"changeComments"`
+	ChangeCommentsSkipped int `json:"changeCommentsSkipped"`
+
+	// Suspensions lists every attempt to suspend a terminated employee's Atlassian account
+	// (ENABLE_ACCOUNT_SUSPENSION), including those refused by the per-run cap.
+	// SuspensionsSkipped counts terminated employees with no active account to suspend.
+	Suspensions        []SuspensionEntry `json:"suspensions"`
+	SuspensionsSkipped int               `json:"suspensionsSkipped"`
+
+	// DebugBundles lists the debug bundles written for employees in SYNC_DEBUG_EMAILS. Detail is
+	// the bundle's path.
+	DebugBundles []ReportEntry `json:"debugBundles,omitempty"`
+
+	// FollowUpIssues lists the Jira issues created or commented on for the failures above.
+	FollowUpIssues []string `json:"followUpIssues,omitempty"`
+}
+
+// Phases of an employee's sync, recorded with each EmployeeSyncError.
+const (
+	PhaseName      = "name"       // Composing the display name
+	PhaseRole      = "role"       // Finding or creating the Job Role
+	PhaseLocation  = "location"   // Finding or creating the Location
+	PhaseCreate    = "create"     // Creating the asset
+	PhaseUpdate    = "update"     // Updating the asset
+	PhaseWriteBack = "write-back" // Storing the object key in Paycor
+)
+
+// EmployeeSyncError is one failure while syncing an employee. Errors in the name, create and
+// update phases mean the employee was not synced; role and write-back errors don't.
+type EmployeeSyncError struct {
+	EmployeeID string `json:"employeeId"`
+	Email      string `json:"email"`
+	Phase      string `json:"phase"`
+	Err        string `json:"error"`
+}
+
+// recordError adds an employee's failure in a phase to Errors.
+func (r *SyncReport) recordError(emp models.Employee, phase string, err error) {
+	r.Errors = append(r.Errors, EmployeeSyncError{EmployeeID: emp.ID, Email: emp.Email.EmailAddress, Phase: phase, Err: err.Error()})
+}
+
+// errorsByPhase counts Errors per phase.
+func (r *SyncReport) errorsByPhase() map[string]int {
+	counts := make(map[string]int)
+	for _, syncErr := range r.Errors {
+		counts[syncErr.Phase]++
+	}
+	return counts
+}
+
+// MissingOptionEntry is a select value that needs adding to a Jira attribute.
+type MissingOptionEntry struct {
+	EmployeeID string `json:"employeeId"`
+	Email      string `json:"email"`
+	Operation  string `json:"operation"` // "create" or "update"
+	Attribute  string `json:"attribute"`
+	Value      string `json:"value"`
+}
+
+// recordMissingOptions adds any missing select options carried by err to the report.
+func (r *SyncReport) recordMissingOptions(entry ReportEntry, operation string, err error) {
+	var missingErr *jira.MissingOptionError
+	if !errors.As(err, &missingErr) {
+		return
+	}
+	for _, option := range missingErr.Options {
+		r.MissingOptions = append(r.MissingOptions, MissingOptionEntry{
+			EmployeeID: entry.EmployeeID,
+			Email:      entry.Email,
+			Operation:  operation,
+			Attribute:  option.Attribute,
+			Value:      option.Value,
+		})
+	}
+}
+
+// roleReferenced drops a role from OrphanRoles once another employee's asset references it.
+func (r *SyncReport) roleReferenced(roleKey string) {
+	for i, entry := range r.OrphanRoles {
+		if entry.ObjectKey == roleKey {
+			r.OrphanRoles = append(r.OrphanRoles[:i], r.OrphanRoles[i+1:]...)
+			return
+		}
+	}
+}
+
+// LogSummary writes the report counts, the rehires and, for dry runs, every pending change to the log.
+func (r *SyncReport) LogSummary() {
+	scope := "all"
+	if len(r.Fields) > 0 {
+		scope = strings.Join(r.Fields, ", ")
+	}
+	if r.DeadlineExceeded {
+		log.Println("WARN: [Syncer] The run hit its time budget and is incomplete.")
+	}
+	if r.LimitReached {
+		log.Printf("WARN: [Syncer] The run was limited to %d employees and is incomplete.", r.Limit)
+	}
+	log.Printf("INFO: [Syncer] Run %s summary (target=%s, paycor=%s, dryRun=%t, fields in scope: %s): fetched=%d filtered=%d excluded=%d resumed=%d created=%d updated=%d unchanged=%d rehired=%d nameChanges=%d suppressed=%d failed=%d decodeFailures=%d invalid=%d degraded=%d orphanRoles=%d roles=found:%d/created:%d mappingErrors=%d missingOptions=%d retries=paycor:%d/jira:%d rateLimit=lowest:%d/waits:%d duration=%v",
+		r.RunID, r.Target, r.Paycor, r.DryRun, scope, r.Fetched, r.Filtered, r.Excluded, r.Resumed, len(r.Created), len(r.Updated), len(r.Unchanged), len(r.Rehired), len(r.NameChanges), len(r.Suppressed), len(r.Failed), len(r.DecodeFailures), len(r.Invalid), len(r.Degraded), len(r.OrphanRoles), r.RolesFound, r.RolesCreated, len(r.MappingErrors), len(r.MissingOptions), r.PaycorRetries, r.JiraRetries, r.JiraRateLimitLowest, r.JiraRateLimitWaits, r.FinishedAt.Sub(r.StartedAt))
+	if r.LocationsFound > 0 || r.LocationsCreated > 0 {
+		log.Printf("INFO: [Syncer] Locations: found=%d created=%d", r.LocationsFound, r.LocationsCreated)
+	}
+	if r.DryRun {
+		for _, entry := range append(append([]ReportEntry{}, r.Updated...), r.Rehired...) {
+			for _, change := range entry.Changes {
+				log.Printf("INFO: [Syncer] DRY RUN change for %s (%s): %s", entry.Email, entry.ObjectKey, change)
+			}
+		}
+	}
+	for _, entry := range r.Rehired {
+		log.Printf("INFO: [Syncer] Rehired: employee %s (%s) asset %s %s", entry.EmployeeID, entry.Email, entry.ObjectKey, entry.Detail)
+	}
+	for _, entry := range r.NameChanges {
+		log.Printf("INFO: [Syncer] Name change: employee %s (%s) asset %s %s", entry.EmployeeID, entry.Email, entry.ObjectKey, entry.Detail)
+	}
+	for _, entry := range r.MissingOptions {
+		log.Printf("WARN: [Syncer] Missing select option on %s for employee %s (%s): %s = '%s'", entry.Operation, entry.EmployeeID, entry.Email, entry.Attribute, entry.Value)
+	}
+	for _, entry := range r.Suppressed {
+		for _, change := range entry.Changes {
+			log.Printf("INFO: [Syncer] Suppressed by write policy for %s (%s): %s", entry.Email, entry.ObjectKey, change)
+		}
+	}
+	if len(r.Errors) > 0 {
+		counts := r.errorsByPhase()
+		phases := make([]string, 0, len(counts))
+		for phase, count := range counts {
+			phases = append(phases, fmt.Sprintf("%s=%d", phase, count))
+		}
+		sort.Strings(phases)
+		log.Printf("WARN: [Syncer] %d employee errors by phase: %s", len(r.Errors), strings.Join(phases, " "))
+	}
+	for _, entry := range r.Degraded {
+		log.Printf("WARN: [Syncer] Synced degraded: employee %s (%s): %s", entry.EmployeeID, entry.Email, entry.Detail)
+	}
+	for _, entry := range r.OrphanRoles {
+		log.Printf("WARN: [Syncer] Orphan role: %s %s, created for employee %s (%s)", entry.ObjectKey, entry.Detail, entry.EmployeeID, entry.Email)
+	}
+	for _, entry := range r.Suspensions {
+		level := "INFO"
+		if entry.Outcome == SuspensionRefused || entry.Outcome == SuspensionFailed {
+			level = "WARN"
+		}
+		log.Printf("%s: [Syncer] Account suspension %s: employee %s (%s) account %s %s", level, entry.Outcome, entry.EmployeeID, entry.Email, entry.AccountID, entry.Detail)
+	}
+	if len(r.FollowUpIssues) > 0 {
+		log.Printf("INFO: [Syncer] Follow-up issues for run %s: %s", r.RunID, strings.Join(r.FollowUpIssues, ", "))
+	}
+	for _, failure := range r.DecodeFailures {
+		log.Printf("WARN: [Syncer] Paycor record %d on page %d (employee %s) could not be decoded: %s", failure.Index, failure.Page, failure.EmployeeID, failure.Err)
+	}
+	for _, entry := range r.Invalid {
+		log.Printf("WARN: [Syncer] Invalid, not synced: employee %s (%s): %s", entry.EmployeeID, entry.Email, strings.Join(entry.Failures, "; "))
+	}
+	for _, entry := range r.ValidationWarnings {
+		log.Printf("INFO: [Syncer] Validation warning: employee %s (%s): %s", entry.EmployeeID, entry.Email, strings.Join(entry.Failures, "; "))
+	}
+	if len(r.WriteBacks) > 0 {
+		verb := "written"
+		if r.DryRun {
+			verb = "would be written"
+		}
+		log.Printf("INFO: [Syncer] Paycor write-back: %d object keys %s.", len(r.WriteBacks), verb)
+	}
+	for _, entry := range r.DebugBundles {
+		log.Printf("INFO: [Syncer] Debug bundle for employee %s (%s): %s", entry.EmployeeID, entry.Email, entry.Detail)
+	}
+	for _, entry := range r.WriteBackFailures {
+		log.Printf("WARN: [Syncer] Paycor write-back failed for employee %s (%s) asset %s: %s", entry.EmployeeID, entry.Email, entry.ObjectKey, entry.Detail)
+	}
+	for _, entry := range r.MappingErrors {
+		log.Printf("WARN: [Syncer] Mapping error: employee %s (%s): %s", entry.EmployeeID, entry.Email, entry.Detail)
+	}
+}
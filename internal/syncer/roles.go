@@ -0,0 +1,91 @@
+// internal/syncer/roles.go
+
+package syncer
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// objectKeyCache maps names to object keys for the run, so each name is looked up in Jira once.
+// It holds job titles to Role keys, or work location names to Location keys. A name cached with
+// an empty key is known to have no object yet.
+type objectKeyCache struct {
+	mu      sync.Mutex
+	keys    map[string]string // Lowercased name to object key
+	found   int               // Names matched to an existing object
+	created int               // Objects created for names with none
+}
+
+func newObjectKeyCache() *objectKeyCache {
+	return &objectKeyCache{keys: make(map[string]string)}
+}
+
+// get returns the cached key for a name, and whether the name is cached at all.
+func (c *objectKeyCache) get(name string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key, ok := c.keys[strings.ToLower(name)]
+	return key, ok
+}
+
+// set caches the key for a name. An empty key records that the name has no object.
+func (c *objectKeyCache) set(name, objectKey string, created bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keys[strings.ToLower(name)] = objectKey
+	switch {
+	case created:
+		c.created++
+	case objectKey != "":
+		c.found++
+	}
+}
+
+// counts returns how many names were found and how many objects were created.
+func (c *objectKeyCache) counts() (found, created int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.found, c.created
+}
+
+// warmRoles resolves the job titles of a page of employees in batches before the page is
+// synced, turning a lookup per employee into a few AQL queries per page. Titles without a role
+// are cached as missing so they're created without another lookup when first needed. If a
+// batch fails the titles are left uncached and looked up one by one as before.
+func (s *Syncer) warmRoles(ctx context.Context, employees []models.Employee) {
+	now := time.Now()
+	seen := make(map[string]bool)
+	var titles []string
+	for _, emp := range employees {
+		if !s.cfg.Features.IncludeTerminated && isTerminated(emp, now, s.cfg.Paycor.PaycorTimezone) || !s.filter.matches(emp) {
+			continue
+		}
+		title := emp.PositionData.JobTitle
+		if title == "" || seen[strings.ToLower(title)] {
+			continue
+		}
+		seen[strings.ToLower(title)] = true
+		if _, cached := s.roles.get(title); !cached {
+			titles = append(titles, title)
+		}
+	}
+	if len(titles) == 0 {
+		return
+	}
+
+	found, err := s.jiraClient.FindRoles(ctx, titles)
+	if err != nil {
+		log.Printf("WARN: Failed to look up %d job titles in one pass; roles will be looked up per employee: %v", len(titles), err)
+		return
+	}
+	for _, title := range titles {
+		s.roles.set(title, found[strings.ToLower(title)], false)
+	}
+	log.Printf("INFO: Role cache warmed: %d of %d new job titles have a Role in Jira; the rest will be created as needed.", len(found), len(titles))
+}
@@ -0,0 +1,93 @@
+// internal/syncer/suspension.go
+
+package syncer
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+	"github.com/Devon-ODell/PSDIv0.2/internal/orgadmin"
+)
+
+// Suspension outcomes recorded in the report besides those of orgadmin.SuspendUserByEmail.
+const (
+	SuspensionRefused = "refused" // The per-run cap (ACCOUNT_SUSPENSION_MAX_PER_RUN) was reached
+	SuspensionFailed  = "failed"
+)
+
+// SuspensionEntry is one attempt to suspend a terminated employee's Atlassian account.
+type SuspensionEntry struct {
+	EmployeeID string `json:"employeeId"`
+	Email      string `json:"email"`
+	AccountID  string `json:"accountId,omitempty"`
+	Outcome    string `json:"outcome"` // suspended, would-suspend, refused or failed
+	Detail     string `json:"detail,omitempty"`
+}
+
+// suspendAccount suspends the Atlassian account of a terminated employee when
+// ENABLE_ACCOUNT_SUSPENSION is set. It is only called after the run has flipped the employee's
+// asset to terminated, so long-gone employees cost no admin API calls. Accounts already suspended or
+// deactivated, and employees with no account, are only counted. Once
+// ACCOUNT_SUSPENSION_MAX_PER_RUN suspensions were attempted in a run, or would have been in a
+// dry run, the rest are refused without calling the API: a mass termination in Paycor is more
+// likely a data error than a layoff.
+func (s *Syncer) suspendAccount(ctx context.Context, emp models.Employee, report *SyncReport) {
+	if s.orgAdmin == nil {
+		return
+	}
+	email := strings.TrimSpace(emp.Email.EmailAddress)
+	if email == "" {
+		return
+	}
+	entry := SuspensionEntry{EmployeeID: emp.ID, Email: email}
+	if attempted := report.suspensionAttempts(); attempted >= s.cfg.OrgAdmin.AccountSuspensionMaxRun {
+		log.Printf("WARN: Not suspending the Atlassian account of employee %s (%s): %d suspensions were already attempted this run (ACCOUNT_SUSPENSION_MAX_PER_RUN).", emp.ID, email, attempted)
+		entry.Outcome = SuspensionRefused
+		report.Suspensions = append(report.Suspensions, entry)
+		return
+	}
+
+	result, err := s.orgAdmin.SuspendUserByEmail(ctx, email, s.opts.DryRun)
+	entry.AccountID = result.AccountID
+	if err != nil {
+		log.Printf("ERROR: Failed to suspend the Atlassian account of employee %s (%s): %v", emp.ID, email, err)
+		entry.Outcome = SuspensionFailed
+		entry.Detail = err.Error()
+		report.Suspensions = append(report.Suspensions, entry)
+		return
+	}
+	switch result.Outcome {
+	case orgadmin.SuspendOutcomeNotFound, orgadmin.SuspendOutcomeAlreadyInactive:
+		report.SuspensionsSkipped++
+		return
+	}
+	entry.Outcome = result.Outcome
+	report.Suspensions = append(report.Suspensions, entry)
+}
+
+// suspensionAttempts counts the suspensions attempted so far in the run, including failed and
+// dry-run ones but not refused ones.
+func (r *SyncReport) suspensionAttempts() int {
+	attempts := 0
+	for _, entry := range r.Suspensions {
+		if entry.Outcome != SuspensionRefused {
+			attempts++
+		}
+	}
+	return attempts
+}
+
+// wroteTerminatedStatus reports whether any of the update entries changed Status to a
+// terminated value, in Jira or, in a dry run, in the plan.
+func wroteTerminatedStatus(updated []ReportEntry) bool {
+	for _, entry := range updated {
+		for _, change := range entry.Changes {
+			if change.Attribute == "Status" && len(change.New) > 0 && terminatedJiraStatuses[strings.ToLower(strings.TrimSpace(change.New[0]))] {
+				return true
+			}
+		}
+	}
+	return false
+}
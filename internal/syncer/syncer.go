@@ -0,0 +1,979 @@
+// internal/syncer/syncer.go
+
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/jira"
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+	"github.com/Devon-ODell/PSDIv0.2/internal/orgadmin"
+	"github.com/Devon-ODell/PSDIv0.2/internal/paycor"
+)
+
+// The Jira "Status" values written for active and terminated employees.
+const (
+	jiraStatusActive     = "Active"
+	jiraStatusTerminated = "Terminated"
+)
+
+// terminatedJiraStatuses are the Jira "Status" values that mean the employee has left.
+var terminatedJiraStatuses = map[string]bool{
+	"terminated": true,
+	"inactive":   true,
+}
+
+// Options control how a sync run behaves.
+type Options struct {
+	DryRun bool     // Log what would change without writing anything to Jira
+	Fields []string // Attribute names that updates are restricted to; creates always send the full mapped set
+	Resume bool     // Skip employees an interrupted run already completed, per its checkpoint
+	Limit  int      // Stop after syncing this many employees, for smoke tests; 0 means no limit
+}
+
+// Syncer runs the Paycor to Jira Assets employee sync.
+type Syncer struct {
+	cfg          *config.AppConfig
+	paycorClient *paycor.Client
+	jiraClient   *jira.Client
+	opts         Options
+	fields       map[string]bool // opts.Fields as a set; empty means all attributes
+	filter       *employeeFilter // Parsed SYNC_FILTER; nil syncs everyone
+	nameTemplate *template.Template
+	onboarding   *template.Template // Rehire onboarding issue description
+	validator    *employeeValidator
+	orgAdmin     *orgadmin.Client // Suspends terminated employees' Atlassian accounts; nil unless ENABLE_ACCOUNT_SUSPENSION is set
+
+	// schema holds the Employee object type's attribute definitions.
+	schema *models.AttributeSchema
+
+	// roles caches job title to Role key lookups for the run; see warmRoles.
+	roles *objectKeyCache
+
+	// locations caches work location name to Location key lookups for the run; see syncLocations.
+	// It is nil unless the attribute map has a "Location" attribute.
+	locations *objectKeyCache
+
+	// comments holds the change comments queued during the run; see postChangeComments.
+	comments []changeComment
+
+	// managerNames maps Paycor employee IDs seen so far in the run to their display name,
+	// so a manager can be named consistently with their own asset.
+	managerNames map[string]string
+}
+
+// New creates a Syncer from already initialized Paycor and Jira clients.
+// It returns an error if opts.Fields names an attribute that isn't in the attribute map,
+// or if SYNC_FILTER, the display name or onboarding description template, or the validation
+// rules can't be parsed. A Last Synced, Managed By or rehire date attribute missing from the map is an error too,
+// as is an incomplete Atlassian admin configuration when ENABLE_ACCOUNT_SUSPENSION is set, or a
+// "Location" attribute without JIRA_LOCATION_OBJECT_TYPE_ID and the Location Name attribute ID.
+func New(cfg *config.AppConfig, paycorClient *paycor.Client, jiraClient *jira.Client, opts Options) (*Syncer, error) {
+	opts.DryRun = opts.DryRun || cfg.Features.DryRun
+	fields := make(map[string]bool, len(opts.Fields))
+	for _, field := range opts.Fields {
+		if _, ok := models.AttributeID[field]; !ok {
+			return nil, fmt.Errorf("unknown attribute '%s' in fields; it must be a name from the attribute map", field)
+		}
+		fields[field] = true
+	}
+	filter, err := parseFilter(cfg.SyncFilter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SYNC_FILTER: %w", err)
+	}
+	nameTemplate, err := parseNameTemplate(cfg.Jira)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JIRA_NAME_TEMPLATE: %w", err)
+	}
+	onboarding, err := parseOnboardingTemplate(cfg.Jira.JiraOnboardingTemplatePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JIRA_ONBOARDING_TEMPLATE_PATH: %w", err)
+	}
+	validator, err := newEmployeeValidator(cfg.SyncValidationRules, cfg.Paycor.PaycorTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SYNC_VALIDATION_RULES_PATH: %w", err)
+	}
+	var orgAdmin *orgadmin.Client
+	if cfg.OrgAdmin.AccountSuspensionEnabled {
+		if orgAdmin, err = orgadmin.NewClient(cfg.OrgAdmin); err != nil {
+			return nil, fmt.Errorf("failed to initialize Atlassian admin client: %w", err)
+		}
+	}
+	for _, marker := range []struct{ setting, name string }{
+		{"JIRA_LAST_SYNCED_ATTRIBUTE", cfg.Jira.JiraLastSyncedAttribute},
+		{"JIRA_MANAGED_BY_ATTRIBUTE", cfg.Jira.JiraManagedByAttribute},
+		{"JIRA_REHIRE_DATE_ATTRIBUTE", cfg.Jira.JiraRehireDateAttribute},
+	} {
+		if _, ok := models.AttributeID[marker.name]; marker.name != "" && !ok {
+			return nil, fmt.Errorf("%s '%s' is not in the attribute map", marker.setting, marker.name)
+		}
+	}
+
+	var locations *objectKeyCache
+	if _, ok := models.AttributeID["Location"]; ok {
+		if cfg.Jira.JiraLocationObjectTypeID == "" {
+			return nil, fmt.Errorf("the attribute map has a 'Location' attribute but JIRA_LOCATION_OBJECT_TYPE_ID is not set")
+		}
+		if _, ok := models.AttributeID[models.LocationNameAttribute]; !ok {
+			return nil, fmt.Errorf("the attribute map has a 'Location' attribute but no '%s' entry; run cmd/schemaBootstrap with JIRA_LOCATION_OBJECT_TYPE_ID set", models.LocationNameAttribute)
+		}
+		locations = newObjectKeyCache()
+	}
+
+	return &Syncer{
+		cfg:          cfg,
+		paycorClient: paycorClient,
+		jiraClient:   jiraClient,
+		opts:         opts,
+		fields:       fields,
+		filter:       filter,
+		nameTemplate: nameTemplate,
+		onboarding:   onboarding,
+		validator:    validator,
+		orgAdmin:     orgAdmin,
+		roles:        newObjectKeyCache(),
+		locations:    locations,
+		managerNames: make(map[string]string),
+	}, nil
+}
+
+// RunSync streams every employee from Paycor and creates or updates the matching Jira asset.
+// Employees are processed page by page while the next Paycor page is being fetched.
+// Individual employee failures are recorded in the report and do not stop the run.
+func (s *Syncer) RunSync(ctx context.Context) (*SyncReport, error) {
+	startedAt := time.Now().UTC()
+	report := &SyncReport{RunID: startedAt.UTC().Format("20060102T150405Z"), Target: s.cfg.Jira.JiraTarget, Paycor: s.cfg.Paycor.PaycorEnvironmentLabel(), StartedAt: startedAt, Status: RunStatusRunning, DryRun: s.opts.DryRun, Fields: s.opts.Fields}
+	s.pruneHistory()
+	s.writeReport(report)
+	// Clients are created per run, so their retry counts are this run's.
+	defer func() {
+		// Every successful return sets the final status; anything still running failed.
+		if report.Status == RunStatusRunning {
+			report.Status = RunStatusFailed
+		}
+		report.FinishedAt = time.Now().UTC()
+		report.PaycorRetries = s.paycorClient.RetriesUsed()
+		report.JiraRetries = s.jiraClient.RetriesUsed()
+		_, report.JiraRateLimitLowest, report.JiraRateLimitWaits = s.jiraClient.RateLimitStats()
+		report.RolesFound, report.RolesCreated = s.roles.counts()
+		if s.locations != nil {
+			report.LocationsFound, report.LocationsCreated = s.locations.counts()
+		}
+		s.writeReport(report)
+		if s.opts.DryRun {
+			s.writeDryRunDiff(report)
+		}
+	}()
+
+	if s.opts.DryRun {
+		log.Println("INFO: DRY RUN: No changes will be written to Jira.")
+	}
+	if !s.cfg.Features.IncludeTerminated {
+		log.Println("INFO: Terminated employees will be skipped unless their asset still shows them as active. Set SYNC_INCLUDE_TERMINATED=true for a full historical sync.")
+	}
+	if len(s.fields) > 0 {
+		log.Printf("INFO: Updates are restricted to fields: %s", strings.Join(s.opts.Fields, ", "))
+	}
+	if s.filter != nil {
+		report.Filter = s.filter.expression
+		log.Printf("INFO: Only employees matching the filter '%s' will be synced.", s.filter.expression)
+	}
+	if s.opts.Limit > 0 {
+		report.Limit = s.opts.Limit
+		log.Printf("WARN: LIMIT ACTIVE: Only the first %d employees will be synced. This is a partial run, not a full sync.", s.opts.Limit)
+	}
+
+	// =========================================================================
+	// Jira Preparation
+	// =========================================================================
+	log.Println("INFO: Beginning Jira integration phase...")
+
+	// Load the Employee attribute definitions so values can be formatted for their attribute type.
+	if err := s.loadSchema(ctx); err != nil {
+		return report, err
+	}
+	s.syncLocations(ctx)
+
+	// 1. Fetch all existing Employee Assets from Jira
+	// This is done once to avoid making a request for every single employee in the loop.
+	log.Println("INFO: Fetching all existing employee assets from Jira for comparison...")
+	existingJiraAssets, err := s.jiraClient.GetAllEmployeeAssets(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to get employee assets from Jira: %w", err)
+	}
+	log.Printf("INFO: Found %d existing employee assets in Jira.", len(existingJiraAssets))
+
+	// 2. Create a map for efficient lookups using the employee's normalized email as a unique key.
+	jiraAssetsMap := make(map[string]models.EmployeeAssets)
+	for _, asset := range existingJiraAssets {
+		if email := models.NormalizeEmail(s.schema.GetAttributeValue(asset, "Email"), s.cfg.Features.StripEmailPlusTags); email != "" {
+			jiraAssetsMap[email] = asset
+		}
+	}
+
+	// =========================================================================
+	// Paycor Extraction and Sync
+	// =========================================================================
+	// 3. Stream Paycor employees and sync each page to Jira as it arrives.
+	log.Println("INFO: Streaming employees from Paycor and syncing each to Jira...")
+	startTime := time.Now()
+
+	// The run budget only bounds the Paycor fetch and when the next employee may start.
+	// Jira writes use the caller's ctx, so the employee in progress is always finished.
+	fetchCtx := ctx
+	if s.cfg.SyncMaxDuration > 0 {
+		var cancel context.CancelFunc
+		fetchCtx, cancel = context.WithTimeout(ctx, s.cfg.SyncMaxDuration-time.Since(report.StartedAt))
+		defer cancel()
+	}
+	overBudget := func() bool {
+		return fetchCtx.Err() != nil && ctx.Err() == nil
+	}
+
+	cp, err := s.openCheckpoint(report.StartedAt)
+	if err != nil {
+		return report, err
+	}
+	completed := false
+	if cp != nil {
+		// Keep whatever progress was made unless the run got all the way through.
+		defer func() {
+			if completed {
+				cp.remove()
+			} else {
+				cp.flush()
+			}
+		}()
+	}
+
+	// Reaching the limit cancels the stream so no further Paycor pages are requested.
+	streamCtx, stopStream := context.WithCancel(fetchCtx)
+	defer stopStream()
+
+	var dump *employeeDump
+	if s.cfg.DebugDumpPath != "" {
+		dump = openEmployeeDump(s.cfg.DebugDumpPath)
+		defer dump.close()
+	}
+	processed, synced := 0, 0
+	progress := newProgress(s.cfg.SyncChunkSize, startTime)
+pages:
+	for page := range s.paycorClient.FetchEmployees(streamCtx) {
+		report.DecodeFailures = append(report.DecodeFailures, page.DecodeFailures...)
+		if page.Err != nil {
+			if overBudget() {
+				break
+			}
+			return report, fmt.Errorf("failed to fetch employees from Paycor: %w", page.Err)
+		}
+		report.Fetched += len(page.Employees)
+		if page.TotalCount > 0 {
+			progress.total = page.TotalCount
+		}
+		for _, emp := range page.Employees {
+			if name, err := composeName(s.nameTemplate, emp); err == nil {
+				s.managerNames[emp.ID] = name
+			}
+		}
+		dump.write(page.Employees)
+		s.warmRoles(ctx, page.Employees)
+
+		for _, emp := range page.Employees {
+			if overBudget() {
+				break pages
+			}
+			if s.opts.Limit > 0 && synced >= s.opts.Limit {
+				report.LimitReached = true
+				stopStream()
+				break pages
+			}
+			processed++
+			if s.processEmployee(ctx, emp, cp, jiraAssetsMap, report) {
+				synced++
+			}
+			if progress.advance(processed) {
+				progress.log(processed, report)
+				if cp != nil {
+					cp.flush()
+				}
+				s.writeReport(report)
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return report, fmt.Errorf("sync was cancelled: %w", err)
+	}
+	if overBudget() {
+		report.DeadlineExceeded = true
+		log.Printf("WARN: Run budget of %v (SYNC_MAX_DURATION) exhausted. Stopped after processing %d of %d fetched employees; the rest will be picked up by the next run.",
+			s.cfg.SyncMaxDuration, processed, report.Fetched)
+	}
+	log.Printf("INFO: Fetched and processed %d employees from Paycor in %v.", report.Fetched, time.Since(startTime))
+	if report.Filtered > 0 {
+		log.Printf("INFO: Skipped %d terminated employees.", report.Filtered)
+	}
+	if s.filter != nil {
+		log.Printf("INFO: Filter '%s' matched %d of %d employees; %d were excluded.", s.filter.expression, processed-report.Filtered-report.Excluded, processed-report.Filtered, report.Excluded)
+	}
+	if report.Resumed > 0 {
+		log.Printf("INFO: Skipped %d employees already completed by the interrupted run.", report.Resumed)
+	}
+	if report.LimitReached {
+		log.Printf("WARN: Stopped after %d employees because of the limit. Employees beyond it were not fetched or synced.", synced)
+	}
+	completed = !report.DeadlineExceeded && !report.LimitReached
+
+	if report.Fetched == 0 {
+		log.Println("INFO: No employees found in Paycor. Nothing was synced to Jira.")
+	}
+
+	s.postChangeComments(ctx, report)
+
+	s.fileFollowUpIssues(ctx, report)
+
+	log.Println("INFO: Jira integration phase completed.")
+	report.Status = RunStatusCompleted
+	if !completed {
+		report.Status = RunStatusIncomplete
+	}
+	return report, nil
+}
+
+// processEmployee filters and validates a single employee and, if it is in scope and valid,
+// syncs it and records it in the checkpoint. It reports whether the employee was synced,
+// successfully or not.
+func (s *Syncer) processEmployee(ctx context.Context, emp models.Employee, cp *checkpoint, jiraAssetsMap map[string]models.EmployeeAssets, report *SyncReport) bool {
+	terminated := isTerminated(emp, time.Now(), s.cfg.Paycor.PaycorTimezone)
+	offboarding := terminated && s.isOffboarding(emp, jiraAssetsMap)
+	// Skipped terminated employees still have an asset that shows them as active flipped,
+	// so the termination reaches Jira.
+	if !s.cfg.Features.IncludeTerminated && terminated && !offboarding {
+		report.Filtered++
+		return false
+	}
+	if !s.filter.matches(emp) {
+		report.Excluded++
+		return false
+	}
+	if cp != nil && s.opts.Resume && cp.done(emp) {
+		report.Resumed++
+		return false
+	}
+	outcome, failures := s.validator.validate(emp, time.Now())
+	entry := ValidationEntry{EmployeeID: emp.ID, Email: emp.Email.EmailAddress, Failures: failures}
+	switch outcome {
+	case ValidationInvalid:
+		log.Printf("WARN: Skipping employee %s (%s): invalid Paycor data: %s", emp.ID, emp.Email.EmailAddress, strings.Join(failures, "; "))
+		report.Invalid = append(report.Invalid, entry)
+		return false
+	case ValidationWarnings:
+		report.ValidationWarnings = append(report.ValidationWarnings, entry)
+	}
+	failedBefore, updatedBefore := len(report.Failed), len(report.Updated)
+	syncCtx, debug := s.startDebugCapture(ctx, emp)
+	s.syncEmployee(syncCtx, emp, jiraAssetsMap, report)
+	s.finishDebugCapture(debug, report)
+	// The account is only suspended once the asset shows the termination, so an employee whose
+	// update failed, or was held back by -fields or a write policy, keeps their access.
+	if offboarding && wroteTerminatedStatus(report.Updated[updatedBefore:]) {
+		s.suspendAccount(ctx, emp, report)
+	}
+	if cp != nil && len(report.Failed) == failedBefore {
+		cp.complete(emp, s.cfg.SyncCheckpointEvery)
+	}
+	return true
+}
+
+// writeReport writes the report so far to SYNC_REPORT_PATH and the run history, if set.
+// Failures are logged only.
+func (s *Syncer) writeReport(report *SyncReport) {
+	s.writeHistory(report)
+	if s.cfg.SyncReportPath == "" {
+		return
+	}
+	if err := writeJSONFile(s.cfg.SyncReportPath, report); err != nil {
+		log.Printf("WARN: Failed to write sync report %s: %v", s.cfg.SyncReportPath, err)
+	}
+}
+
+// managerName returns the name written to "Manager Name". When Paycor sends a ManagerID for an
+// employee already fetched in this run, that employee's display name is used; otherwise the
+// free-text manager name is normalized. Managers on a later Paycor page fall back to the
+// free-text name.
+func (s *Syncer) managerName(emp models.Employee) string {
+	if id := strings.TrimSpace(emp.PositionData.ManagerID); id != "" {
+		if name, ok := s.managerNames[id]; ok {
+			return name
+		}
+	}
+	return normalizePersonName(emp.PositionData.Manager, s.cfg.Jira.JiraManagerNameFormat)
+}
+
+// openCheckpoint returns the checkpoint for this run, or nil when checkpointing is disabled or
+// this is a dry run. With Options.Resume the interrupted run's checkpoint is continued, provided
+// it was written against the same Jira target.
+func (s *Syncer) openCheckpoint(startedAt time.Time) (*checkpoint, error) {
+	path := s.cfg.SyncCheckpointPath
+	if path == "" || s.opts.DryRun {
+		if s.opts.Resume {
+			log.Println("WARN: -resume has no effect without SYNC_CHECKPOINT_PATH, or in a dry run.")
+		}
+		return nil, nil
+	}
+	target := s.cfg.Jira.JiraTarget
+	if !s.opts.Resume {
+		return newCheckpoint(path, target, startedAt), nil
+	}
+	cp, err := loadCheckpoint(path)
+	if err != nil {
+		return nil, err
+	}
+	if cp == nil {
+		log.Printf("INFO: No checkpoint found at %s; nothing to resume, starting a full run.", path)
+		return newCheckpoint(path, target, startedAt), nil
+	}
+	// The employees it records were written to the checkpoint's target; skipping them in another
+	// would leave that target's assets unsynced.
+	if cp.Target != target {
+		return nil, fmt.Errorf("checkpoint %s belongs to a run against Jira target %s, not %s; resume with that target or delete the checkpoint",
+			path, targetName(cp.Target), targetName(target))
+	}
+	log.Printf("INFO: Resuming the run started %s: %d employees already completed.", s.displayTime(cp.RunStartedAt), len(cp.Completed))
+	return cp, nil
+}
+
+// targetName describes a Jira target for messages; the unprefixed settings have no name.
+func targetName(target string) string {
+	if target == "" {
+		return "(default)"
+	}
+	return "'" + target + "'"
+}
+
+// syncEmployee creates or updates the Jira asset for a single Paycor employee and records the outcome.
+func (s *Syncer) syncEmployee(ctx context.Context, emp models.Employee, jiraAssetsMap map[string]models.EmployeeAssets, report *SyncReport) {
+	log.Printf("INFO: Processing Paycor employee: %s %s (Email: %s)", emp.FirstName, emp.LastName, emp.Email.EmailAddress)
+	entry := ReportEntry{EmployeeID: emp.ID, Email: emp.Email.EmailAddress}
+
+	name, err := composeName(s.nameTemplate, emp)
+	if err != nil {
+		log.Printf("ERROR: %v", err)
+		entry.Detail = err.Error()
+		report.Failed = append(report.Failed, entry)
+		report.recordError(emp, PhaseName, err)
+		return
+	}
+
+	// A role that can't be resolved shouldn't drop the whole employee from the run:
+	// sync everything else, leave Job Role untouched and report the employee as degraded.
+	roleKey, roleCreated, roleErr := s.resolveRoleWithRetry(ctx, emp.PositionData.JobTitle)
+	if roleErr != nil {
+		log.Printf("ERROR: Could not find or create Jira Role for '%s'. Syncing employee %s without 'Job Role'. Error: %v", emp.PositionData.JobTitle, emp.ID, roleErr)
+		report.Degraded = append(report.Degraded, ReportEntry{
+			EmployeeID: emp.ID,
+			Email:      emp.Email.EmailAddress,
+			Detail:     fmt.Sprintf("role '%s' not resolved: %v", emp.PositionData.JobTitle, roleErr),
+		})
+		report.recordError(emp, PhaseRole, roleErr)
+	} else if roleKey == "" {
+		log.Printf("WARN: No role key was found or created for job title '%s'. The 'Job Role' field will be empty.", emp.PositionData.JobTitle)
+	}
+
+	// An unresolved location degrades the employee the same way; Location is left untouched.
+	locationKey, _, locationErr := s.resolveLocationWithRetry(ctx, emp.WorkLocation.Name)
+	if locationErr != nil {
+		log.Printf("ERROR: Could not find or create Jira Location for '%s'. Syncing employee %s without 'Location'. Error: %v", emp.WorkLocation.Name, emp.ID, locationErr)
+		report.Degraded = append(report.Degraded, ReportEntry{
+			EmployeeID: emp.ID,
+			Email:      emp.Email.EmailAddress,
+			Detail:     fmt.Sprintf("location '%s' not resolved: %v", emp.WorkLocation.Name, locationErr),
+		})
+		report.recordError(emp, PhaseLocation, locationErr)
+	}
+
+	// A role created for this employee is only referenced once their asset write succeeds with
+	// Job Role in it. If that doesn't happen the role is left orphaned and reported.
+	roleWritten := false
+	if roleCreated {
+		defer func() {
+			if roleWritten {
+				return
+			}
+			log.Printf("WARN: Role '%s' (%s) was created for employee %s, but no asset references it.", emp.PositionData.JobTitle, roleKey, emp.ID)
+			report.OrphanRoles = append(report.OrphanRoles, ReportEntry{
+				EmployeeID: emp.ID,
+				Email:      emp.Email.EmailAddress,
+				ObjectKey:  roleKey,
+				Detail:     fmt.Sprintf("role '%s'", emp.PositionData.JobTitle),
+			})
+		}()
+	}
+	markRoleWritten := func() {
+		if roleKey != "" {
+			roleWritten = true
+			report.roleReferenced(roleKey)
+		}
+	}
+
+	// Map Paycor data to the structure Jira expects
+	jiraAssetData := mapPaycorToJiraAsset(emp, name, roleKey, locationKey, s.managerName(emp), isTerminated(emp, time.Now(), s.cfg.Paycor.PaycorTimezone))
+	jiraAssetData.Attributes = append(jiraAssetData.Attributes, s.managedByAttributes()...)
+	if roleErr != nil {
+		jiraAssetData.Attributes = withoutAttribute(jiraAssetData.Attributes, "Job Role")
+	}
+
+	// Check if an asset with this email already exists in our map
+	emailKey := models.NormalizeEmail(emp.Email.EmailAddress, s.cfg.Features.StripEmailPlusTags)
+	existingAsset, exists := jiraAssetsMap[emailKey]
+	rehire := exists && isRehire(emp, s.schema.GetAttributeValue(existingAsset, "Status"))
+	if rehire {
+		log.Printf("INFO: Employee %s was terminated in Jira and is active again in Paycor (rehired %s).", emp.ID, emp.EmploymentDateData.RehireDate)
+		jiraAssetData.Attributes = append(jiraAssetData.Attributes, s.rehireDateAttributes(emp)...)
+	}
+
+	// Values are converted to their attribute's type. Ones that can't be are dropped from the
+	// payload rather than sent to Jira, and reported.
+	var problems []string
+	jiraAssetData.Attributes, problems = coerceAttributes(jiraAssetData.Attributes, s.schema, s.cfg.Paycor.PaycorTimezone)
+	for _, problem := range problems {
+		log.Printf("WARN: Mapping problem for employee %s: %s. The attribute will not be written.", emp.ID, problem)
+		report.MappingErrors = append(report.MappingErrors, ReportEntry{EmployeeID: emp.ID, Email: emp.Email.EmailAddress, Detail: problem})
+	}
+
+	if !exists {
+		// CREATE: The asset does not exist, so we create a new one.
+		if s.opts.DryRun {
+			log.Printf("INFO: DRY RUN: Would create a new Jira asset for employee %s.", emp.ID)
+			entry.Changes = diffAttributes(nil, jiraAssetData.Attributes, nil)
+			report.Created = append(report.Created, entry)
+			s.writeBackObjectKey(ctx, emp, "<new asset>", report)
+			return
+		}
+		log.Println("INFO: Employee does not exist in Jira. Creating new asset.")
+		jiraAssetData.Attributes = append(jiraAssetData.Attributes, s.lastSyncedAttributes(time.Now())...)
+		newAsset, outcome, err := s.jiraClient.CreateEmployeeAsset(ctx, jiraAssetData)
+		if err != nil {
+			log.Printf("ERROR: Failed to create Jira asset for employee %s: %v", emp.ID, err)
+			entry.Detail = err.Error()
+			report.Failed = append(report.Failed, entry)
+			report.recordMissingOptions(entry, "create", err)
+			report.recordError(emp, PhaseCreate, err)
+			return
+		}
+		markRoleWritten()
+		entry.ObjectKey = newAsset.ObjectKey
+		if outcome == jira.CreateOutcomeUpdatedExisting {
+			// Another writer created the asset since the bulk load, so this was an update.
+			log.Printf("SUCCESS: Updated existing Jira asset %s for employee %s after its create conflicted.", newAsset.ObjectKey, emp.ID)
+			entry.Detail = "create conflicted; existing asset updated"
+			report.Updated = append(report.Updated, entry)
+		} else {
+			log.Printf("SUCCESS: Successfully created new Jira asset for employee %s with key %s.", emp.ID, newAsset.ObjectKey)
+			report.Created = append(report.Created, entry)
+		}
+		s.writeBackObjectKey(ctx, emp, newAsset.ObjectKey, report)
+		return
+	}
+
+	// UPDATE: The asset already exists, so we update it if anything in scope changed.
+	entry.ObjectKey = existingAsset.ObjectKey
+	var suppressed []AttributeChange
+	jiraAssetData.Attributes, suppressed = applyWritePolicies(existingAsset.Attributes, jiraAssetData.Attributes, s.cfg.Jira.JiraWritePolicies)
+	if suppressed = filterChanges(suppressed, s.fields); len(suppressed) > 0 {
+		for _, change := range suppressed {
+			log.Printf("INFO: Asset %s: not updating %s (write policy %s).", existingAsset.ObjectKey, change, s.cfg.Jira.JiraWritePolicies[change.Attribute])
+		}
+		report.Suppressed = append(report.Suppressed, ReportEntry{EmployeeID: emp.ID, Email: emp.Email.EmailAddress, ObjectKey: existingAsset.ObjectKey, Changes: suppressed})
+	}
+	entry.Changes = diffAttributes(existingAsset.Attributes, jiraAssetData.Attributes, s.fields)
+	if len(entry.Changes) == 0 {
+		log.Printf("INFO: Jira asset %s for employee %s is already up to date.", existingAsset.ObjectKey, emp.ID)
+		report.Unchanged = append(report.Unchanged, entry)
+		// The Paycor field may still be empty, e.g. when write-back was enabled after the asset was created.
+		s.writeBackObjectKey(ctx, emp, existingAsset.ObjectKey, report)
+		return
+	}
+	for _, change := range entry.Changes {
+		log.Printf("INFO: Asset %s: %s", existingAsset.ObjectKey, change)
+	}
+	oldName := s.schema.GetAttributeValue(existingAsset, "Name")
+	nameChanged := oldName != "" && oldName != jiraAssetData.Label && s.inScope("Name")
+
+	if s.opts.DryRun {
+		log.Printf("INFO: DRY RUN: Would update Jira asset %s for employee %s.", existingAsset.ObjectKey, emp.ID)
+		s.writeBackObjectKey(ctx, emp, existingAsset.ObjectKey, report)
+	} else {
+		log.Printf("INFO: Employee exists in Jira. Updating asset ID %s.", existingAsset.ID)
+		payload := models.EmployeeAssets{Attributes: filterAttributes(jiraAssetData.Attributes, s.fields)}
+		payload.Attributes = append(payload.Attributes, s.lastSyncedAttributes(time.Now())...)
+		if err := s.jiraClient.UpdateEmployeeAsset(ctx, existingAsset.ID, payload); err != nil {
+			log.Printf("ERROR: Failed to update Jira asset for employee %s: %v", emp.ID, err)
+			entry.Detail = err.Error()
+			report.Failed = append(report.Failed, entry)
+			report.recordMissingOptions(entry, "update", err)
+			report.recordError(emp, PhaseUpdate, err)
+			return
+		}
+		log.Printf("SUCCESS: Successfully updated Jira asset for employee %s.", emp.ID)
+		if s.inScope("Job Role") {
+			markRoleWritten()
+		}
+
+		// Keep the cached asset in step with Jira so nothing later in the run sees the stale name.
+		jiraAssetsMap[emailKey] = mergeAttributes(existingAsset, jiraAssetData)
+		s.queueChangeComment(existingAsset.ID, existingAsset.ObjectKey, report.RunID, entry.Changes)
+		s.writeBackObjectKey(ctx, emp, existingAsset.ObjectKey, report)
+	}
+
+	if nameChanged {
+		log.Printf("INFO: Name change for employee %s: '%s' → '%s'.", emp.ID, oldName, jiraAssetData.Label)
+		report.NameChanges = append(report.NameChanges, ReportEntry{
+			EmployeeID: emp.ID,
+			Email:      emp.Email.EmailAddress,
+			ObjectKey:  existingAsset.ObjectKey,
+			Detail:     fmt.Sprintf("'%s' → '%s'", oldName, jiraAssetData.Label),
+		})
+	}
+
+	if !rehire {
+		report.Updated = append(report.Updated, entry)
+		return
+	}
+	entry.Detail = fmt.Sprintf("rehire date %s", emp.EmploymentDateData.RehireDate)
+	if s.opts.DryRun {
+		report.Rehired = append(report.Rehired, entry)
+		return
+	}
+	issueKey := s.createRehireOnboardingIssue(ctx, emp, existingAsset.ObjectKey)
+	if issueKey != "" {
+		entry.Detail += fmt.Sprintf(", onboarding issue %s", issueKey)
+	}
+	s.commentRehire(ctx, emp, existingAsset, report.RunID, issueKey)
+	report.Rehired = append(report.Rehired, entry)
+}
+
+// writeBackObjectKey stores the Jira object key on the Paycor employee when write-back is enabled.
+// The field is only written when it is empty or holds a different key, so unchanged employees
+// cause no Paycor writes; in dry-run mode the write is only logged and reported. Failures are
+// reported but never fail the employee, since the Jira write already succeeded.
+func (s *Syncer) writeBackObjectKey(ctx context.Context, emp models.Employee, objectKey string, report *SyncReport) {
+	if !s.cfg.Paycor.PaycorWriteBackObjectKey || objectKey == "" {
+		return
+	}
+	field := s.cfg.Paycor.PaycorObjectKeyField
+	current, _ := emp.CustomFieldValue(field)
+	if current == objectKey {
+		return
+	}
+	entry := ReportEntry{EmployeeID: emp.ID, Email: emp.Email.EmailAddress, ObjectKey: objectKey}
+	if current != "" {
+		entry.Detail = fmt.Sprintf("replaces '%s'", current)
+	}
+	if s.opts.DryRun {
+		log.Printf("INFO: DRY RUN: Would write object key %s to Paycor field '%s' on employee %s (currently '%s').", objectKey, field, emp.ID, current)
+		report.WriteBacks = append(report.WriteBacks, entry)
+		return
+	}
+	err := s.paycorClient.UpdateEmployeeCustomField(ctx, emp.ID, field, objectKey)
+	if err == nil {
+		report.WriteBacks = append(report.WriteBacks, entry)
+		return
+	}
+	if errors.Is(err, paycor.ErrReadOnlyField) {
+		log.Printf("ERROR: Paycor field '%s' is read-only; object key write-back will fail for every employee until it is made writable.", s.cfg.Paycor.PaycorObjectKeyField)
+	} else {
+		log.Printf("ERROR: Failed to write object key %s back to Paycor employee %s: %v", objectKey, emp.ID, err)
+	}
+	report.WriteBackFailures = append(report.WriteBackFailures, ReportEntry{
+		EmployeeID: emp.ID,
+		Email:      emp.Email.EmailAddress,
+		ObjectKey:  objectKey,
+		Detail:     err.Error(),
+	})
+	report.recordError(emp, PhaseWriteBack, err)
+}
+
+// displayTime formats a timestamp for logs in DISPLAY_TIMEZONE.
+func (s *Syncer) displayTime(t time.Time) string {
+	if s.cfg.DisplayTimezone == nil {
+		return t.Format(time.RFC3339)
+	}
+	return t.In(s.cfg.DisplayTimezone).Format(time.RFC3339)
+}
+
+// inScope reports whether an attribute is included in this run's updates.
+func (s *Syncer) inScope(attributeName string) bool {
+	return len(s.fields) == 0 || s.fields[attributeName]
+}
+
+// Role lookups are retried this many times, with a doubling delay, before the employee is
+// synced without a Job Role.
+const (
+	roleLookupAttempts = 3
+	roleLookupBackoff  = time.Second
+)
+
+// resolveRoleWithRetry calls resolveRole, retrying failures with backoff.
+func (s *Syncer) resolveRoleWithRetry(ctx context.Context, jobTitle string) (string, bool, error) {
+	return resolveWithRetry(ctx, "Role", jobTitle, s.resolveRole)
+}
+
+// resolveWithRetry calls resolve for name, retrying failures with backoff. kind names the
+// object type in the log.
+func resolveWithRetry(ctx context.Context, kind, name string, resolve func(context.Context, string) (string, bool, error)) (string, bool, error) {
+	delay := roleLookupBackoff
+	var err error
+	for attempt := 1; attempt <= roleLookupAttempts; attempt++ {
+		var key string
+		var created bool
+		key, created, err = resolve(ctx, name)
+		if err == nil {
+			return key, created, nil
+		}
+		if attempt == roleLookupAttempts {
+			break
+		}
+		log.Printf("WARN: %s lookup for '%s' failed (attempt %d/%d), retrying in %v: %v", kind, name, attempt, roleLookupAttempts, delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return "", false, ctx.Err()
+		}
+		delay *= 2
+	}
+	return "", false, err
+}
+
+// resolveRole returns the Role object key for a job title, and whether the role was created
+// for it. Titles are resolved through the role cache, falling back to a lookup in Jira. Outside
+// dry-run mode a missing role is created; in dry-run mode a placeholder is returned instead so
+// nothing is written.
+func (s *Syncer) resolveRole(ctx context.Context, jobTitle string) (string, bool, error) {
+	if jobTitle == "" {
+		return "", false, nil
+	}
+	roleKey, cached := s.roles.get(jobTitle)
+	if roleKey != "" {
+		return roleKey, false, nil
+	}
+	if !cached {
+		var err error
+		roleKey, err = s.jiraClient.FindRole(ctx, jobTitle)
+		if err != nil {
+			return "", false, err
+		}
+		s.roles.set(jobTitle, roleKey, false)
+		if roleKey != "" {
+			return roleKey, false, nil
+		}
+	}
+
+	if s.opts.DryRun {
+		log.Printf("INFO: DRY RUN: Would create Jira Role '%s'.", jobTitle)
+		return fmt.Sprintf("<new role: %s>", jobTitle), false, nil
+	}
+	log.Printf("INFO: No Jira Role exists for job title '%s'. Creating it.", jobTitle)
+	roleKey, err := s.jiraClient.CreateRole(ctx, jobTitle)
+	if err != nil {
+		return "", false, err
+	}
+	s.roles.set(jobTitle, roleKey, true)
+	return roleKey, true, nil
+}
+
+// loadSchema fetches the Employee object type's attribute definitions.
+func (s *Syncer) loadSchema(ctx context.Context) error {
+	attributes, err := s.jiraClient.GetObjectTypeAttributes(ctx, s.cfg.Jira.JiraEmployeeObjectTypeID)
+	if err != nil {
+		return fmt.Errorf("failed to load Employee attribute schema: %w", err)
+	}
+	s.schema = models.NewAttributeSchema(attributes)
+	return s.checkSourceKinds()
+}
+
+// isTerminated reports whether a Paycor employee has left. The status is authoritative when
+// Paycor sends one; otherwise a termination date that has already passed counts as terminated.
+// A termination date without an offset is read in loc.
+func isTerminated(emp models.Employee, now time.Time, loc *time.Location) bool {
+	if status := strings.TrimSpace(emp.StatusData.Status); status != "" {
+		return strings.EqualFold(status, "Terminated")
+	}
+	terminated, ok, err := models.ParsePaycorDate(emp.EmploymentDateData.TerminationDate, loc)
+	return err == nil && ok && !terminated.After(now)
+}
+
+// isOffboarding reports whether a terminated employee has an asset that doesn't show them as
+// terminated yet, so this run records the termination.
+func (s *Syncer) isOffboarding(emp models.Employee, jiraAssetsMap map[string]models.EmployeeAssets) bool {
+	asset, exists := jiraAssetsMap[models.NormalizeEmail(emp.Email.EmailAddress, s.cfg.Features.StripEmailPlusTags)]
+	if !exists {
+		return false
+	}
+	return !terminatedJiraStatuses[strings.ToLower(strings.TrimSpace(s.schema.GetAttributeValue(asset, "Status")))]
+}
+
+// isRehire reports whether an employee whose existing asset status is terminated
+// is active again in Paycor with a rehire date.
+func isRehire(emp models.Employee, existingStatus string) bool {
+	if emp.EmploymentDateData.RehireDate == "" {
+		return false
+	}
+	if !strings.EqualFold(emp.StatusData.Status, "Active") {
+		return false
+	}
+	return terminatedJiraStatuses[strings.ToLower(strings.TrimSpace(existingStatus))]
+}
+
+// rehireDateAttributes returns the attribute carrying the rehire date, or nothing if
+// JIRA_REHIRE_DATE_ATTRIBUTE is unset. New checks the attribute is in the map.
+func (s *Syncer) rehireDateAttributes(emp models.Employee) []models.AssetAttribute {
+	name := s.cfg.Jira.JiraRehireDateAttribute
+	if name == "" {
+		return nil
+	}
+	return []models.AssetAttribute{
+		{
+			ObjectTypeAttributeID: strconv.Itoa(models.AttributeID[name]),
+			Values:                []models.Value{{Value: emp.EmploymentDateData.RehireDate}},
+		},
+	}
+}
+
+// commentRehire records the rehire in the asset's history with a comment. Failures are logged only,
+// since the asset itself was already updated.
+func (s *Syncer) commentRehire(ctx context.Context, emp models.Employee, asset models.EmployeeAssets, runID, issueKey string) {
+	text := fmt.Sprintf("PSDI sync run %s: rehired %s (Paycor employee %s); status set back to %s", runID, emp.EmploymentDateData.RehireDate, emp.ID, jiraStatusActive)
+	if issueKey != "" {
+		text += fmt.Sprintf(", onboarding issue %s", issueKey)
+	}
+	if err := s.jiraClient.AddObjectComment(ctx, asset.ID, text); err != nil {
+		log.Printf("WARN: Failed to comment on rehired employee %s's asset %s: %v", emp.ID, asset.ObjectKey, err)
+	}
+}
+
+// managedByAttributes returns the Managed By flag marking the asset as owned by the sync, or
+// nothing if JIRA_MANAGED_BY_ATTRIBUTE is unset. It is compared like any mapped attribute, so an
+// existing asset without the flag is updated to carry it.
+func (s *Syncer) managedByAttributes() []models.AssetAttribute {
+	name := s.cfg.Jira.JiraManagedByAttribute
+	if name == "" {
+		return nil
+	}
+	return []models.AssetAttribute{
+		{
+			ObjectTypeAttributeID: strconv.Itoa(models.AttributeID[name]),
+			Values:                []models.Value{{Value: s.cfg.Jira.JiraManagedByValue}},
+		},
+	}
+}
+
+// lastSyncedAttributes returns the Last Synced timestamp, or nothing if JIRA_LAST_SYNCED_ATTRIBUTE
+// is unset. It is added to the write only after the diff, since it differs on every run and
+// would otherwise make every asset look changed.
+func (s *Syncer) lastSyncedAttributes(now time.Time) []models.AssetAttribute {
+	name := s.cfg.Jira.JiraLastSyncedAttribute
+	if name == "" {
+		return nil
+	}
+	return []models.AssetAttribute{
+		{
+			ObjectTypeAttributeID: strconv.Itoa(models.AttributeID[name]),
+			Values:                []models.Value{{Value: now.UTC().Format(models.JiraAssetsDateTimeFormat)}},
+		},
+	}
+}
+
+// isManaged reports whether an asset carries the Managed By flag. With JIRA_MANAGED_BY_ATTRIBUTE
+// unset every asset counts as managed.
+func (s *Syncer) isManaged(asset models.EmployeeAssets) bool {
+	name := s.cfg.Jira.JiraManagedByAttribute
+	if name == "" {
+		return true
+	}
+	return strings.EqualFold(strings.TrimSpace(s.schema.GetAttributeValue(asset, name)), s.cfg.Jira.JiraManagedByValue)
+}
+
+// employeeDump writes the fetched employees to a JSON array for debugging, one page at a
+// time, so the roster is never held in memory for it. A nil dump writes nothing, and a
+// failed write disables it for the rest of the run.
+type employeeDump struct {
+	path    string
+	file    *os.File
+	encoder *json.Encoder
+	written int
+}
+
+// openEmployeeDump creates the dump file at path. It returns nil, after logging why, if the
+// file can't be created.
+func openEmployeeDump(path string) *employeeDump {
+	file, err := os.Create(path)
+	if err == nil {
+		_, err = file.WriteString("[\n")
+	}
+	if err != nil {
+		log.Printf("WARN: Failed to create debug dump file '%s': %v", path, err)
+		if file != nil {
+			file.Close()
+		}
+		return nil
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	log.Printf("INFO: Saving fetched employees to %s", path)
+	return &employeeDump{path: path, file: file, encoder: encoder}
+}
+
+// write appends a page of employees to the dump.
+func (d *employeeDump) write(employees []models.Employee) {
+	if d == nil || d.file == nil {
+		return
+	}
+	for _, emp := range employees {
+		if d.written > 0 {
+			if _, err := d.file.WriteString(","); err != nil {
+				d.fail(err)
+				return
+			}
+		}
+		if err := d.encoder.Encode(emp); err != nil {
+			d.fail(err)
+			return
+		}
+		d.written++
+	}
+}
+
+// fail logs a write error and stops the dump, leaving what was written so far.
+func (d *employeeDump) fail(err error) {
+	log.Printf("WARN: Failed to write debug dump file '%s': %v", d.path, err)
+	d.file.Close()
+	d.file = nil
+}
+
+// close ends the JSON array and closes the file. A run that stops early still leaves a valid
+// dump of the employees fetched before it stopped.
+func (d *employeeDump) close() {
+	if d == nil || d.file == nil {
+		return
+	}
+	_, err := d.file.WriteString("]\n")
+	if closeErr := d.file.Close(); err == nil {
+		err = closeErr
+	}
+	d.file = nil
+	if err != nil {
+		log.Printf("WARN: Failed to write debug dump file '%s': %v", d.path, err)
+		return
+	}
+	log.Printf("INFO: Saved %d fetched employees to %s", d.written, d.path)
+}
@@ -0,0 +1,507 @@
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/jira"
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+	"github.com/Devon-ODell/PSDIv0.2/internal/orgadmin"
+	"github.com/Devon-ODell/PSDIv0.2/internal/paycor"
+)
+
+func TestMain(m *testing.M) {
+	// The syncer and the Jira client log every step; keep test output readable.
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// testJiraConfig is the Jira configuration the fake-server tests run with. The Employee
+// object type is "7".
+func testJiraConfig(assetsURL string) config.JiraConfig {
+	return config.JiraConfig{
+		JiraSiteName:               "example.atlassian.net",
+		JiraWorkspaceID:            "workspace",
+		JiraAdminEmail:             "admin@example.com",
+		JiraOrgAPIKey:              "api-key",
+		JiraAssetsURL:              assetsURL,
+		JiraEmployeeObjectTypeName: "Employees",
+		JiraEmployeeObjectTypeID:   "7",
+		JiraRoleObjectTypeName:     "Role",
+		JiraRoleObjectTypeID:       "8",
+	}
+}
+
+// newFakeJiraSyncer returns a Syncer whose Jira client talks to handler. It has no Paycor
+// client. Settings read during the sync can be changed through s.cfg afterwards.
+func newFakeJiraSyncer(t *testing.T, handler http.Handler) *Syncer {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	cfg := &config.AppConfig{Jira: testJiraConfig(server.URL)}
+	client, err := jira.NewClient(cfg.Jira)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	s, err := New(cfg, nil, client, Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return s
+}
+
+// assetWithStatus returns an asset for the email whose "Status" is status.
+func assetWithStatus(email, status string) models.EmployeeAssets {
+	return models.EmployeeAssets{
+		ObjectKey: "EMP-1",
+		Attributes: []models.AssetAttribute{
+			{ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Email"]), Values: []models.Value{{Value: email}}},
+			{ObjectTypeAttributeID: strconv.Itoa(models.AttributeID["Status"]), Values: []models.Value{{Value: status}}},
+		},
+	}
+}
+
+func TestIsOffboarding(t *testing.T) {
+	s := &Syncer{cfg: &config.AppConfig{}}
+	emp := models.Employee{ID: "1", Email: models.Email{EmailAddress: "Jane.Doe@example.com"}, StatusData: models.StatusData{Status: "Terminated"}}
+
+	tests := []struct {
+		name   string
+		assets map[string]models.EmployeeAssets
+		want   bool
+	}{
+		{name: "no asset", assets: map[string]models.EmployeeAssets{}, want: false},
+		{name: "asset still active", assets: map[string]models.EmployeeAssets{"jane.doe@example.com": assetWithStatus("jane.doe@example.com", "Active")}, want: true},
+		{name: "asset already terminated", assets: map[string]models.EmployeeAssets{"jane.doe@example.com": assetWithStatus("jane.doe@example.com", "Terminated")}, want: false},
+		{name: "asset inactive", assets: map[string]models.EmployeeAssets{"jane.doe@example.com": assetWithStatus("jane.doe@example.com", "inactive")}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.isOffboarding(emp, tt.assets); got != tt.want {
+				t.Errorf("isOffboarding() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncEmployeeWritePolicies(t *testing.T) {
+	// Paycor now reports a corrected hire date for an employee whose asset was created with the
+	// original one.
+	emp := models.Employee{
+		ID:                 "1",
+		FirstName:          "Jane",
+		LastName:           "Doe",
+		Email:              models.Email{EmailAddress: "jane.doe@example.com"},
+		EmploymentDateData: models.EmploymentDateData{HireDate: "2024-03-01"},
+		PositionData:       models.PositionData{Manager: "John Roe"},
+	}
+	attribute := func(name, value string) models.AssetAttribute {
+		return models.AssetAttribute{ObjectTypeAttributeID: strconv.Itoa(models.AttributeID[name]), Values: []models.Value{{Value: value}}}
+	}
+	upToDate := []models.AssetAttribute{
+		attribute("Name", "Jane Doe"),
+		attribute("Email", "jane.doe@example.com"),
+		attribute("Status", "Active"),
+		attribute("Manager Name", "John Roe"),
+	}
+	withStartDate := func(value string) []models.AssetAttribute {
+		return append(append([]models.AssetAttribute{}, upToDate...), attribute("Start Date", value))
+	}
+
+	tests := []struct {
+		name           string
+		policy         string
+		existing       []models.AssetAttribute
+		wantUpdate     bool
+		wantStartDate  bool // Whether the update writes Start Date
+		wantSuppressed bool
+	}{
+		{
+			name:           "create-only attribute differs",
+			policy:         config.WritePolicyCreateOnly,
+			existing:       withStartDate("2023-02-13"),
+			wantSuppressed: true,
+		},
+		{
+			name:   "create-only attribute differs alongside another change",
+			policy: config.WritePolicyCreateOnly,
+			existing: []models.AssetAttribute{
+				attribute("Name", "Jane Doe"), attribute("Email", "jane.doe@example.com"), attribute("Status", "Active"),
+				attribute("Manager Name", "Someone Else"), attribute("Start Date", "2023-02-13"),
+			},
+			wantUpdate:     true,
+			wantSuppressed: true,
+		},
+		{
+			name:           "if-empty attribute already set",
+			policy:         config.WritePolicyIfEmpty,
+			existing:       withStartDate("2023-02-13"),
+			wantSuppressed: true,
+		},
+		{
+			name:          "if-empty attribute still empty",
+			policy:        config.WritePolicyIfEmpty,
+			existing:      upToDate,
+			wantUpdate:    true,
+			wantStartDate: true,
+		},
+		{
+			name:          "always",
+			policy:        config.WritePolicyAlways,
+			existing:      withStartDate("2023-02-13"),
+			wantUpdate:    true,
+			wantStartDate: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updates []map[string][]models.AssetAttribute
+			mux := serveSchema(typedSchema).(*http.ServeMux)
+			mux.HandleFunc("PUT /object/1001", func(w http.ResponseWriter, r *http.Request) {
+				var body map[string][]models.AssetAttribute
+				json.NewDecoder(r.Body).Decode(&body)
+				updates = append(updates, body)
+				w.Write([]byte(`{"id":"1001","objectKey":"EMP-1001"}`))
+			})
+			s := newFakeJiraSyncer(t, mux)
+			s.cfg.Jira.JiraWritePolicies = map[string]string{"Start Date": tt.policy}
+			if err := s.loadSchema(context.Background()); err != nil {
+				t.Fatalf("loadSchema: %v", err)
+			}
+
+			assets := map[string]models.EmployeeAssets{
+				"jane.doe@example.com": {ID: "1001", ObjectKey: "EMP-1001", Attributes: tt.existing},
+			}
+			report := &SyncReport{}
+			s.syncEmployee(context.Background(), emp, assets, report)
+
+			if len(report.Failed) != 0 {
+				t.Fatalf("the employee failed: %+v", report.Failed)
+			}
+			if got := len(updates) > 0; got != tt.wantUpdate {
+				t.Fatalf("update sent = %t, want %t (report: %d updated, %d unchanged)", got, tt.wantUpdate, len(report.Updated), len(report.Unchanged))
+			}
+			if tt.wantUpdate {
+				startDateID := strconv.Itoa(models.AttributeID["Start Date"])
+				wrote := false
+				for _, attr := range updates[0]["attributes"] {
+					if attr.ObjectTypeAttributeID == startDateID {
+						wrote = true
+					}
+				}
+				if wrote != tt.wantStartDate {
+					t.Errorf("update wrote Start Date = %t, want %t: %+v", wrote, tt.wantStartDate, updates[0])
+				}
+			}
+
+			if got := len(report.Suppressed) > 0; got != tt.wantSuppressed {
+				t.Fatalf("suppressed changes reported = %t, want %t", got, tt.wantSuppressed)
+			}
+			if tt.wantSuppressed {
+				changes := report.Suppressed[0].Changes
+				if len(changes) != 1 || changes[0].Attribute != "Start Date" || changes[0].New[0] != "2024-03-01" {
+					t.Errorf("suppressed changes = %v, want the Start Date change to 2024-03-01", changes)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncEmployeeWritesBackUnchangedObjectKey(t *testing.T) {
+	const field = "Jira Asset Key"
+	withField := func(value string) []models.CustomField {
+		return []models.CustomField{{Name: field, Value: json.RawMessage(strconv.Quote(value))}}
+	}
+	tests := []struct {
+		name          string
+		customFields  []models.CustomField
+		dryRun        bool
+		wantWriteBack bool
+		wantPaycorPut bool
+	}{
+		{name: "field missing", wantWriteBack: true, wantPaycorPut: true},
+		{name: "field empty", customFields: withField(""), wantWriteBack: true, wantPaycorPut: true},
+		{name: "field holds another key", customFields: withField("EMP-7"), wantWriteBack: true, wantPaycorPut: true},
+		{name: "field already holds the key", customFields: withField("EMP-1001")},
+		{name: "dry run", customFields: withField(""), dryRun: true, wantWriteBack: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var puts []string
+			paycorMux := http.NewServeMux()
+			paycorMux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"access_token":"access","token_type":"Bearer","refresh_token":"refresh","expires_in":3600}`))
+			})
+			paycorMux.HandleFunc("PUT /employees/1/customfields", func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				puts = append(puts, string(body))
+			})
+			paycorServer := httptest.NewServer(paycorMux)
+			t.Cleanup(paycorServer.Close)
+
+			s := newFakeJiraSyncer(t, serveSchema(typedSchema))
+			if err := s.loadSchema(context.Background()); err != nil {
+				t.Fatalf("loadSchema: %v", err)
+			}
+			s.cfg.Paycor = config.PaycorConfig{
+				PaycorTokenURLBase:           paycorServer.URL + "/token",
+				PaycorAPIBaseURL:             paycorServer.URL,
+				PaycorClientID:               "client",
+				PaycorClientSecret:           "secret",
+				PaycorOcpApimSubscriptionKey: "subscription",
+				PaycorRefreshToken:           "refresh",
+				PaycorLegalEntityID:          "123456",
+				PaycorTokenAuthStyle:         config.PaycorTokenAuthQuery,
+				PaycorWriteBackObjectKey:     true,
+				PaycorObjectKeyField:         field,
+				HTTP:                         config.HTTPClientConfig{Timeout: 10 * time.Second},
+			}
+			paycorClient, err := paycor.NewClient(context.Background(), s.cfg.Paycor)
+			if err != nil {
+				t.Fatalf("paycor.NewClient: %v", err)
+			}
+			s.paycorClient = paycorClient
+			s.opts.DryRun = tt.dryRun
+
+			emp := models.Employee{
+				ID:           "1",
+				FirstName:    "Jane",
+				LastName:     "Doe",
+				Email:        models.Email{EmailAddress: "jane.doe@example.com"},
+				CustomFields: tt.customFields,
+			}
+			attribute := func(name, value string) models.AssetAttribute {
+				return models.AssetAttribute{ObjectTypeAttributeID: strconv.Itoa(models.AttributeID[name]), Values: []models.Value{{Value: value}}}
+			}
+			assets := map[string]models.EmployeeAssets{
+				"jane.doe@example.com": {ID: "1001", ObjectKey: "EMP-1001", Attributes: []models.AssetAttribute{
+					attribute("Name", "Jane Doe"), attribute("Email", "jane.doe@example.com"), attribute("Status", "Active"),
+				}},
+			}
+			report := &SyncReport{}
+			s.syncEmployee(context.Background(), emp, assets, report)
+
+			if len(report.Unchanged) != 1 || len(report.Failed) != 0 || len(report.WriteBackFailures) != 0 {
+				t.Fatalf("report: %d unchanged, %d failed, %d write-back failures; want the asset unchanged",
+					len(report.Unchanged), len(report.Failed), len(report.WriteBackFailures))
+			}
+			if got := len(report.WriteBacks) == 1 && report.WriteBacks[0].ObjectKey == "EMP-1001"; got != tt.wantWriteBack {
+				t.Errorf("write-backs = %+v, want EMP-1001 recorded = %t", report.WriteBacks, tt.wantWriteBack)
+			}
+			if got := len(puts) == 1; got != tt.wantPaycorPut {
+				t.Errorf("Paycor updates = %q, want one = %t", puts, tt.wantPaycorPut)
+			}
+		})
+	}
+}
+
+func TestProcessEmployeeSuspendsOnlyOnceTerminated(t *testing.T) {
+	terminated := models.Employee{
+		ID:         "1",
+		FirstName:  "Jane",
+		LastName:   "Doe",
+		Email:      models.Email{EmailAddress: "jane.doe@example.com"},
+		StatusData: models.StatusData{Status: "Terminated"},
+	}
+	tests := []struct {
+		name          string
+		employee      func() models.Employee
+		failUpdate    bool
+		fields        map[string]bool
+		dryRun        bool
+		wantSuspended bool
+		wantOutcome   string // The recorded suspension, if any
+	}{
+		{name: "asset flipped to terminated", wantSuspended: true, wantOutcome: "suspended"},
+		{name: "dry run", dryRun: true, wantOutcome: "would-suspend"},
+		{name: "update failed", failUpdate: true},
+		{name: "status outside -fields", fields: map[string]bool{"Name": true}},
+		{
+			name: "invalid Paycor data",
+			employee: func() models.Employee {
+				emp := terminated
+				emp.LastName = ""
+				return emp
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := serveSchema(typedSchema).(*http.ServeMux)
+			mux.HandleFunc("PUT /object/1001", func(w http.ResponseWriter, r *http.Request) {
+				if tt.failUpdate {
+					http.Error(w, `{"errorMessages":["rejected"]}`, http.StatusBadRequest)
+					return
+				}
+				w.Write([]byte(`{"id":"1001","objectKey":"EMP-1001"}`))
+			})
+			s := newFakeJiraSyncer(t, mux)
+			if err := s.loadSchema(context.Background()); err != nil {
+				t.Fatalf("loadSchema: %v", err)
+			}
+			s.opts.DryRun = tt.dryRun
+			if tt.fields != nil {
+				s.fields = tt.fields
+			}
+
+			suspended := false
+			admin := http.NewServeMux()
+			admin.HandleFunc("GET /admin/v1/orgs/org/users", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"data":[{"account_id":"acc-1","email":"jane.doe@example.com","account_status":"active"}]}`))
+			})
+			admin.HandleFunc("POST /admin/v1/orgs/org/directory/users/acc-1/suspend-access", func(w http.ResponseWriter, r *http.Request) {
+				suspended = true
+			})
+			adminServer := httptest.NewServer(admin)
+			t.Cleanup(adminServer.Close)
+			s.cfg.OrgAdmin = config.OrgAdminConfig{
+				AtlassianOrgID:           "org",
+				AtlassianOrgAdminToken:   "token",
+				AtlassianAdminURL:        adminServer.URL,
+				AccountSuspensionEnabled: true,
+				AccountSuspensionMaxRun:  5,
+				HTTP:                     config.HTTPClientConfig{Timeout: 10 * time.Second},
+			}
+			orgAdmin, err := orgadmin.NewClient(s.cfg.OrgAdmin)
+			if err != nil {
+				t.Fatalf("orgadmin.NewClient: %v", err)
+			}
+			s.orgAdmin = orgAdmin
+
+			emp := terminated
+			if tt.employee != nil {
+				emp = tt.employee()
+			}
+			attribute := func(name, value string) models.AssetAttribute {
+				return models.AssetAttribute{ObjectTypeAttributeID: strconv.Itoa(models.AttributeID[name]), Values: []models.Value{{Value: value}}}
+			}
+			assets := map[string]models.EmployeeAssets{
+				"jane.doe@example.com": {ID: "1001", ObjectKey: "EMP-1001", Attributes: []models.AssetAttribute{
+					attribute("Name", "Jane Doe"), attribute("Email", "jane.doe@example.com"), attribute("Status", "Active"),
+				}},
+			}
+			report := &SyncReport{}
+			s.processEmployee(context.Background(), emp, nil, assets, report)
+
+			if suspended != tt.wantSuspended {
+				t.Errorf("account suspended = %t, want %t", suspended, tt.wantSuspended)
+			}
+			var outcomes []string
+			for _, entry := range report.Suspensions {
+				outcomes = append(outcomes, entry.Outcome)
+			}
+			var want []string
+			if tt.wantOutcome != "" {
+				want = []string{tt.wantOutcome}
+			}
+			if !reflect.DeepEqual(outcomes, want) {
+				t.Errorf("suspensions recorded = %q, want %q", outcomes, want)
+			}
+		})
+	}
+}
+
+func TestEmployeeDump(t *testing.T) {
+	pages := [][]models.Employee{
+		{{ID: "1", FirstName: "Jane", LastName: "Doe"}, {ID: "2", FirstName: "John", LastName: "Roe"}},
+		{},
+		{{ID: "3", FirstName: "Ann", LastName: "Lee"}},
+	}
+	tests := []struct {
+		name    string
+		pages   int
+		wantIDs []string
+	}{
+		{name: "every page", pages: 3, wantIDs: []string{"1", "2", "3"}},
+		{name: "stopped after the first page", pages: 1, wantIDs: []string{"1", "2"}},
+		{name: "nothing fetched", pages: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := t.TempDir() + "/employees.json"
+			dump := openEmployeeDump(path)
+			if dump == nil {
+				t.Fatal("openEmployeeDump returned nil")
+			}
+			for _, page := range pages[:tt.pages] {
+				dump.write(page)
+			}
+			dump.close()
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var employees []models.Employee
+			if err := json.Unmarshal(data, &employees); err != nil {
+				t.Fatalf("the dump is not a JSON array of employees: %v\n%s", err, data)
+			}
+			var ids []string
+			for _, emp := range employees {
+				ids = append(ids, emp.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("dumped IDs = %q, want %q", ids, tt.wantIDs)
+			}
+		})
+	}
+
+	// A dump that couldn't be opened is nil, and writing to it is a no-op.
+	dump := openEmployeeDump(t.TempDir() + "/missing/employees.json")
+	if dump != nil {
+		t.Fatal("openEmployeeDump succeeded in a directory that doesn't exist")
+	}
+	dump.write(pages[0])
+	dump.close()
+}
+
+func TestOpenCheckpointResumesOnlySameTarget(t *testing.T) {
+	tests := []struct {
+		name          string
+		written       string // target of the interrupted run's checkpoint
+		current       string // target of this run
+		wantCompleted int    // employees carried over; -1 when resuming is refused
+	}{
+		{name: "same named target", written: "staging", current: "staging", wantCompleted: 1},
+		{name: "default target", written: "", current: "", wantCompleted: 1},
+		{name: "another named target", written: "staging", current: "production", wantCompleted: -1},
+		{name: "named target after the default", written: "", current: "staging", wantCompleted: -1},
+		{name: "default target after a named one", written: "staging", current: "", wantCompleted: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := t.TempDir() + "/checkpoint.json"
+			written := newCheckpoint(path, tt.written, time.Now())
+			written.complete(models.Employee{ID: "1"}, 0)
+			written.flush()
+
+			cfg := &config.AppConfig{SyncCheckpointPath: path}
+			cfg.Jira.JiraTarget = tt.current
+			s := &Syncer{cfg: cfg, opts: Options{Resume: true}}
+			cp, err := s.openCheckpoint(time.Now())
+			if tt.wantCompleted < 0 {
+				if err == nil {
+					t.Fatalf("openCheckpoint resumed %d employees written against another target", len(cp.Completed))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("openCheckpoint: %v", err)
+			}
+			if len(cp.Completed) != tt.wantCompleted || cp.Target != tt.current {
+				t.Errorf("resumed %d employees for target %q, want %d for %q", len(cp.Completed), cp.Target, tt.wantCompleted, tt.current)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// internal/syncer/validation.go
+
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+)
+
+// Outcomes of validating an employee.
+const (
+	ValidationValid    = "valid"
+	ValidationWarnings = "valid-with-warnings"
+	ValidationInvalid  = "invalid"
+)
+
+// validationFields maps the field names usable in validation rules to the Paycor value they check.
+var validationFields = map[string]func(emp models.Employee) string{
+	"firstName":       func(emp models.Employee) string { return emp.FirstName },
+	"lastName":        func(emp models.Employee) string { return emp.LastName },
+	"email":           func(emp models.Employee) string { return emp.Email.EmailAddress },
+	"employeeNumber":  func(emp models.Employee) string { return emp.EmployeeNumber },
+	"jobTitle":        func(emp models.Employee) string { return emp.PositionData.JobTitle },
+	"department":      func(emp models.Employee) string { return emp.PositionData.Department },
+	"hireDate":        func(emp models.Employee) string { return emp.EmploymentDateData.HireDate },
+	"terminationDate": func(emp models.Employee) string { return emp.EmploymentDateData.TerminationDate },
+	"rehireDate":      func(emp models.Employee) string { return emp.EmploymentDateData.RehireDate },
+}
+
+// FieldRule is the validation rule for one field. A failed rule makes the employee invalid,
+// or only adds a warning when Severity is "warning". Pattern and the date window are only
+// checked when the field has a value.
+type FieldRule struct {
+	Required      bool   `json:"required,omitempty"`
+	Pattern       string `json:"pattern,omitempty"`       // Regular expression the whole value must match
+	MaxPastYears  int    `json:"maxPastYears,omitempty"`  // Dates further back than this are rejected
+	MaxFutureDays int    `json:"maxFutureDays,omitempty"` // Dates further ahead than this are rejected
+	Severity      string `json:"severity,omitempty"`      // "error" (default) or "warning"
+
+	pattern *regexp.Regexp
+}
+
+// defaultValidationRules catch the data seen in practice: missing names, placeholder emails
+// such as "n/a", and mistyped dates decades out.
+var defaultValidationRules = map[string]FieldRule{
+	"firstName":      {Required: true},
+	"lastName":       {Required: true},
+	"email":          {Required: true, Pattern: `[^@\s]+@[^@\s]+\.[^@\s]+`},
+	"employeeNumber": {Required: true, Severity: "warning"},
+	"hireDate":       {MaxPastYears: 80, MaxFutureDays: 365},
+	"rehireDate":     {MaxPastYears: 80, MaxFutureDays: 365},
+}
+
+// employeeValidator checks employees against the rules for each field.
+type employeeValidator struct {
+	rules    map[string]FieldRule
+	location *time.Location // PAYCOR_TIMEZONE, for dates without an offset
+}
+
+// newEmployeeValidator returns a validator for the default rules, with any fields in the JSON
+// file at path (SYNC_VALIDATION_RULES_PATH) replacing their default rule. A field mapped to {}
+// disables its checks. Dates without an offset are read in loc.
+func newEmployeeValidator(path string, loc *time.Location) (*employeeValidator, error) {
+	rules := make(map[string]FieldRule, len(defaultValidationRules))
+	for field, rule := range defaultValidationRules {
+		rules[field] = rule
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read validation rules %s: %w", path, err)
+		}
+		var overrides map[string]FieldRule
+		if err := json.Unmarshal(data, &overrides); err != nil {
+			return nil, fmt.Errorf("failed to parse validation rules %s: %w", path, err)
+		}
+		for field, rule := range overrides {
+			rules[field] = rule
+		}
+	}
+
+	for field, rule := range rules {
+		if _, ok := validationFields[field]; !ok {
+			return nil, fmt.Errorf("unknown validation field '%s'", field)
+		}
+		if rule.Severity != "" && rule.Severity != "error" && rule.Severity != "warning" {
+			return nil, fmt.Errorf("validation rule for '%s' has severity '%s', expected 'error' or 'warning'", field, rule.Severity)
+		}
+		if rule.Pattern != "" {
+			pattern, err := regexp.Compile(`^(?:` + rule.Pattern + `)$`)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern for validation field '%s': %w", field, err)
+			}
+			rule.pattern = pattern
+		}
+		rules[field] = rule
+	}
+	return &employeeValidator{rules: rules, location: loc}, nil
+}
+
+// ValidationEntry is an employee that failed one or more validation rules.
+type ValidationEntry struct {
+	EmployeeID string   `json:"employeeId"`
+	Email      string   `json:"email"`
+	Failures   []string `json:"failures"` // e.g. "email: 'n/a' does not match the required pattern"
+}
+
+// validate checks an employee against every rule. It returns the outcome and the failed rules,
+// with errors listed before warnings.
+func (v *employeeValidator) validate(emp models.Employee, now time.Time) (string, []string) {
+	var errs, warnings []string
+	fields := make([]string, 0, len(v.rules))
+	for field := range v.rules {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		rule := v.rules[field]
+		failure := rule.check(strings.TrimSpace(validationFields[field](emp)), now, v.location)
+		if failure == "" {
+			continue
+		}
+		if rule.Severity == "warning" {
+			warnings = append(warnings, field+": "+failure)
+		} else {
+			errs = append(errs, field+": "+failure)
+		}
+	}
+
+	switch {
+	case len(errs) > 0:
+		return ValidationInvalid, append(errs, warnings...)
+	case len(warnings) > 0:
+		return ValidationWarnings, warnings
+	}
+	return ValidationValid, nil
+}
+
+// check returns why a value fails the rule, or "" if it passes. Dates without an offset are read in loc.
+func (rule FieldRule) check(value string, now time.Time, loc *time.Location) string {
+	if value == "" {
+		if rule.Required {
+			return "missing"
+		}
+		return ""
+	}
+	if rule.pattern != nil && !rule.pattern.MatchString(value) {
+		return fmt.Sprintf("'%s' does not match the required pattern", value)
+	}
+	if rule.MaxPastYears == 0 && rule.MaxFutureDays == 0 {
+		return ""
+	}
+	date, ok, err := models.ParsePaycorDate(value, loc)
+	if err != nil || !ok {
+		return fmt.Sprintf("'%s' is not a date", value)
+	}
+	if rule.MaxPastYears > 0 && date.Before(now.AddDate(-rule.MaxPastYears, 0, 0)) {
+		return fmt.Sprintf("%s is more than %d years in the past", date.Format("2006-01-02"), rule.MaxPastYears)
+	}
+	if rule.MaxFutureDays > 0 && date.After(now.AddDate(0, 0, rule.MaxFutureDays)) {
+		return fmt.Sprintf("%s is more than %d days in the future", date.Format("2006-01-02"), rule.MaxFutureDays)
+	}
+	return ""
+}
+
+// ValidationReport summarises a validate-only run.
+type ValidationReport struct {
+	GeneratedAt time.Time         `json:"generatedAt"`
+	Fetched     int               `json:"fetched"`
+	Filtered    int               `json:"filtered"` // Terminated employees left out
+	Excluded    int               `json:"excluded"` // Employees outside SYNC_FILTER
+	Valid       int               `json:"valid"`
+	Warnings    []ValidationEntry `json:"warnings"`
+	Invalid     []ValidationEntry `json:"invalid"`
+}
+
+// ValidateEmployees fetches every Paycor employee in scope and validates them without touching
+// Jira, for data-quality audits.
+func (s *Syncer) ValidateEmployees(ctx context.Context) (*ValidationReport, error) {
+	report := &ValidationReport{GeneratedAt: time.Now()}
+	for page := range s.paycorClient.FetchEmployees(ctx) {
+		if page.Err != nil {
+			return report, fmt.Errorf("failed to fetch employees from Paycor: %w", page.Err)
+		}
+		report.Fetched += len(page.Employees)
+		for _, emp := range page.Employees {
+			if !s.cfg.Features.IncludeTerminated && isTerminated(emp, time.Now(), s.cfg.Paycor.PaycorTimezone) {
+				report.Filtered++
+				continue
+			}
+			if !s.filter.matches(emp) {
+				report.Excluded++
+				continue
+			}
+			outcome, failures := s.validator.validate(emp, time.Now())
+			entry := ValidationEntry{EmployeeID: emp.ID, Email: emp.Email.EmailAddress, Failures: failures}
+			switch outcome {
+			case ValidationInvalid:
+				report.Invalid = append(report.Invalid, entry)
+			case ValidationWarnings:
+				report.Warnings = append(report.Warnings, entry)
+				report.Valid++
+			default:
+				report.Valid++
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return report, fmt.Errorf("validation was cancelled: %w", err)
+	}
+	return report, nil
+}
+
+// LogSummary writes the validation counts and every failed rule to the log.
+func (r *ValidationReport) LogSummary() {
+	log.Printf("INFO: [Validation] fetched=%d filtered=%d excluded=%d valid=%d withWarnings=%d invalid=%d",
+		r.Fetched, r.Filtered, r.Excluded, r.Valid, len(r.Warnings), len(r.Invalid))
+	for _, entry := range r.Invalid {
+		log.Printf("WARN: [Validation] Invalid: employee %s (%s): %s", entry.EmployeeID, entry.Email, strings.Join(entry.Failures, "; "))
+	}
+	for _, entry := range r.Warnings {
+		log.Printf("INFO: [Validation] Warning: employee %s (%s): %s", entry.EmployeeID, entry.Email, strings.Join(entry.Failures, "; "))
+	}
+}
@@ -0,0 +1,147 @@
+package psdi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Devon-ODell/PSDIv0.2/pkg/psdi"
+)
+
+func TestMain(m *testing.M) {
+	// The sync logs every step; keep the examples' output to what they print.
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func ExampleSyncer_Run() {
+	// A real caller would use psdi.LoadConfig; this configuration points at fake Paycor and
+	// Jira servers that hold two employees, one of whom already has an asset.
+	cfg, cleanup := exampleConfig()
+	defer cleanup()
+
+	ctx := context.Background()
+	s, err := psdi.New(ctx, cfg, psdi.Options{DryRun: true})
+	if err != nil {
+		fmt.Println("setup failed:", err)
+		return
+	}
+	report, err := s.Run(ctx)
+	if err != nil {
+		fmt.Println("sync failed:", err)
+		return
+	}
+
+	fmt.Printf("%d fetched, %d to create, %d to update\n", report.Fetched, len(report.Created), len(report.Updated))
+	for _, entry := range report.Updated {
+		for _, change := range entry.Changes {
+			fmt.Printf("%s %s\n", entry.ObjectKey, change)
+		}
+	}
+	// Output:
+	// 2 fetched, 1 to create, 1 to update
+	// EMP-2 Job Role: (empty) → <new role: Engineering Manager>
+}
+
+func ExampleSyncer_Run_cancelled() {
+	cfg, cleanup := exampleConfig()
+	defer cleanup()
+
+	s, err := psdi.New(context.Background(), cfg, psdi.Options{DryRun: true})
+	if err != nil {
+		fmt.Println("setup failed:", err)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// A cancelled run still returns the report for the employees processed so far, and its
+	// error wraps ctx.Err().
+	report, err := s.Run(ctx)
+	fmt.Println("cancelled:", errors.Is(err, context.Canceled))
+	fmt.Println("report returned:", report != nil)
+	// Output:
+	// cancelled: true
+	// report returned: true
+}
+
+// exampleConfig starts fake Paycor and Jira servers and returns a configuration that uses them.
+// Paycor has Jane Doe and John Roe; Jira has an asset for John only, with no Job Role, and no roles.
+func exampleConfig() (*psdi.Config, func()) {
+	paycor := http.NewServeMux()
+	paycor.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"access","token_type":"Bearer","expires_in":3600}`))
+	})
+	paycor.HandleFunc("GET /legalentities/123456/employees", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"records":[
+			{"id":"1","firstName":"Jane","lastName":"Doe","email":{"emailAddress":"jane.doe@example.com"},
+			 "positionData":{"jobTitle":"Engineer"},"employmentDateData":{"hireDate":"2023-02-13"},"statusData":{"status":"Active"}},
+			{"id":"2","firstName":"John","lastName":"Roe","email":{"emailAddress":"john.roe@example.com"},
+			 "positionData":{"jobTitle":"Engineering Manager"},"employmentDateData":{"hireDate":"2019-06-03"},"statusData":{"status":"Active"}}
+		]}`))
+	})
+	paycorServer := httptest.NewServer(paycor)
+
+	attribute := func(name, value string) map[string]interface{} {
+		ids := map[string]string{"Name": "82", "Email": "89", "Start Date": "91", "Status": "92"}
+		return map[string]interface{}{"objectTypeAttributeId": ids[name], "objectAttributeValues": []map[string]string{{"value": value}}}
+	}
+	jira := http.NewServeMux()
+	jira.HandleFunc("GET /objecttype/7/attributes", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"id":"82","name":"Name","type":0,"defaultType":{"id":0,"name":"Text"},"editable":true},
+			{"id":"89","name":"Email","type":0,"defaultType":{"id":0,"name":"Text"},"editable":true},
+			{"id":"91","name":"Start Date","type":0,"defaultType":{"id":4,"name":"Date"},"editable":true},
+			{"id":"92","name":"Status","type":7,"editable":true},
+			{"id":"87","name":"Job Role","type":1,"editable":true},
+			{"id":"86","name":"Manager Name","type":0,"defaultType":{"id":0,"name":"Text"},"editable":true}
+		]`))
+	})
+	jira.HandleFunc("GET /aql/objects", func(w http.ResponseWriter, r *http.Request) {
+		entries := []interface{}{}
+		if strings.Contains(r.URL.Query().Get("aql"), `"Employees"`) {
+			entries = append(entries, map[string]interface{}{
+				"id": "2", "objectKey": "EMP-2", "label": "John Roe",
+				"attributes": []interface{}{
+					attribute("Name", "John Roe"), attribute("Email", "john.roe@example.com"),
+					attribute("Start Date", "2019-06-03"), attribute("Status", "Active"),
+				},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"objectEntries": entries, "isLast": true})
+	})
+	jiraServer := httptest.NewServer(jira)
+
+	cfg := &psdi.Config{}
+	cfg.Paycor.PaycorTokenURLBase = paycorServer.URL + "/token"
+	cfg.Paycor.PaycorAPIBaseURL = paycorServer.URL
+	cfg.Paycor.PaycorClientID = "client"
+	cfg.Paycor.PaycorClientSecret = "secret"
+	cfg.Paycor.PaycorOcpApimSubscriptionKey = "subscription"
+	cfg.Paycor.PaycorRefreshToken = "refresh"
+	cfg.Paycor.PaycorLegalEntityID = "123456"
+	cfg.Paycor.HTTP.Timeout = 10 * time.Second
+	cfg.Jira.JiraSiteName = "example.atlassian.net"
+	cfg.Jira.JiraWorkspaceID = "workspace"
+	cfg.Jira.JiraAdminEmail = "admin@example.com"
+	cfg.Jira.JiraOrgAPIKey = "api-key"
+	cfg.Jira.JiraAssetsURL = jiraServer.URL
+	cfg.Jira.JiraEmployeeObjectTypeName = "Employees"
+	cfg.Jira.JiraEmployeeObjectTypeID = "7"
+	cfg.Jira.JiraRoleObjectTypeName = "Role"
+	cfg.Jira.JiraRoleObjectTypeID = "8"
+
+	return cfg, func() {
+		paycorServer.Close()
+		jiraServer.Close()
+	}
+}
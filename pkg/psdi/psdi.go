@@ -0,0 +1,174 @@
+// pkg/psdi/psdi.go
+
+// Package psdi is the public API of the Paycor to Jira Assets employee sync, for services that
+// embed the sync instead of running cmd/server.
+//
+// Everything exported here is stable. Config, the clients, the report types and the error types
+// are aliases of the implementation types, so their fields and methods are part of the API too,
+// but the packages they come from are not and may change.
+//
+// Every method that talks to Paycor or Jira takes a context. Cancelling it stops the work
+// promptly; the error returned then wraps ctx.Err(), so errors.Is(err, context.Canceled) holds.
+// Errors from the APIs can be inspected with errors.As against *JiraAPIError, *PaycorAPIError
+// and *PaycorTokenError.
+package psdi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Devon-ODell/PSDIv0.2/internal/config"
+	"github.com/Devon-ODell/PSDIv0.2/internal/jira"
+	"github.com/Devon-ODell/PSDIv0.2/internal/models"
+	"github.com/Devon-ODell/PSDIv0.2/internal/paycor"
+	"github.com/Devon-ODell/PSDIv0.2/internal/syncer"
+)
+
+// Config is the sync's configuration. LoadConfig reads it from the environment; callers may
+// adjust fields before passing it to New.
+type Config = config.AppConfig
+
+// PaycorClient and JiraClient are the API clients a Syncer uses.
+type (
+	PaycorClient = paycor.Client
+	JiraClient   = jira.Client
+)
+
+// Reports returned by the Syncer's operations.
+type (
+	Report           = syncer.SyncReport
+	ReportEntry      = syncer.ReportEntry
+	ValidationReport = syncer.ValidationReport
+	BackfillReport   = syncer.BackfillReport
+	ReconcileReport  = syncer.ReconcileReport
+)
+
+// Error types callers can match with errors.As.
+type (
+	JiraAPIError     = jira.APIError     // Jira answered with a non-2xx status
+	PaycorTokenError = paycor.TokenError // The Paycor refresh token exchange failed
+	PaycorAPIError   = paycor.APIError   // Paycor answered with a non-2xx status
+)
+
+// ErrReadOnlyField is returned, wrapped, when the Paycor field that receives the object key
+// can't be written.
+var ErrReadOnlyField = paycor.ErrReadOnlyField
+
+// Options control a Syncer. The zero value syncs everyone and writes to Jira.
+type Options struct {
+	DryRun bool     // Log and report what would change without writing to Jira; SYNC_DRY_RUN also enables it
+	Fields []string // Attribute names updates are restricted to; creates always send every attribute
+	Resume bool     // Skip employees an interrupted run already completed, per SYNC_CHECKPOINT_PATH
+	Limit  int      // Stop after syncing this many employees; 0 means no limit
+}
+
+// LoadConfig reads the configuration from the environment, as cmd/server does.
+func LoadConfig() (*Config, error) {
+	return config.Load()
+}
+
+// LoadAttributeMap loads an attribute ID map written by cmd/schemaBootstrap and returns the
+// number of entries loaded. The map is process-wide: it applies to every Syncer.
+func LoadAttributeMap(path string) (int, error) {
+	return models.LoadAttributeMap(path)
+}
+
+// NewPaycorClient creates a Paycor client from cfg.Paycor. The context is kept for token
+// refreshes, so it should live as long as the client. It makes no request; call the client's
+// CheckAccess to verify the credentials, scopes and legal entity up front.
+func NewPaycorClient(ctx context.Context, cfg *Config) (*PaycorClient, error) {
+	return paycor.NewClient(ctx, cfg.Paycor)
+}
+
+// NewJiraClient creates a Jira client from cfg.Jira.
+func NewJiraClient(cfg *Config) (*JiraClient, error) {
+	return jira.NewClient(cfg.Jira)
+}
+
+// Syncer runs the sync and its related operations. A Syncer is not safe for concurrent use;
+// run one operation at a time.
+type Syncer struct {
+	jiraClient *JiraClient
+	engine     *syncer.Syncer
+}
+
+// New creates a Syncer with new Paycor and Jira clients built from cfg.
+func New(ctx context.Context, cfg *Config, opts Options) (*Syncer, error) {
+	paycorClient, err := NewPaycorClient(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Paycor client: %w", err)
+	}
+	jiraClient, err := NewJiraClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Jira client: %w", err)
+	}
+	return NewWithClients(cfg, paycorClient, jiraClient, opts)
+}
+
+// NewWithClients creates a Syncer from already built clients. jiraClient may be nil when the
+// Syncer is only used for Validate, which never contacts Jira.
+// It returns an error if the options or the sync settings in cfg are invalid.
+func NewWithClients(cfg *Config, paycorClient *PaycorClient, jiraClient *JiraClient, opts Options) (*Syncer, error) {
+	if paycorClient == nil {
+		return nil, fmt.Errorf("a Paycor client is required")
+	}
+	fields := make([]string, 0, len(opts.Fields))
+	for _, field := range opts.Fields {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	engine, err := syncer.New(cfg, paycorClient, jiraClient, syncer.Options{
+		DryRun: opts.DryRun,
+		Fields: fields,
+		Resume: opts.Resume,
+		Limit:  opts.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Syncer{jiraClient: jiraClient, engine: engine}, nil
+}
+
+// CheckSchema verifies that every attribute the sync writes exists on the Jira Employee object
+// type and is writable, so schema drift is caught before any write.
+func (s *Syncer) CheckSchema(ctx context.Context) error {
+	if s.jiraClient == nil {
+		return fmt.Errorf("this Syncer has no Jira client")
+	}
+	return s.jiraClient.ValidateAttributeSchema(ctx)
+}
+
+// Run syncs every Paycor employee in scope to Jira. The report is returned even when Run fails
+// or is cancelled, covering the employees processed so far; per-employee failures are in the
+// report and do not make Run fail.
+func (s *Syncer) Run(ctx context.Context) (*Report, error) {
+	if s.jiraClient == nil {
+		return nil, fmt.Errorf("this Syncer has no Jira client")
+	}
+	return s.engine.RunSync(ctx)
+}
+
+// Validate checks every Paycor employee in scope against the validation rules without
+// contacting Jira.
+func (s *Syncer) Validate(ctx context.Context) (*ValidationReport, error) {
+	return s.engine.ValidateEmployees(ctx)
+}
+
+// BackfillPaycorIDs writes the Paycor employee ID onto Jira assets that lack it, matched by
+// email. With Options.DryRun the matches are only reported.
+func (s *Syncer) BackfillPaycorIDs(ctx context.Context) (*BackfillReport, error) {
+	if s.jiraClient == nil {
+		return nil, fmt.Errorf("this Syncer has no Jira client")
+	}
+	return s.engine.BackfillPaycorIDs(ctx)
+}
+
+// Reconcile reports who is in Paycor, in Jira, or both, without changing either.
+func (s *Syncer) Reconcile(ctx context.Context) (*ReconcileReport, error) {
+	if s.jiraClient == nil {
+		return nil, fmt.Errorf("this Syncer has no Jira client")
+	}
+	return s.engine.Reconcile(ctx)
+}